@@ -264,7 +264,7 @@ func NewInitApp(
 	)
 
 	app.mm.SetOrderEndBlockers(
-		crisis.ModuleName, gov.ModuleName, staking.ModuleName, register.ModuleName,
+		crisis.ModuleName, gov.ModuleName, staking.ModuleName, register.ModuleName, pot.ModuleName, sds.ModuleName,
 		// this line is used by starport scaffolding # 6.1
 	)
 
@@ -288,6 +288,11 @@ func NewInitApp(
 		// this line is used by starport scaffolding # 7
 	)
 
+	// only the stratos modules register their invariants, the register pools & the pot rewards
+	// are not tracked by the supply module, which would break the invariants of the sdk modules
+	for _, moduleName := range []string{register.ModuleName, pot.ModuleName, sds.ModuleName} {
+		app.mm.Modules[moduleName].RegisterInvariants(&app.crisisKeeper)
+	}
 	app.mm.RegisterRoutes(app.Router(), app.QueryRouter())
 
 	app.SetInitChainer(app.InitChainer)
@@ -296,6 +301,10 @@ func NewInitApp(
 
 	app.upgradeKeeper.SetUpgradeHandler(version.Version, func(ctx sdk.Context, plan upgrade.Plan) {
 		logger.Info("Upgrade Handler working")
+		app.registerKeeper.MigrateParams(ctx)
+		app.potKeeper.MigrateParams(ctx)
+		app.sdsKeeper.MigrateParams(ctx)
+		app.registerKeeper.MigrateGenesisUnissuedPrepay(ctx)
 	})
 	app.SetStoreLoader(bam.StoreLoaderWithUpgrade(&store.StoreUpgrades{
 		Renamed: []store.StoreRename{{
@@ -336,9 +345,38 @@ func (app *NewApp) InitChainer(ctx sdk.Context, req abci.RequestInitChain) abci.
 	var genesisState simapp.GenesisState
 
 	app.cdc.MustUnmarshalJSON(req.AppStateBytes, &genesisState)
+	if err := ValidateFoundationAccountBalance(app.cdc, genesisState); err != nil {
+		panic(err)
+	}
 	return app.mm.InitGenesis(ctx, genesisState)
 }
 
+// ValidateFoundationAccountBalance checks the foundation account balance of the pot genesis against the account in the auth genesis
+func ValidateFoundationAccountBalance(cdc *codec.Codec, genesisState simapp.GenesisState) error {
+	var potGenesis pot.GenesisState
+	if genesisState[pot.ModuleName] == nil {
+		return nil
+	}
+	if err := cdc.UnmarshalJSON(genesisState[pot.ModuleName], &potGenesis); err != nil {
+		return err
+	}
+
+	var authGenesis auth.GenesisState
+	if genesisState[auth.ModuleName] != nil {
+		if err := cdc.UnmarshalJSON(genesisState[auth.ModuleName], &authGenesis); err != nil {
+			return err
+		}
+	}
+	foundationAccountAddr := supply.NewModuleAddress(pot.FoundationAccount)
+	foundationAccountBalance := sdk.Coins{}
+	for _, account := range authGenesis.Accounts {
+		if account.GetAddress().Equals(foundationAccountAddr) {
+			foundationAccountBalance = account.GetCoins()
+		}
+	}
+	return pot.ValidateFoundationAccountBalance(potGenesis, foundationAccountBalance)
+}
+
 func (app *NewApp) BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock) abci.ResponseBeginBlock {
 	return app.mm.BeginBlock(ctx, req)
 }
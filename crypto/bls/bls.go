@@ -0,0 +1,175 @@
+package bls
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+
+	bls12381 "github.com/kilic/bls12-381"
+)
+
+const (
+	PrivKeySize   = 32
+	PubKeySize    = 48 // compressed G1 point
+	SignatureSize = 96 // compressed G2 point
+)
+
+var (
+	// domain separation tag of the signature scheme (public keys in G1, signatures in G2)
+	signatureDST = []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_")
+	// domain separation tag of the proof of possession
+	possessionDST = []byte("BLS_POP_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_")
+
+	ErrInvalidPrivKey   = errors.New("invalid BLS private key")
+	ErrInvalidPubKey    = errors.New("invalid BLS public key")
+	ErrInvalidSignature = errors.New("invalid BLS signature")
+	ErrEmptyPubKeys     = errors.New("no BLS public key to verify against")
+)
+
+// NewKeyPair generates a random BLS private key and its public key
+func NewKeyPair() (privKey, pubKey []byte, err error) {
+	g1 := bls12381.NewG1()
+	sk, err := rand.Int(rand.Reader, g1.Q())
+	if err != nil {
+		return nil, nil, err
+	}
+	privKey = make([]byte, PrivKeySize)
+	sk.FillBytes(privKey)
+	pubKey, err = PubKeyFromPrivKey(privKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return privKey, pubKey, nil
+}
+
+// PubKeyFromPrivKey returns the compressed public key of the given private key
+func PubKeyFromPrivKey(privKey []byte) ([]byte, error) {
+	g1 := bls12381.NewG1()
+	sk, err := scalarFromBytes(g1, privKey)
+	if err != nil {
+		return nil, err
+	}
+	pk := g1.MulScalarBig(g1.New(), g1.One(), sk)
+	return g1.ToCompressed(pk), nil
+}
+
+// Sign signs msg with the given private key
+func Sign(msg []byte, privKey []byte) ([]byte, error) {
+	return signWithDST(msg, privKey, signatureDST)
+}
+
+// AggregateSignatures combines several signatures into a single signature
+func AggregateSignatures(signatures ...[]byte) ([]byte, error) {
+	if len(signatures) == 0 {
+		return nil, ErrInvalidSignature
+	}
+	g2 := bls12381.NewG2()
+	aggregated := g2.Zero()
+	for _, signature := range signatures {
+		sig, err := signatureFromBytes(g2, signature)
+		if err != nil {
+			return nil, err
+		}
+		g2.Add(aggregated, aggregated, sig)
+	}
+	return g2.ToCompressed(aggregated), nil
+}
+
+// Verify checks an (aggregate) signature of msg signed by all of pubKeys
+func Verify(msg []byte, signature []byte, pubKeys ...[]byte) (bool, error) {
+	return verifyWithDST(msg, signature, signatureDST, pubKeys...)
+}
+
+// ProvePossession signs the public key of privKey, proving the ownership of the private key.
+// Aggregate verification is only safe against rogue key attacks with public keys whose possession has been proven.
+func ProvePossession(privKey []byte) ([]byte, error) {
+	pubKey, err := PubKeyFromPrivKey(privKey)
+	if err != nil {
+		return nil, err
+	}
+	return signWithDST(pubKey, privKey, possessionDST)
+}
+
+// VerifyPossession checks the proof of possession of pubKey
+func VerifyPossession(pubKey []byte, proof []byte) (bool, error) {
+	return verifyWithDST(pubKey, proof, possessionDST, pubKey)
+}
+
+func signWithDST(msg []byte, privKey []byte, dst []byte) ([]byte, error) {
+	g1 := bls12381.NewG1()
+	sk, err := scalarFromBytes(g1, privKey)
+	if err != nil {
+		return nil, err
+	}
+	g2 := bls12381.NewG2()
+	h, err := g2.HashToCurve(msg, dst)
+	if err != nil {
+		return nil, err
+	}
+	sig := g2.MulScalarBig(g2.New(), h, sk)
+	return g2.ToCompressed(sig), nil
+}
+
+func verifyWithDST(msg []byte, signature []byte, dst []byte, pubKeys ...[]byte) (bool, error) {
+	if len(pubKeys) == 0 {
+		return false, ErrEmptyPubKeys
+	}
+	g1 := bls12381.NewG1()
+	aggregatedPubKey := g1.Zero()
+	for _, pubKey := range pubKeys {
+		pk, err := pubKeyFromBytes(g1, pubKey)
+		if err != nil {
+			return false, err
+		}
+		g1.Add(aggregatedPubKey, aggregatedPubKey, pk)
+	}
+
+	g2 := bls12381.NewG2()
+	sig, err := signatureFromBytes(g2, signature)
+	if err != nil {
+		return false, err
+	}
+	h, err := g2.HashToCurve(msg, dst)
+	if err != nil {
+		return false, err
+	}
+
+	// e(pk, H(m)) == e(g1, sig)
+	engine := bls12381.NewEngine()
+	engine.AddPair(aggregatedPubKey, h)
+	engine.AddPairInv(g1.One(), sig)
+	return engine.Check(), nil
+}
+
+func scalarFromBytes(g1 *bls12381.G1, privKey []byte) (*big.Int, error) {
+	if len(privKey) != PrivKeySize {
+		return nil, ErrInvalidPrivKey
+	}
+	sk := new(big.Int).SetBytes(privKey)
+	if sk.Sign() == 0 || sk.Cmp(g1.Q()) >= 0 {
+		return nil, ErrInvalidPrivKey
+	}
+	return sk, nil
+}
+
+func pubKeyFromBytes(g1 *bls12381.G1, pubKey []byte) (*bls12381.PointG1, error) {
+	if len(pubKey) != PubKeySize {
+		return nil, ErrInvalidPubKey
+	}
+	pk, err := g1.FromCompressed(pubKey)
+	if err != nil || g1.IsZero(pk) {
+		return nil, ErrInvalidPubKey
+	}
+	return pk, nil
+}
+
+func signatureFromBytes(g2 *bls12381.G2, signature []byte) (*bls12381.PointG2, error) {
+	if len(signature) != SignatureSize {
+		return nil, ErrInvalidSignature
+	}
+	sig, err := g2.FromCompressed(signature)
+	if err != nil {
+		return nil, ErrInvalidSignature
+	}
+	return sig, nil
+}
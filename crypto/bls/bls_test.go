@@ -0,0 +1,79 @@
+package bls
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	privKey, pubKey, err := NewKeyPair()
+	require.NoError(t, err)
+	require.Len(t, pubKey, PubKeySize)
+
+	msg := []byte("volume report")
+	sig, err := Sign(msg, privKey)
+	require.NoError(t, err)
+	require.Len(t, sig, SignatureSize)
+
+	ok, err := Verify(msg, sig, pubKey)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = Verify([]byte("another report"), sig, pubKey)
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestAggregateVerify(t *testing.T) {
+	msg := []byte("volume report")
+	var pubKeys, sigs [][]byte
+	for i := 0; i < 3; i++ {
+		privKey, pubKey, err := NewKeyPair()
+		require.NoError(t, err)
+		sig, err := Sign(msg, privKey)
+		require.NoError(t, err)
+		pubKeys = append(pubKeys, pubKey)
+		sigs = append(sigs, sig)
+	}
+
+	aggregated, err := AggregateSignatures(sigs...)
+	require.NoError(t, err)
+
+	ok, err := Verify(msg, aggregated, pubKeys...)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// missing signer
+	ok, err = Verify(msg, aggregated, pubKeys[:2]...)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	_, err = Verify(msg, aggregated, []byte("bad key"))
+	require.Equal(t, ErrInvalidPubKey, err)
+	_, err = Verify(msg, aggregated)
+	require.Equal(t, ErrEmptyPubKeys, err)
+}
+
+func TestProofOfPossession(t *testing.T) {
+	privKey, pubKey, err := NewKeyPair()
+	require.NoError(t, err)
+	_, otherPubKey, err := NewKeyPair()
+	require.NoError(t, err)
+
+	proof, err := ProvePossession(privKey)
+	require.NoError(t, err)
+
+	ok, err := VerifyPossession(pubKey, proof)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = VerifyPossession(otherPubKey, proof)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// a proof of possession is not a valid signature of the public key bytes
+	ok, err = Verify(pubKey, proof, pubKey)
+	require.NoError(t, err)
+	require.False(t, ok)
+}
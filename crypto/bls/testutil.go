@@ -0,0 +1,14 @@
+package bls
+
+// GenPubKeyAndProof generates a random BLS public key and its proof of possession, it panics on failure and is meant for tests
+func GenPubKeyAndProof() (pubKey, proof []byte) {
+	privKey, pubKey, err := NewKeyPair()
+	if err != nil {
+		panic(err)
+	}
+	proof, err = ProvePossession(privKey)
+	if err != nil {
+		panic(err)
+	}
+	return pubKey, proof
+}
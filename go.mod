@@ -7,6 +7,7 @@ require (
 	github.com/cosmos/cosmos-sdk v0.39.2
 	github.com/golang/mock v1.4.3 // indirect
 	github.com/gorilla/mux v1.7.4
+	github.com/kilic/bls12-381 v0.1.0
 	github.com/onsi/ginkgo v1.8.0 // indirect
 	github.com/onsi/gomega v1.5.0 // indirect
 	github.com/spf13/afero v1.2.2 // indirect
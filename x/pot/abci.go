@@ -13,7 +13,10 @@ func BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock, k keeper.Keeper)
 	// 	TODO: fill out if your application requires beginblock, if not you can delete this function
 }
 
-// EndBlocker called every block, process inflation, update validator set.
+// EndBlocker called every block, finalizes the volume reports agreed by the meta nodes,
+// matures the pot rewards on the maturation schedule and unsuspends the nodes whose suspend duration is over.
 func EndBlocker(ctx sdk.Context, k keeper.Keeper) {
-	// 	TODO: fill out if your application requires endblock, if not you can delete this function
+	k.FinalizeVolumeReports(ctx)
+	k.RewardMature(ctx)
+	k.UnsuspendMatureNodes(ctx)
 }
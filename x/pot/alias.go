@@ -14,13 +14,17 @@ const (
 )
 
 var (
-	NewKeeper               = keeper.NewKeeper
-	RegisterCodec           = types.RegisterCodec
-	ParamKeyTable           = types.ParamKeyTable
-	NewGenesisState         = types.NewGenesisState
-	NewMsgFoundationDeposit = types.NewMsgFoundationDeposit
+	NewKeeper                        = keeper.NewKeeper
+	RegisterInvariants               = keeper.RegisterInvariants
+	AllInvariants                    = keeper.AllInvariants
+	RegisterCodec                    = types.RegisterCodec
+	ParamKeyTable                    = types.ParamKeyTable
+	NewGenesisState                  = types.NewGenesisState
+	ValidateFoundationAccountBalance = types.ValidateFoundationAccountBalance
+	NewMsgFoundationDeposit          = types.NewMsgFoundationDeposit
 )
 
 type (
-	Keeper = keeper.Keeper
+	Keeper       = keeper.Keeper
+	GenesisState = types.GenesisState
 )
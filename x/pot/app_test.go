@@ -2,12 +2,16 @@ package pot
 
 import (
 	"testing"
+	"time"
 
+	"github.com/stratosnet/stratos-chain/crypto/bls"
 	stratos "github.com/stratosnet/stratos-chain/types"
 	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/tmhash"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 
+	"github.com/cosmos/cosmos-sdk/store/prefix"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/bank"
@@ -15,6 +19,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/staking"
 	"github.com/cosmos/cosmos-sdk/x/supply"
 	supplyexported "github.com/cosmos/cosmos-sdk/x/supply/exported"
+	"github.com/stratosnet/stratos-chain/x/pot/keeper"
 	"github.com/stratosnet/stratos-chain/x/pot/types"
 	"github.com/stratosnet/stratos-chain/x/register"
 )
@@ -31,25 +36,36 @@ var (
 )
 
 // initialize data of volume report
-func setupMsgVolumeReport(newEpoch int64) types.MsgVolumeReport {
+func setupMsgVolumeReport(newEpoch int64, reporter stratos.SdsAddress, reporterOwner sdk.AccAddress) types.MsgVolumeReport {
 	volume1 := types.NewSingleWalletVolume(resOwner1, resourceNodeVolume1)
 	volume2 := types.NewSingleWalletVolume(resOwner2, resourceNodeVolume2)
 	volume3 := types.NewSingleWalletVolume(resOwner3, resourceNodeVolume3)
 
 	nodesVolume := []types.SingleWalletVolume{volume1, volume2, volume3}
-	reporter := idxNodeNetworkId1
 	epoch := sdk.NewInt(newEpoch)
 	reportReference := "report for epoch " + epoch.String()
-	reporterOwner := idxOwner1
 
-	pubKeys := make([][]byte, 1)
-	for i := range pubKeys {
-		pubKeys[i] = make([]byte, 1)
-	}
-
-	signature := types.NewBLSSignatureInfo(pubKeys, []byte("signature"), []byte("txData"))
+	volumeReportMsg := types.NewMsgVolumeReport(nodesVolume, nil, reporter, epoch, reportReference, reporterOwner, types.BLSSignatureInfo{})
+	return signMsgVolumeReport(volumeReportMsg)
+}
 
-	volumeReportMsg := types.NewMsgVolumeReport(nodesVolume, reporter, epoch, reportReference, reporterOwner, signature)
+// all meta nodes sign the report with their BLS keys
+func signMsgVolumeReport(volumeReportMsg types.MsgVolumeReport) types.MsgVolumeReport {
+	txData := tmhash.Sum(volumeReportMsg.GetBLSSignBytes())
+	var pubKeys, signatures [][]byte
+	for _, privKey := range idxNodeBLSPrivKeys {
+		signature, err := bls.Sign(txData, privKey)
+		if err != nil {
+			panic(err)
+		}
+		signatures = append(signatures, signature)
+		pubKeys = append(pubKeys, blsPubKeyFromPrivKey(privKey))
+	}
+	aggregatedSignature, err := bls.AggregateSignatures(signatures...)
+	if err != nil {
+		panic(err)
+	}
+	volumeReportMsg.BLSSignature = types.NewBLSSignatureInfo(pubKeys, aggregatedSignature, txData)
 
 	return volumeReportMsg
 }
@@ -160,7 +176,7 @@ func TestPotVolumeReportMsgs(t *testing.T) {
 
 		ctx.Logger().Info("*****************************************************************************")
 		/********************* prepare tx data *********************/
-		volumeReportMsg := setupMsgVolumeReport(i + 1)
+		volumeReportMsg := setupMsgVolumeReport(i+1, idxNodeNetworkId1, idxOwner1)
 
 		lastTotalMinedToken := k.GetTotalMinedTokens(ctx)
 		ctx.Logger().Info("last committed mined token = " + lastTotalMinedToken.String())
@@ -261,6 +277,11 @@ func TestPotVolumeReportMsgs(t *testing.T) {
 		lastUnissuedPrepay := k.RegisterKeeper.GetTotalUnissuedPrepay(ctx)
 		lastMatureTotalOfResNode1 := k.GetMatureTotalReward(ctx, resOwner2)
 
+		/********************* simulate distribution *********************/
+		simulation := simulateDistribution(t, ctx, k, volumeReportMsg)
+		require.True(t, k.GetLastReportedEpoch(ctx).LT(volumeReportMsg.Epoch))
+		require.Equal(t, lastFoundationAccBalance, bankKeeper.GetCoins(ctx, foundationAccAddr))
+
 		/********************* deliver tx *********************/
 
 		idxOwnerAcc1 := mApp.AccountKeeper.GetAccount(ctx, idxOwner1)
@@ -269,9 +290,35 @@ func TestPotVolumeReportMsgs(t *testing.T) {
 
 		SignCheckDeliver(t, mApp.Cdc, mApp.BaseApp, header, []sdk.Msg{volumeReportMsg}, []uint64{ownerAccNum}, []uint64{ownerAccSeq}, true, true, idxOwnerPrivKey1)
 
+		/********************* the report is pending until the quorum of meta nodes agrees *********************/
+		header = abci.Header{Height: mApp.LastBlockHeight() + 1}
+		ctx = mApp.BaseApp.NewContext(true, header)
+		require.True(t, k.GetLastReportedEpoch(ctx).LT(volumeReportMsg.Epoch))
+
+		// a reporter cannot submit twice for the same epoch
+		idxOwnerAcc1 = mApp.AccountKeeper.GetAccount(ctx, idxOwner1)
+		SignCheckDeliver(t, mApp.Cdc, mApp.BaseApp, header, []sdk.Msg{volumeReportMsg}, []uint64{idxOwnerAcc1.GetAccountNumber()}, []uint64{idxOwnerAcc1.GetSequence()}, false, false, idxOwnerPrivKey1)
+
+		// a disagreeing reporter does not block the consensus and is flagged once the report is finalized
+		header = abci.Header{Height: mApp.LastBlockHeight() + 1}
+		ctx = mApp.BaseApp.NewContext(true, header)
+		volumeReportMsg3 := setupMsgVolumeReport(i+1, idxNodeNetworkId3, idxOwner3)
+		volumeReportMsg3.ReportReference = "another " + volumeReportMsg3.ReportReference
+		volumeReportMsg3 = signMsgVolumeReport(volumeReportMsg3)
+		idxOwnerAcc3 := mApp.AccountKeeper.GetAccount(ctx, idxOwner3)
+		SignCheckDeliver(t, mApp.Cdc, mApp.BaseApp, header, []sdk.Msg{volumeReportMsg3}, []uint64{idxOwnerAcc3.GetAccountNumber()}, []uint64{idxOwnerAcc3.GetSequence()}, true, true, idxOwnerPrivKey3)
+
+		header = abci.Header{Height: mApp.LastBlockHeight() + 1}
+		ctx = mApp.BaseApp.NewContext(true, header)
+		volumeReportMsg2 := setupMsgVolumeReport(i+1, idxNodeNetworkId2, idxOwner2)
+		idxOwnerAcc2 := mApp.AccountKeeper.GetAccount(ctx, idxOwner2)
+		SignCheckDeliver(t, mApp.Cdc, mApp.BaseApp, header, []sdk.Msg{volumeReportMsg2}, []uint64{idxOwnerAcc2.GetAccountNumber()}, []uint64{idxOwnerAcc2.GetSequence()}, true, true, idxOwnerPrivKey2)
+
 		/********************* commit & check result *********************/
 		header = abci.Header{Height: mApp.LastBlockHeight() + 1}
 		ctx = mApp.BaseApp.NewContext(true, header)
+		require.Equal(t, volumeReportMsg.Epoch, k.GetLastReportedEpoch(ctx))
+		require.Equal(t, i+1, k.GetReporterMismatchCount(ctx, idxNodeNetworkId3))
 
 		//TODO: recovery when shift to main net
 		checkResult(t, ctx, k, registerKeeper,
@@ -282,6 +329,9 @@ func TestPotVolumeReportMsgs(t *testing.T) {
 			lastMatureTotalOfResNode1,
 			slashingAmtSetup,
 		) // Main net
+		checkWalletVolumes(t, ctx, k, volumeReportMsg)
+		checkSimulation(t, ctx, k, volumeReportMsg, simulation)
+		checkInvariants(t, ctx, k, registerKeeper)
 
 		//TODO: remove when shift to main net
 		//checkResultForIncentiveTestnet(
@@ -298,6 +348,300 @@ func TestPotVolumeReportMsgs(t *testing.T) {
 	}
 }
 
+func TestRewardPreference(t *testing.T) {
+	mApp, k, _, _, _, registerKeeper := getMockApp(t)
+	accs := setupAccounts(mApp)
+	mock.SetGenesis(mApp, accs)
+
+	/********************* restaking to a node of another owner is rejected *********************/
+	header := abci.Header{Height: mApp.LastBlockHeight() + 1}
+	ctx := mApp.BaseApp.NewContext(true, header)
+	preferenceMsg := types.NewMsgSetRewardPreference(resOwner1, types.RewardActionRestake, nil, resNodeNetworkId2)
+	resOwnerAcc1 := mApp.AccountKeeper.GetAccount(ctx, resOwner1)
+	SignCheckDeliver(t, mApp.Cdc, mApp.BaseApp, header, []sdk.Msg{preferenceMsg}, []uint64{resOwnerAcc1.GetAccountNumber()}, []uint64{resOwnerAcc1.GetSequence()}, false, false, resOwnerPrivKey1)
+
+	/********************* restake to the own resource node *********************/
+	header = abci.Header{Height: mApp.LastBlockHeight() + 1}
+	ctx = mApp.BaseApp.NewContext(true, header)
+	preferenceMsg = types.NewMsgSetRewardPreference(resOwner1, types.RewardActionRestake, nil, resNodeNetworkId1)
+	resOwnerAcc1 = mApp.AccountKeeper.GetAccount(ctx, resOwner1)
+	SignCheckDeliver(t, mApp.Cdc, mApp.BaseApp, header, []sdk.Msg{preferenceMsg}, []uint64{resOwnerAcc1.GetAccountNumber()}, []uint64{resOwnerAcc1.GetSequence()}, true, true, resOwnerPrivKey1)
+
+	header = abci.Header{Height: k.MaturationInterval(ctx)}
+	ctx = mApp.BaseApp.NewContext(true, header)
+	preference, found := k.GetRewardPreference(ctx, resOwner1)
+	require.True(t, found)
+	require.Equal(t, types.RewardActionRestake, preference.Action)
+
+	/********************* mature rewards are staked to the resource node *********************/
+	reward := sdk.NewCoins(sdk.NewCoin(k.BondDenom(ctx), sdk.NewInt(1000)))
+	k.SetIndividualReward(ctx, resOwner1, sdk.OneInt(), types.NewReward(resOwner1, sdk.Coins{}, reward))
+	k.SetImmatureTotalReward(ctx, resOwner1, reward)
+	resourceNode, _ := registerKeeper.GetResourceNode(ctx, resNodeNetworkId1)
+	lastTokens := resourceNode.GetTokens()
+
+	// the rewards mature on the block schedule even though no volume is reported
+	k.RewardMature(ctx)
+	require.Equal(t, sdk.OneInt(), k.GetLastMaturedEpoch(ctx))
+
+	require.True(t, k.GetMatureTotalReward(ctx, resOwner1).IsZero())
+	resourceNode, _ = registerKeeper.GetResourceNode(ctx, resNodeNetworkId1)
+	require.Equal(t, lastTokens.Add(sdk.NewInt(1000)), resourceNode.GetTokens())
+}
+
+func TestSlashingResourceNodeStake(t *testing.T) {
+	mApp, k, _, _, _, registerKeeper := getMockApp(t)
+	accs := setupAccounts(mApp)
+	mock.SetGenesis(mApp, accs)
+
+	header := abci.Header{Height: mApp.LastBlockHeight() + 1, Time: time.Now()}
+	ctx := mApp.BaseApp.NewContext(true, header)
+	params := k.GetParams(ctx)
+	params.StakeSlashFraction = sdk.NewDecWithPrec(1, 1)
+	params.SuspendDuration = time.Hour
+	k.SetParams(ctx, params)
+
+	/********************* 10% of the stake is burnt & the node is suspended for an hour *********************/
+	lastBondedToken := registerKeeper.GetResourceNodeBondedToken(ctx)
+	_, stakeSlashed, _, err := k.SlashingResourceNode(ctx, resNodeNetworkId1, resOwner1, sdk.NewInt(100), true)
+	require.NoError(t, err)
+	require.Equal(t, resNodeInitialStake1.QuoRaw(10), stakeSlashed)
+
+	resourceNode, _ := registerKeeper.GetResourceNode(ctx, resNodeNetworkId1)
+	require.Equal(t, resNodeInitialStake1.Sub(stakeSlashed), resourceNode.GetTokens())
+	require.Equal(t, lastBondedToken.Amount.Sub(stakeSlashed), registerKeeper.GetResourceNodeBondedToken(ctx).Amount)
+	require.True(t, resourceNode.Suspend)
+
+	k.UnsuspendMatureNodes(ctx)
+	resourceNode, _ = registerKeeper.GetResourceNode(ctx, resNodeNetworkId1)
+	require.True(t, resourceNode.Suspend)
+
+	/********************* a renewed suspension skips the queue entry of the previous one *********************/
+	ctx = ctx.WithBlockTime(header.Time.Add(time.Hour / 2))
+	_, _, _, err = k.SlashingResourceNode(ctx, resNodeNetworkId1, resOwner1, sdk.NewInt(100), true)
+	require.NoError(t, err)
+	ctx = ctx.WithBlockTime(header.Time.Add(time.Hour))
+	k.UnsuspendMatureNodes(ctx)
+	resourceNode, _ = registerKeeper.GetResourceNode(ctx, resNodeNetworkId1)
+	require.True(t, resourceNode.Suspend)
+
+	/********************* the node is unsuspended once the suspend duration is over *********************/
+	ctx = ctx.WithBlockTime(header.Time.Add(time.Hour / 2).Add(time.Hour))
+	k.UnsuspendMatureNodes(ctx)
+	resourceNode, _ = registerKeeper.GetResourceNode(ctx, resNodeNetworkId1)
+	require.False(t, resourceNode.Suspend)
+	_, found := registerKeeper.GetNodeSuspendedUntil(ctx, resNodeNetworkId1)
+	require.False(t, found)
+	checkInvariants(t, ctx, k, registerKeeper)
+}
+
+func TestUnsuspendNode(t *testing.T) {
+	mApp, k, _, _, _, registerKeeper := getMockApp(t)
+	accs := setupAccounts(mApp)
+	mock.SetGenesis(mApp, accs)
+
+	header := abci.Header{Height: mApp.LastBlockHeight() + 1, Time: time.Now()}
+	ctx := mApp.BaseApp.NewContext(true, header)
+
+	_, _, _, err := k.SlashingResourceNode(ctx, resNodeNetworkId1, resOwner1, sdk.NewInt(100), true)
+	require.NoError(t, err)
+	suspendedTime, found := registerKeeper.GetNodeSuspendedTime(ctx, resNodeNetworkId1)
+	require.True(t, found)
+	require.True(t, suspendedTime.Equal(header.Time))
+
+	/********************* the node can not be unsuspended by another owner or before the min suspend period *********************/
+	err = registerKeeper.UnsuspendNode(ctx, resNodeNetworkId1, resOwner2, false)
+	require.Error(t, err)
+	err = registerKeeper.UnsuspendNode(ctx, resNodeNetworkId1, resOwner1, false)
+	require.Equal(t, register.ErrMinSuspendPeriod, err)
+
+	/********************* the slashing debt of the owner must be cleared *********************/
+	ctx = ctx.WithBlockTime(header.Time.Add(registerKeeper.MinSuspendPeriod(ctx)))
+	registerKeeper.SetSlashing(ctx, resOwner1, sdk.NewInt(100))
+	err = registerKeeper.UnsuspendNode(ctx, resNodeNetworkId1, resOwner1, false)
+	require.Equal(t, register.ErrSlashingNotCleared, err)
+
+	registerKeeper.SetSlashing(ctx, resOwner1, sdk.ZeroInt())
+	err = registerKeeper.UnsuspendNode(ctx, resNodeNetworkId1, resOwner1, false)
+	require.NoError(t, err)
+	resourceNode, _ := registerKeeper.GetResourceNode(ctx, resNodeNetworkId1)
+	require.False(t, resourceNode.Suspend)
+	_, found = registerKeeper.GetNodeSuspendedTime(ctx, resNodeNetworkId1)
+	require.False(t, found)
+
+	err = registerKeeper.UnsuspendNode(ctx, resNodeNetworkId1, resOwner1, false)
+	require.Equal(t, register.ErrNodeNotSuspended, err)
+
+	/********************* a suspension for a fixed duration can not be ended early *********************/
+	params := k.GetParams(ctx)
+	params.SuspendDuration = 2 * registerKeeper.MinSuspendPeriod(ctx)
+	k.SetParams(ctx, params)
+	_, _, _, err = k.SlashingResourceNode(ctx, resNodeNetworkId1, resOwner1, sdk.NewInt(100), true)
+	require.NoError(t, err)
+	registerKeeper.SetSlashing(ctx, resOwner1, sdk.ZeroInt())
+
+	suspendedAt := ctx.BlockHeader().Time
+	ctx = ctx.WithBlockTime(suspendedAt.Add(registerKeeper.MinSuspendPeriod(ctx)))
+	err = registerKeeper.UnsuspendNode(ctx, resNodeNetworkId1, resOwner1, false)
+	require.Equal(t, register.ErrSuspensionNotExpired, err)
+
+	ctx = ctx.WithBlockTime(suspendedAt.Add(params.SuspendDuration))
+	err = registerKeeper.UnsuspendNode(ctx, resNodeNetworkId1, resOwner1, false)
+	require.NoError(t, err)
+	_, found = registerKeeper.GetNodeSuspendedUntil(ctx, resNodeNetworkId1)
+	require.False(t, found)
+}
+
+// check the volumes of the report are stored and can be queried by epoch & by wallet
+// check the invariants of the register & pot modules still hold
+func TestConsumeOzone(t *testing.T) {
+	mApp, k, _, _, _, registerKeeper := getMockApp(t)
+	accs := setupAccounts(mApp)
+	mock.SetGenesis(mApp, accs)
+
+	header := abci.Header{Height: mApp.LastBlockHeight() + 1}
+	ctx := mApp.BaseApp.NewContext(true, header)
+	handler := NewHandler(k)
+	registerKeeper.AddOzoneBalance(ctx, foundationDepositorAccAddr, sdk.NewInt(1000))
+
+	/********************* a consumer cannot be reported twice *********************/
+	consumerVolume := types.NewSingleWalletVolume(foundationDepositorAccAddr, sdk.NewInt(600))
+	volumeReportMsg := setupMsgVolumeReport(1, idxNodeNetworkId1, idxOwner1)
+	volumeReportMsg.ConsumerVolumes = []types.SingleWalletVolume{consumerVolume, consumerVolume}
+	require.Equal(t, types.ErrDuplicateConsumer, volumeReportMsg.ValidateBasic())
+
+	/********************* consumption beyond the ozone balance is rejected *********************/
+	volumeReportMsg.ConsumerVolumes = []types.SingleWalletVolume{types.NewSingleWalletVolume(foundationDepositorAccAddr, sdk.NewInt(1001))}
+	_, err := handler(ctx, signMsgVolumeReport(volumeReportMsg))
+	require.True(t, types.ErrInsufficientOzoneBalance.Is(err))
+
+	volumeReportMsg.ConsumerVolumes = []types.SingleWalletVolume{consumerVolume}
+	_, err = handler(ctx, signMsgVolumeReport(volumeReportMsg))
+	require.NoError(t, err)
+	submissions := k.GetAllVolumeReportSubmissions(ctx)
+	require.Len(t, submissions, 1)
+	require.Equal(t, volumeReportMsg.ConsumerVolumes, submissions[0].ConsumerVolumes)
+
+	/********************* the balance is debited, capped at the remaining balance *********************/
+	k.ConsumeOzone(ctx, volumeReportMsg.ConsumerVolumes, sdk.OneInt())
+	require.Equal(t, sdk.NewInt(400), registerKeeper.GetOzoneBalance(ctx, foundationDepositorAccAddr))
+	k.ConsumeOzone(ctx, volumeReportMsg.ConsumerVolumes, sdk.NewInt(2))
+	require.True(t, registerKeeper.GetOzoneBalance(ctx, foundationDepositorAccAddr).IsZero())
+}
+
+func TestVolumeReportValidation(t *testing.T) {
+	mApp, k, _, _, _, _ := getMockApp(t)
+	accs := setupAccounts(mApp)
+	mock.SetGenesis(mApp, accs)
+
+	header := abci.Header{Height: mApp.LastBlockHeight() + 1}
+	ctx := mApp.BaseApp.NewContext(true, header)
+	handler := NewHandler(k)
+
+	/********************* a report without any volume is rejected *********************/
+	volumeReportMsg := setupMsgVolumeReport(1, idxNodeNetworkId1, idxOwner1)
+	zeroVolumes := []types.SingleWalletVolume{types.NewSingleWalletVolume(resOwner1, sdk.ZeroInt())}
+	zeroReportMsg := volumeReportMsg
+	zeroReportMsg.WalletVolumes = zeroVolumes
+	require.Equal(t, types.ErrZeroTotalVolume, zeroReportMsg.ValidateBasic())
+
+	/********************* only the owner of the reporter can submit its report *********************/
+	_, err := handler(ctx, setupMsgVolumeReport(1, idxNodeNetworkId1, idxOwner2))
+	require.Equal(t, types.ErrNotTheOwner, err)
+
+	/********************* a distribution failing in EndBlocker leaves the epoch retryable *********************/
+	// the foundation account has no deposit, so the distribution fails
+	for _, reporter := range []stratos.SdsAddress{idxNodeNetworkId1, idxNodeNetworkId2, idxNodeNetworkId3} {
+		submission := types.NewVolumeReportSubmission(sdk.OneInt(), reporter, volumeReportMsg.BLSSignature.TxData,
+			volumeReportMsg.WalletVolumes, nil, volumeReportMsg.ReportReference, "")
+		k.SetVolumeReportSubmission(ctx, submission)
+	}
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	require.NotPanics(t, func() { k.FinalizeVolumeReports(ctx) })
+	require.Len(t, k.GetAllVolumeReportSubmissions(ctx), 3)
+	require.True(t, k.GetLastReportedEpoch(ctx).IsZero())
+	require.Equal(t, types.EventTypeVolumeReportFailed, ctx.EventManager().Events()[0].Type)
+}
+
+func checkInvariants(t *testing.T, ctx sdk.Context, k Keeper, registerKeeper register.Keeper) {
+	msg, broken := register.AllInvariants(registerKeeper)(ctx)
+	require.False(t, broken, msg)
+	msg, broken = AllInvariants(k)(ctx)
+	require.False(t, broken, msg)
+}
+
+func checkWalletVolumes(t *testing.T, ctx sdk.Context, k Keeper, volumeReportMsg types.MsgVolumeReport) {
+	reportRecord := k.GetVolumeReport(ctx, volumeReportMsg.Epoch)
+	require.Equal(t, volumeReportMsg.WalletVolumes, reportRecord.WalletVolumes)
+
+	querier := keeper.NewQuerier(k)
+	params := types.NewQueryWalletVolumesByEpochParams(1, 0, volumeReportMsg.Epoch)
+	bz, err := querier(ctx, []string{keeper.QueryWalletVolumesByEpoch}, abci.RequestQuery{Data: types.ModuleCdc.MustMarshalJSON(params)})
+	require.NoError(t, err)
+	var epochRecords []types.WalletVolumeRecord
+	types.ModuleCdc.MustUnmarshalJSON(bz, &epochRecords)
+	require.Len(t, epochRecords, len(volumeReportMsg.WalletVolumes))
+
+	individualReward, found := k.GetIndividualReward(ctx, resOwner1, volumeReportMsg.Epoch.Add(sdk.NewInt(k.MatureEpoch(ctx))))
+	require.True(t, found)
+
+	historyParams := types.NewQueryWalletVolumeHistoryParams(1, 0, resOwner1)
+	bz, err = querier(ctx, []string{keeper.QueryWalletVolumeHistory}, abci.RequestQuery{Data: types.ModuleCdc.MustMarshalJSON(historyParams)})
+	require.NoError(t, err)
+	var history []types.WalletVolumeRecord
+	types.ModuleCdc.MustUnmarshalJSON(bz, &history)
+	require.Len(t, history, int(volumeReportMsg.Epoch.Int64()))
+
+	latest := history[len(history)-1]
+	require.Equal(t, volumeReportMsg.Epoch, latest.Epoch)
+	require.Equal(t, volumeReportMsg.WalletVolumes[0].Volume, latest.Volume)
+	require.Equal(t, individualReward.RewardFromMiningPool, latest.RewardFromMiningPool)
+	require.Equal(t, individualReward.RewardFromTrafficPool, latest.RewardFromTrafficPool)
+}
+
+func TestSimulateDistributionValidation(t *testing.T) {
+	mApp, k, _, _, _, _ := getMockApp(t)
+	accs := setupAccounts(mApp)
+	mock.SetGenesis(mApp, accs)
+
+	header := abci.Header{Height: mApp.LastBlockHeight() + 1}
+	ctx := mApp.BaseApp.NewContext(true, header)
+	querier := keeper.NewQuerier(k)
+
+	invalidVolumes := map[error][]types.SingleWalletVolume{
+		types.ErrEmptyWalletVolumes: nil,
+		types.ErrZeroTotalVolume:    {types.NewSingleWalletVolume(resOwner1, sdk.ZeroInt())},
+		types.ErrNegativeVolume:     {types.NewSingleWalletVolume(resOwner1, sdk.NewInt(-1))},
+	}
+	for expectedErr, walletVolumes := range invalidVolumes {
+		params := types.NewQuerySimulateDistributionParams(sdk.OneInt(), walletVolumes)
+		_, err := querier(ctx, []string{keeper.QuerySimulateDistribution}, abci.RequestQuery{Data: types.ModuleCdc.MustMarshalJSON(params)})
+		require.Equal(t, expectedErr, err)
+	}
+}
+
+func simulateDistribution(t *testing.T, ctx sdk.Context, k Keeper, volumeReportMsg types.MsgVolumeReport) types.DistributionSimulation {
+	querier := keeper.NewQuerier(k)
+	params := types.NewQuerySimulateDistributionParams(volumeReportMsg.Epoch, volumeReportMsg.WalletVolumes)
+	bz, err := querier(ctx, []string{keeper.QuerySimulateDistribution}, abci.RequestQuery{Data: types.ModuleCdc.MustMarshalJSON(params)})
+	require.NoError(t, err)
+	var simulation types.DistributionSimulation
+	types.ModuleCdc.MustUnmarshalJSON(bz, &simulation)
+	return simulation
+}
+
+// check the simulated rewards are the ones distributed by the report
+func checkSimulation(t *testing.T, ctx sdk.Context, k Keeper, volumeReportMsg types.MsgVolumeReport, simulation types.DistributionSimulation) {
+	require.Equal(t, volumeReportMsg.Epoch, simulation.Epoch)
+	require.NotEmpty(t, simulation.Rewards)
+	for _, reward := range simulation.Rewards {
+		individualReward, found := k.GetIndividualReward(ctx, reward.WalletAddress, volumeReportMsg.Epoch.Add(sdk.NewInt(k.MatureEpoch(ctx))))
+		require.True(t, found)
+		require.Equal(t, individualReward.RewardFromMiningPool, reward.RewardFromMiningPool)
+		require.Equal(t, individualReward.RewardFromTrafficPool, reward.RewardFromTrafficPool)
+	}
+}
+
 //for incentive test net
 //func checkResultForIncentiveTestnet(t *testing.T, ctx sdk.Context, k Keeper,
 //	currentEpoch sdk.Int,
@@ -463,6 +807,93 @@ func checkValidator(t *testing.T, mApp *mock.App, stakingKeeper staking.Keeper,
 	return validator
 }
 
+func TestExportImportGenesis(t *testing.T) {
+	mApp, k, _, bankKeeper, _, _ := getMockApp(t)
+	accs := setupAccounts(mApp)
+	mock.SetGenesis(mApp, accs)
+
+	header := abci.Header{Height: mApp.LastBlockHeight() + 1, Time: time.Now()}
+	ctx := mApp.BaseApp.NewContext(true, header)
+
+	/********************* populate the pot state *********************/
+	epoch := sdk.NewInt(1)
+	reward := sdk.NewCoins(sdk.NewCoin(k.BondDenom(ctx), sdk.NewInt(1000)))
+	k.SetLastReportedEpoch(ctx, epoch)
+	k.SetLastMaturedEpoch(ctx, epoch)
+	k.SetMinedTokens(ctx, epoch, sdk.NewCoin(types.DefaultRewardDenom, sdk.NewInt(1000)))
+	k.SetIndividualReward(ctx, resOwner1, epoch.AddRaw(k.MatureEpoch(ctx)), types.NewReward(resOwner1, sdk.Coins{}, reward))
+	k.SetImmatureTotalReward(ctx, resOwner1, reward)
+	k.SetMatureTotalReward(ctx, resOwner2, reward)
+	k.SetVolumeReport(ctx, epoch, types.NewReportRecord(idxNodeNetworkId1, "reference", "hash", nil))
+	k.SetReporterMismatchCount(ctx, idxNodeNetworkId1, 1)
+	k.SetRewardPreference(ctx, types.NewRewardPreference(resOwner1, types.RewardActionRestake, nil, resNodeNetworkId1))
+	foundationAddr := k.SupplyKeeper.GetModuleAddress(types.FoundationAccount)
+	_, err := bankKeeper.AddCoins(ctx, foundationAddr, reward)
+	require.NoError(t, err)
+
+	exported := ExportGenesis(ctx, k)
+	require.NoError(t, types.ValidateGenesis(exported))
+	bz := mApp.Cdc.MustMarshalJSON(exported)
+
+	/********************* import the exported state into a new app *********************/
+	newApp, newKeeper, _, newBankKeeper, _, _ := getMockApp(t)
+	mock.SetGenesis(newApp, accs)
+	newCtx := newApp.BaseApp.NewContext(true, header)
+
+	var imported types.GenesisState
+	newApp.Cdc.MustUnmarshalJSON(bz, &imported)
+	require.Error(t, types.ValidateFoundationAccountBalance(imported, newBankKeeper.GetCoins(newCtx, foundationAddr)))
+
+	_, err = newBankKeeper.AddCoins(newCtx, foundationAddr, reward)
+	require.NoError(t, err)
+	require.NoError(t, types.ValidateFoundationAccountBalance(imported, newBankKeeper.GetCoins(newCtx, foundationAddr)))
+	InitGenesis(newCtx, newKeeper, imported)
+	require.Equal(t, bz, newApp.Cdc.MustMarshalJSON(ExportGenesis(newCtx, newKeeper)))
+
+	/********************* individual rewards of a genesis without epochs mature right after the last reported epoch *********************/
+	legacyApp, legacyKeeper, _, _, _, _ := getMockApp(t)
+	mock.SetGenesis(legacyApp, accs)
+	legacyCtx := legacyApp.BaseApp.NewContext(true, header)
+
+	legacy := types.DefaultGenesisState()
+	legacy.LastReportedEpoch = epoch.Int64()
+	legacy.IndividualRewardInfo = []types.Reward{types.NewReward(resOwner1, sdk.Coins{}, reward)}
+	legacyBz := legacyApp.Cdc.MustMarshalJSON(legacy)
+	var legacyImported types.GenesisState
+	legacyApp.Cdc.MustUnmarshalJSON(legacyBz, &legacyImported)
+	require.NoError(t, types.ValidateGenesis(legacyImported))
+	InitGenesis(legacyCtx, legacyKeeper, legacyImported)
+
+	individualReward, found := legacyKeeper.GetIndividualReward(legacyCtx, resOwner1, epoch.AddRaw(1))
+	require.True(t, found)
+	require.Equal(t, reward, individualReward.RewardFromTrafficPool)
+}
+
+func TestMigrateParams(t *testing.T) {
+	mApp, k, _, _, _, _ := getMockApp(t)
+	accs := setupAccounts(mApp)
+	mock.SetGenesis(mApp, accs)
+
+	header := abci.Header{Height: mApp.LastBlockHeight() + 1, Time: time.Now()}
+	ctx := mApp.BaseApp.NewContext(true, header)
+	params := k.GetParams(ctx)
+	params.MatureEpoch = 5
+	params.VolumeReportThreshold = sdk.NewDecWithPrec(9, 1)
+	k.SetParams(ctx, params)
+
+	/********************* a param added after the chain started is missing from the param space *********************/
+	paramStore := prefix.NewStore(ctx.KVStore(mApp.KeyParams), append([]byte(DefaultParamSpace), '/'))
+	paramStore.Delete(types.KeyVolumeReportThreshold)
+	require.Panics(t, func() { k.GetParams(ctx) })
+
+	/********************* the missing param is set to its default value, the others are kept *********************/
+	k.MigrateParams(ctx)
+	params = k.GetParams(ctx)
+	require.Equal(t, types.DefaultParams().VolumeReportThreshold, params.VolumeReportThreshold)
+	require.Equal(t, int64(5), params.MatureEpoch)
+}
+
+// check the volumes of the report are stored and can be queried by epoch & by wallet
 func getMockApp(t *testing.T) (*mock.App, Keeper, staking.Keeper, bank.Keeper, supply.Keeper, register.Keeper) {
 	mApp := mock.NewApp()
 
@@ -534,6 +965,7 @@ func getInitChainer(mapp *mock.App, keeper Keeper, accountKeeper auth.AccountKee
 			initialUOzonePrice,
 			sdk.ZeroInt(),
 			make([]register.Slashing, 0),
+			make([]register.IndexingNodeRegistrationVotePool, 0),
 		)
 
 		register.InitGenesis(ctx, registerKeeper, registerGenesis)
@@ -575,10 +1007,11 @@ func getInitChainer(mapp *mock.App, keeper Keeper, accountKeeper auth.AccountKee
 
 }
 
-// getEndBlocker returns a staking endblocker.
+// getEndBlocker returns a staking & pot endblocker.
 func getEndBlocker(keeper Keeper) sdk.EndBlocker {
 	return func(ctx sdk.Context, req abci.RequestEndBlock) abci.ResponseEndBlock {
 		validatorUpdates := keeper.StakingKeeper.BlockValidatorUpdates(ctx)
+		EndBlocker(ctx, keeper)
 
 		return abci.ResponseEndBlock{
 			ValidatorUpdates: validatorUpdates,
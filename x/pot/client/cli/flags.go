@@ -9,6 +9,7 @@ const (
 	FlagEpoch           = "epoch"
 	FlagReportReference = "reference"
 	FlagWalletVolumes   = "wallet-volumes"
+	FlagConsumerVolumes = "consumer-volumes"
 	FlagAmount          = "amount"
 	FlagWalletAddress   = "wallet-address"
 	FlagTargetAddress   = "target-address"
@@ -17,6 +18,9 @@ const (
 	FlagNetworkAddress  = "network-address"
 	FlagSlashing        = "slashing"
 	FlagSuspend         = "suspend"
+	FlagBLSPubKeys      = "bls-pub-keys"
+	FlagBLSSignature    = "bls-signature"
+	FlagRewardAction    = "action"
 )
 
 var (
@@ -24,6 +28,7 @@ var (
 	FsEpoch           = flag.NewFlagSet("", flag.ContinueOnError)
 	FsReportReference = flag.NewFlagSet("", flag.ContinueOnError)
 	FsWalletVolumes   = flag.NewFlagSet("", flag.ContinueOnError)
+	FsConsumerVolumes = flag.NewFlagSet("", flag.ContinueOnError)
 	FsAmount          = flag.NewFlagSet("", flag.ContinueOnError)
 	FsWalletAddress   = flag.NewFlagSet("", flag.ContinueOnError)
 	FsTargetAddress   = flag.NewFlagSet("", flag.ContinueOnError)
@@ -32,6 +37,9 @@ var (
 	FsNetworkAddress  = flag.NewFlagSet("", flag.ContinueOnError)
 	FsSlashing        = flag.NewFlagSet("", flag.ContinueOnError)
 	FsSuspend         = flag.NewFlagSet("", flag.ContinueOnError)
+	FsBLSPubKeys      = flag.NewFlagSet("", flag.ContinueOnError)
+	FsBLSSignature    = flag.NewFlagSet("", flag.ContinueOnError)
+	FsRewardAction    = flag.NewFlagSet("", flag.ContinueOnError)
 )
 
 func init() {
@@ -39,6 +47,7 @@ func init() {
 	FsEpoch.String(FlagEpoch, "", "the epoch when this PoT message reported.")
 	FsReportReference.String(FlagReportReference, "", " the hash used as a reference to this PoT report")
 	FsWalletVolumes.String(FlagWalletVolumes, "", "a string of KEY-VALUE pairs. The KEY is 'wallet-volumes' and the VALUE is the proof of traffic of this wallet`")
+	FsConsumerVolumes.String(FlagConsumerVolumes, "", "a string of KEY-VALUE pairs. The KEY is 'wallet_address' and the VALUE is the uoz consumed by this wallet")
 	FsAmount.String(FlagAmount, "", "Amount of coins to withdraw")
 	FsWalletAddress.String(FlagWalletAddress, "", "The address of the wallet to withdraw")
 	FsTargetAddress.String(FlagTargetAddress, "", "The target account where the money is deposited after withdraw")
@@ -47,5 +56,8 @@ func init() {
 	FsNetworkAddress.String(FlagNetworkAddress, "", "the node address of resource node to slashing")
 	FsSlashing.String(FlagSlashing, "", "the amount of slashing")
 	FsSuspend.String(FlagSuspend, "", "if the resource node is suspend")
+	FsBLSPubKeys.String(FlagBLSPubKeys, "", "comma separated hex encoded BLS public keys of the meta nodes signing the report")
+	FsBLSSignature.String(FlagBLSSignature, "", "hex encoded BLS aggregate signature of the report")
 
+	FsRewardAction.String(FlagRewardAction, "", "the action taken on mature rewards: withdraw, restake, or empty for manual withdrawal")
 }
@@ -3,6 +3,7 @@ package cli
 import (
 	"bufio"
 	"fmt"
+	"io/ioutil"
 	"strconv"
 	"strings"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/version"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/stratosnet/stratos-chain/x/pot/keeper"
@@ -31,6 +33,7 @@ func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	potQueryCmd.AddCommand(
 		flags.GetCommands(
 			GetCmdQueryVolumeReport(queryRoute, cdc),
+			GetCmdSimulateDistribution(queryRoute, cdc),
 		)...,
 	)
 
@@ -79,6 +82,58 @@ func QueryVolumeReport(cliCtx context.CLIContext, queryRoute string, epoch sdk.I
 	return reportRes, height, nil
 }
 
+// GetCmdSimulateDistribution implements the simulate distribution command.
+func GetCmdSimulateDistribution(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "simulate-distribution [traffic-list-file]",
+		Short: "Simulate the pot reward distribution of a traffic list",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Simulate the pot reward distribution of a traffic list without changing the state.
+The file contains the wallet volumes in JSON, e.g. [{"wallet_address":"st1...","volume":"1000"}].
+
+Example:
+$ %s query %s simulate-distribution traffic.json --epoch=10
+`, version.ClientName, types.ModuleName),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+			epoch, err := checkFlagEpoch(viper.GetString(FlagEpoch))
+			if err != nil {
+				return err
+			}
+			bz, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			walletVolumes, err := parseWalletVolumes(cdc, bz)
+			if err != nil {
+				return err
+			}
+
+			params := types.NewQuerySimulateDistributionParams(epoch, walletVolumes)
+			bz, err = cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+			route := fmt.Sprintf("custom/%s/%s", queryRoute, keeper.QuerySimulateDistribution)
+			res, _, err := cliCtx.QueryWithData(route, bz)
+			if err != nil {
+				return err
+			}
+
+			var simulation types.DistributionSimulation
+			cdc.MustUnmarshalJSON(res, &simulation)
+			return cliCtx.PrintOutput(simulation)
+		},
+	}
+	cmd.Flags().AddFlagSet(FsEpoch)
+	_ = cmd.MarkFlagRequired(FlagEpoch)
+
+	return cmd
+}
+
 func checkFlagEpoch(epochStr string) (sdk.Int, error) {
 	epochInt64, err := strconv.ParseInt(epochStr, 10, 64)
 	if err != nil {
@@ -2,8 +2,10 @@ package cli
 
 import (
 	"bufio"
+	"encoding/hex"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/client/flags"
@@ -17,6 +19,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/stratosnet/stratos-chain/x/pot/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
 )
 
 type singleWalletVolumeStr struct {
@@ -24,6 +27,30 @@ type singleWalletVolumeStr struct {
 	Volume        string `json:"volume"`
 }
 
+// parseWalletVolumes parses a JSON list of wallet addresses with their traffic volume
+func parseWalletVolumes(cdc *codec.Codec, bz []byte) ([]types.SingleWalletVolume, error) {
+	var walletVolumesStr = make([]singleWalletVolumeStr, 0)
+	err := cdc.UnmarshalJSON(bz, &walletVolumesStr)
+	if err != nil {
+		return nil, err
+	}
+
+	var walletVolumes = make([]types.SingleWalletVolume, 0)
+	for _, n := range walletVolumesStr {
+		walletAcc, err := sdk.AccAddressFromBech32(n.WalletAddress)
+		if err != nil {
+			return nil, err
+		}
+		volumeInt64, err := strconv.ParseInt(n.Volume, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		volume := sdk.NewInt(volumeInt64)
+		walletVolumes = append(walletVolumes, types.NewSingleWalletVolume(walletAcc, volume))
+	}
+	return walletVolumes, nil
+}
+
 // GetTxCmd returns the transaction commands for this module
 func GetTxCmd(cdc *codec.Codec) *cobra.Command {
 	potTxCmd := &cobra.Command{
@@ -39,6 +66,7 @@ func GetTxCmd(cdc *codec.Codec) *cobra.Command {
 		WithdrawCmd(cdc),
 		FoundationDepositCmd(cdc),
 		SlashingResourceNodeCmd(cdc),
+		SetRewardPreferenceCmd(cdc),
 	)...)
 	return potTxCmd
 }
@@ -116,11 +144,16 @@ func VolumeReportCmd(cdc *codec.Codec) *cobra.Command {
 	cmd.Flags().AddFlagSet(FsEpoch)
 	cmd.Flags().AddFlagSet(FsReportReference)
 	cmd.Flags().AddFlagSet(FsWalletVolumes)
+	cmd.Flags().AddFlagSet(FsConsumerVolumes)
+	cmd.Flags().AddFlagSet(FsBLSPubKeys)
+	cmd.Flags().AddFlagSet(FsBLSSignature)
 
 	_ = cmd.MarkFlagRequired(FlagReporterAddr)
 	_ = cmd.MarkFlagRequired(FlagEpoch)
 	_ = cmd.MarkFlagRequired(FlagReportReference)
 	_ = cmd.MarkFlagRequired(FlagWalletVolumes)
+	_ = cmd.MarkFlagRequired(FlagBLSPubKeys)
+	_ = cmd.MarkFlagRequired(FlagBLSSignature)
 	_ = cmd.MarkFlagRequired(flags.FlagFrom)
 
 	return cmd
@@ -139,36 +172,43 @@ func createVolumeReportMsg(cliCtx context.CLIContext, txBldr auth.TxBuilder) (au
 		return txBldr, nil, err
 	}
 	epoch := sdk.NewInt(value)
-	var walletVolumesStr = make([]singleWalletVolumeStr, 0)
-	err = cliCtx.Codec.UnmarshalJSON([]byte(viper.GetString(FlagWalletVolumes)), &walletVolumesStr)
+	walletVolumes, err := parseWalletVolumes(cliCtx.Codec, []byte(viper.GetString(FlagWalletVolumes)))
 	if err != nil {
 		return txBldr, nil, err
 	}
-
-	var walletVolumes = make([]types.SingleWalletVolume, 0)
-	for _, n := range walletVolumesStr {
-		walletAcc, err := sdk.AccAddressFromBech32(n.WalletAddress)
+	var consumerVolumes []types.SingleWalletVolume
+	if consumerVolumesStr := viper.GetString(FlagConsumerVolumes); consumerVolumesStr != "" {
+		consumerVolumes, err = parseWalletVolumes(cliCtx.Codec, []byte(consumerVolumesStr))
 		if err != nil {
 			return txBldr, nil, err
 		}
-		volumeInt64, err := strconv.ParseInt(n.Volume, 10, 64)
+	}
+
+	var blsPubKeys [][]byte
+	for _, pubKeyStr := range strings.Split(viper.GetString(FlagBLSPubKeys), ",") {
+		pubKey, err := hex.DecodeString(strings.TrimSpace(pubKeyStr))
 		if err != nil {
 			return txBldr, nil, err
 		}
-		volume := sdk.NewInt(volumeInt64)
-		walletVolumes = append(walletVolumes, types.NewSingleWalletVolume(walletAcc, volume))
+		blsPubKeys = append(blsPubKeys, pubKey)
+	}
+	blsSignature, err := hex.DecodeString(viper.GetString(FlagBLSSignature))
+	if err != nil {
+		return txBldr, nil, err
 	}
 
 	reporterOwner := cliCtx.GetFromAddress()
 
 	msg := types.NewMsgVolumeReport(
 		walletVolumes,
+		consumerVolumes,
 		reporter,
 		epoch,
 		reportReference,
 		reporterOwner,
 		types.BLSSignatureInfo{},
 	)
+	msg.BLSSignature = types.NewBLSSignatureInfo(blsPubKeys, blsSignature, tmhash.Sum(msg.GetBLSSignBytes()))
 	return txBldr, msg, nil
 }
 
@@ -299,3 +339,61 @@ func buildSlashingResourceNodeMsg(cliCtx context.CLIContext, txBldr auth.TxBuild
 	msg := types.NewMsgSlashingResourceNode(reporters, reporterOwner, networkAddress, walletAddress, slashing, suspend)
 	return txBldr, msg, nil
 }
+
+// SetRewardPreferenceCmd sets the action taken on the mature rewards of the wallet.
+func SetRewardPreferenceCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-reward-preference",
+		Short: "auto withdraw or restake POT rewards at maturity",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			txBldr, msg, err := buildSetRewardPreferenceMsg(cliCtx, txBldr)
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().AddFlagSet(FsRewardAction)
+	cmd.Flags().AddFlagSet(FsTargetAddress)
+	cmd.Flags().AddFlagSet(FsNetworkAddress)
+
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+
+	return cmd
+}
+
+// makes a new SetRewardPreferenceMsg.
+func buildSetRewardPreferenceMsg(cliCtx context.CLIContext, txBldr auth.TxBuilder) (auth.TxBuilder, sdk.Msg, error) {
+	walletAddress := cliCtx.GetFromAddress()
+	action := viper.GetString(FlagRewardAction)
+
+	var targetAddress sdk.AccAddress
+	var networkAddress stratos.SdsAddress
+	var err error
+	switch action {
+	case types.RewardActionWithdraw:
+		if viper.IsSet(FlagTargetAddress) {
+			targetAddress, err = sdk.AccAddressFromBech32(viper.GetString(FlagTargetAddress))
+			if err != nil {
+				return txBldr, nil, err
+			}
+		} else {
+			targetAddress = walletAddress
+		}
+	case types.RewardActionRestake:
+		networkAddress, err = stratos.SdsAddressFromBech32(viper.GetString(FlagNetworkAddress))
+		if err != nil {
+			return txBldr, nil, err
+		}
+	}
+
+	msg := types.NewMsgSetRewardPreference(walletAddress, action, targetAddress, networkAddress)
+
+	return txBldr, msg, nil
+}
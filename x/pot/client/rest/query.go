@@ -15,8 +15,11 @@ import (
 
 func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router) {
 	r.HandleFunc("/pot/report/epoch/{epoch}", getVolumeReportHandlerFn(cliCtx, keeper.QueryVolumeReport)).Methods("GET")
+	r.HandleFunc("/pot/report/epoch/{epoch}/volumes", getWalletVolumesByEpochHandlerFn(cliCtx, keeper.QueryWalletVolumesByEpoch)).Methods("GET")
+	r.HandleFunc("/pot/report/wallet/{walletAddress}", getWalletVolumeHistoryHandlerFn(cliCtx, keeper.QueryWalletVolumeHistory)).Methods("GET")
 	r.HandleFunc("/pot/rewards/epoch/{epoch}", getPotRewardsByEpochHandlerFn(cliCtx, keeper.QueryPotRewardsByReportEpoch)).Methods("GET")
 	r.HandleFunc("/pot/rewards/wallet/{walletAddress}", getPotRewardsByWalletAddrHandlerFn(cliCtx, keeper.QueryPotRewardsByWalletAddr)).Methods("GET")
+	r.HandleFunc("/pot/simulate-distribution", simulateDistributionHandlerFn(cliCtx, keeper.QuerySimulateDistribution)).Methods("POST")
 	r.HandleFunc("/pot/slashing/{walletAddress}", getPotSlashingByWalletAddressHandlerFn(cliCtx, keeper.QueryPotSlashingByWalletAddr)).Methods("GET")
 }
 
@@ -174,3 +177,110 @@ func getPotSlashingByWalletAddressHandlerFn(cliCtx context.CLIContext, queryPath
 		rest.PostProcessResponse(w, cliCtx, res)
 	}
 }
+
+// GET request handler to query the volume & reward of each wallet reported at an epoch
+func getWalletVolumesByEpochHandlerFn(cliCtx context.CLIContext, queryPath string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_, page, limit, err := rest.ParseHTTPArgsWithLimit(r, 0)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		epoch, ok := sdk.NewIntFromString(mux.Vars(r)["epoch"])
+		if !ok || epoch.IsNegative() {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "Invalid epoch")
+			return
+		}
+
+		params := types.NewQueryWalletVolumesByEpochParams(page, limit, epoch)
+		bz, err := cliCtx.Codec.MarshalJSON(params)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		route := fmt.Sprintf("custom/%s/%s", types.QuerierRoute, queryPath)
+		res, height, err := cliCtx.QueryWithData(route, bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+// GET request handler to query the traffic history of a wallet across epochs
+func getWalletVolumeHistoryHandlerFn(cliCtx context.CLIContext, queryPath string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_, page, limit, err := rest.ParseHTTPArgsWithLimit(r, 0)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		walletAddr, err := sdk.AccAddressFromBech32(mux.Vars(r)["walletAddress"])
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		params := types.NewQueryWalletVolumeHistoryParams(page, limit, walletAddr)
+		bz, err := cliCtx.Codec.MarshalJSON(params)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		route := fmt.Sprintf("custom/%s/%s", types.QuerierRoute, queryPath)
+		res, height, err := cliCtx.QueryWithData(route, bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+// POST request handler to simulate the pot reward distribution of a traffic list
+func simulateDistributionHandlerFn(cliCtx context.CLIContext, queryPath string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		var params types.QuerySimulateDistributionParams
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &params) {
+			return
+		}
+
+		bz, err := cliCtx.Codec.MarshalJSON(params)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		route := fmt.Sprintf("custom/%s/%s", types.QuerierRoute, queryPath)
+		res, height, err := cliCtx.QueryWithData(route, bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/gorilla/mux"
 	stratos "github.com/stratosnet/stratos-chain/types"
 	"github.com/stratosnet/stratos-chain/x/pot/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
 )
 
 //registerTxRoutes registers pot-related REST Tx handlers to a router
@@ -19,6 +20,7 @@ func registerTxRoutes(cliCtx context.CLIContext, r *mux.Router) {
 	r.HandleFunc("/pot/withdraw", withdrawPotRewardsHandlerFn(cliCtx)).Methods("POST")
 	r.HandleFunc("/pot/foundation_deposit", foundationDepositHandlerFn(cliCtx)).Methods("POST")
 	r.HandleFunc("/pot/slashing", slashingResourceNodeHandlerFn(cliCtx)).Methods("POST")
+	r.HandleFunc("/pot/reward_preference", setRewardPreferenceHandlerFn(cliCtx)).Methods("POST")
 }
 
 type (
@@ -33,12 +35,22 @@ type (
 		TargetAddress string       `json:"target_address" yaml:"target_address"`
 	}
 
+	setRewardPreferenceReq struct {
+		BaseReq        rest.BaseReq `json:"base_req" yaml:"base_req"`
+		Action         string       `json:"action" yaml:"action"`                   // withdraw, restake, or empty for manual withdrawal
+		TargetAddress  string       `json:"target_address" yaml:"target_address"`   // used by withdraw
+		NetworkAddress string       `json:"network_address" yaml:"network_address"` // used by restake
+	}
+
 	volumeReportReq struct {
 		BaseReq         rest.BaseReq               `json:"base_req" yaml:"base_req"`
 		WalletVolumes   []types.SingleWalletVolume `json:"wallet_volumes" yaml:"wallet_volumes"`     // volume report
+		ConsumerVolumes []types.SingleWalletVolume `json:"consumer_volumes" yaml:"consumer_volumes"` // uoz consumed by each consumer wallet
 		Reporter        string                     `json:"reporter" yaml:"reporter"`                 // volume reporter
 		Epoch           int64                      `json:"epoch" yaml:"epoch"`                       // volume report epoch
 		ReportReference string                     `json:"report_reference" yaml:"report_reference"` // volume report reference
+		BLSPubKeys      [][]byte                   `json:"bls_pub_keys" yaml:"bls_pub_keys"`         // BLS public keys of the signing meta nodes
+		BLSSignature    []byte                     `json:"bls_signature" yaml:"bls_signature"`       // BLS aggregate signature of the meta nodes
 	}
 
 	slashingResourceNodeReq struct {
@@ -88,7 +100,8 @@ func volumeReportRequestHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
-		msg := types.NewMsgVolumeReport(walletVolumes, reporter, epoch, reportReference, reporterOwner, types.BLSSignatureInfo{})
+		msg := types.NewMsgVolumeReport(walletVolumes, req.ConsumerVolumes, reporter, epoch, reportReference, reporterOwner, types.BLSSignatureInfo{})
+		msg.BLSSignature = types.NewBLSSignatureInfo(req.BLSPubKeys, req.BLSSignature, tmhash.Sum(msg.GetBLSSignBytes()))
 		err = msg.ValidateBasic()
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
@@ -182,6 +195,53 @@ func foundationDepositHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 	}
 }
 
+func setRewardPreferenceHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req setRewardPreferenceReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		walletAddr, ok := checkAccountAddressVar(w, r, req.BaseReq.From)
+		if !ok {
+			return
+		}
+
+		var targetAddr sdk.AccAddress
+		if len(req.TargetAddress) > 0 {
+			targetAddr, ok = checkAccountAddressVar(w, r, req.TargetAddress)
+			if !ok {
+				return
+			}
+		}
+
+		var networkAddr stratos.SdsAddress
+		if len(req.NetworkAddress) > 0 {
+			var err error
+			networkAddr, err = stratos.SdsAddressFromBech32(req.NetworkAddress)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		msg := types.NewMsgSetRewardPreference(walletAddr, req.Action, targetAddr, networkAddr)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
 func checkAccountAddressVar(w http.ResponseWriter, r *http.Request, accountAddrStr string) (sdk.AccAddress, bool) {
 	addr, err := sdk.AccAddressFromBech32(accountAddrStr)
 	if err != nil {
@@ -11,6 +11,12 @@ func InitGenesis(ctx sdk.Context, keeper Keeper, data types.GenesisState) {
 	keeper.SetParams(ctx, data.Params)
 	keeper.SetTotalMinedTokens(ctx, data.TotalMinedToken)
 	keeper.SetLastReportedEpoch(ctx, sdk.NewInt(data.LastReportedEpoch))
+	// genesis files exported before the matured epoch was tracked matured the rewards up to the last reported epoch
+	lastMaturedEpoch := data.LastMaturedEpoch
+	if lastMaturedEpoch == 0 {
+		lastMaturedEpoch = data.LastReportedEpoch
+	}
+	keeper.SetLastMaturedEpoch(ctx, sdk.NewInt(lastMaturedEpoch))
 
 	for _, immatureTotal := range data.ImmatureTotalInfo {
 		keeper.SetImmatureTotalReward(ctx, immatureTotal.WalletAddress, immatureTotal.Value)
@@ -24,6 +30,40 @@ func InitGenesis(ctx sdk.Context, keeper Keeper, data types.GenesisState) {
 		keeper.SetIndividualReward(ctx, individual.WalletAddress, sdk.NewInt(data.LastReportedEpoch+1), individual)
 	}
 
+	for _, individual := range data.IndividualRewards {
+		keeper.SetIndividualReward(ctx, individual.Reward.WalletAddress, individual.Epoch, individual.Reward)
+	}
+
+	for _, minedToken := range data.MinedTokens {
+		keeper.SetMinedTokens(ctx, minedToken.Epoch, minedToken.MinedToken)
+	}
+
+	for _, report := range data.VolumeReports {
+		keeper.SetVolumeReport(ctx, report.Epoch, report.Record)
+	}
+
+	for _, record := range data.WalletVolumeRecords {
+		keeper.SetWalletVolumeRecord(ctx, record)
+	}
+
+	for _, submission := range data.VolumeReportSubmissions {
+		keeper.SetVolumeReportSubmission(ctx, submission)
+	}
+
+	for _, mismatch := range data.ReporterMismatchCounts {
+		keeper.SetReporterMismatchCount(ctx, mismatch.Reporter, mismatch.Count)
+	}
+
+	for _, preference := range data.RewardPreferences {
+		keeper.SetRewardPreference(ctx, preference)
+	}
+
+	// the suspended node queue is rebuilt from the suspension expiries of the register module, which is initialized first
+	for _, suspendedTime := range keeper.RegisterKeeper.GetAllNodeSuspendedTimes(ctx) {
+		if !suspendedTime.SuspendedUntil.IsZero() {
+			keeper.InsertSuspendedNodeQueue(ctx, suspendedTime.NetworkAddr, suspendedTime.SuspendedUntil)
+		}
+	}
 }
 
 // ExportGenesis writes the current store values
@@ -34,18 +74,11 @@ func ExportGenesis(ctx sdk.Context, keeper Keeper) (data types.GenesisState) {
 	totalMinedToken := keeper.GetTotalMinedTokens(ctx)
 	lastReportedEpoch := keeper.GetLastReportedEpoch(ctx)
 
-	var individualRewardInfo []types.Reward
 	var immatureTotalInfo []types.ImmatureTotal
 	keeper.IteratorImmatureTotal(ctx, func(walletAddress sdk.AccAddress, reward sdk.Coins) (stop bool) {
 		if !reward.Empty() && !reward.IsZero() {
 			immatureTotal := types.NewImmatureTotal(walletAddress, reward)
 			immatureTotalInfo = append(immatureTotalInfo, immatureTotal)
-
-			miningReward := sdk.NewCoins(sdk.NewCoin(types.DefaultRewardDenom, reward.AmountOf(types.DefaultRewardDenom)))
-			trafficReward := sdk.NewCoins(sdk.NewCoin(types.DefaultBondDenom, reward.AmountOf(types.DefaultBondDenom)))
-			individualReward := types.NewReward(walletAddress, miningReward, trafficReward)
-			individualRewardInfo = append(individualRewardInfo, individualReward)
-
 		}
 		return false
 	})
@@ -59,6 +92,33 @@ func ExportGenesis(ctx sdk.Context, keeper Keeper) (data types.GenesisState) {
 		return false
 	})
 
-	return types.NewGenesisState(params, totalMinedToken, lastReportedEpoch.Int64(),
-		immatureTotalInfo, matureTotalInfo, individualRewardInfo)
+	data = types.NewGenesisState(params, totalMinedToken, lastReportedEpoch.Int64(),
+		immatureTotalInfo, matureTotalInfo, nil)
+	data.LastMaturedEpoch = keeper.GetLastMaturedEpoch(ctx).Int64()
+
+	keeper.IteratorAllIndividualRewards(ctx, func(epoch sdk.Int, individualReward types.Reward) (stop bool) {
+		data.IndividualRewards = append(data.IndividualRewards, types.NewIndividualReward(epoch, individualReward))
+		return false
+	})
+
+	keeper.IteratorMinedTokens(ctx, func(epoch sdk.Int, minedToken sdk.Coin) (stop bool) {
+		data.MinedTokens = append(data.MinedTokens, types.NewMinedToken(epoch, minedToken))
+		return false
+	})
+	keeper.IteratorVolumeReport(ctx, func(epoch sdk.Int, reportRecord types.VolumeReportRecord) (stop bool) {
+		data.VolumeReports = append(data.VolumeReports, types.NewEpochVolumeReport(epoch, reportRecord))
+		return false
+	})
+	keeper.IteratorAllWalletVolumeRecords(ctx, func(record types.WalletVolumeRecord) (stop bool) {
+		data.WalletVolumeRecords = append(data.WalletVolumeRecords, record)
+		return false
+	})
+
+	data.VolumeReportSubmissions = keeper.GetAllVolumeReportSubmissions(ctx)
+	data.ReporterMismatchCounts = keeper.GetAllReporterMismatchCounts(ctx)
+	data.RewardPreferences = keeper.GetAllRewardPreferences(ctx)
+
+	foundationAccountAddr := keeper.SupplyKeeper.GetModuleAddress(types.FoundationAccount)
+	data.FoundationAccountBalance = keeper.BankKeeper.GetCoins(ctx, foundationAccountAddr)
+	return data
 }
@@ -25,6 +25,8 @@ func NewHandler(k keeper.Keeper) sdk.Handler {
 			return handleMsgFoundationDeposit(ctx, k, msg)
 		case types.MsgSlashingResourceNode:
 			return handleMsgSlashingResourceNode(ctx, k, msg)
+		case types.MsgSetRewardPreference:
+			return handleMsgSetRewardPreference(ctx, k, msg)
 		default:
 			errMsg := fmt.Sprintf("unrecognized %s message type: %T", types.ModuleName, msg)
 			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, errMsg)
@@ -38,6 +40,14 @@ func handleMsgVolumeReport(ctx sdk.Context, k keeper.Keeper, msg types.MsgVolume
 		errMsg := fmt.Sprint("Volume report is not sent by a superior peer")
 		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, errMsg)
 	}
+	// only the owner of a valid meta node counts towards the quorum of the epoch
+	reporter, _ := k.RegisterKeeper.GetIndexingNode(ctx, msg.Reporter)
+	if !reporter.GetOwnerAddr().Equals(msg.ReporterOwner) {
+		return nil, types.ErrNotTheOwner
+	}
+	if reporter.IsSuspended() || !reporter.GetStatus().Equal(sdk.Bonded) {
+		return nil, sdkerrors.Wrap(types.ErrReporterAddress, "reporter is not a bonded meta node")
+	}
 
 	// ensure epoch increment
 	lastEpoch := k.GetLastReportedEpoch(ctx)
@@ -47,22 +57,36 @@ func handleMsgVolumeReport(ctx sdk.Context, k keeper.Keeper, msg types.MsgVolume
 		return nil, e
 	}
 
-	// TODO: verify BLS signature
+	// verify the BLS aggregate signature of the meta nodes
+	if err := k.VerifyVolumeReportSignature(ctx, msg); err != nil {
+		return nil, err
+	}
+
+	// consumers cannot consume more uoz than they purchased
+	for _, consumer := range msg.ConsumerVolumes {
+		balance := k.RegisterKeeper.GetOzoneBalance(ctx, consumer.WalletAddress)
+		if consumer.Volume.GT(balance) {
+			return nil, sdkerrors.Wrapf(types.ErrInsufficientOzoneBalance, "wallet %s consumed %s uoz, balance %s uoz",
+				consumer.WalletAddress.String(), consumer.Volume.String(), balance.String())
+		}
+	}
 
 	txBytes := ctx.TxBytes()
 	txhash := fmt.Sprintf("%X", tmhash.Sum(txBytes))
 
-	totalConsumedOzone, err := k.VolumeReport(ctx, msg.WalletVolumes, msg.Reporter, msg.Epoch, msg.ReportReference, txhash)
+	// the report is finalized in EndBlocker once the quorum of meta nodes submitted the same report
+	err := k.SubmitVolumeReport(ctx, msg, txhash)
 	if err != nil {
 		return nil, err
 	}
 
 	ctx.EventManager().EmitEvents(sdk.Events{
 		sdk.NewEvent(
-			types.EventTypeVolumeReport,
-			sdk.NewAttribute(types.AttributeKeyTotalConsumedOzone, totalConsumedOzone.String()),
+			types.EventTypeSubmitVolumeReport,
+			sdk.NewAttribute(types.AttributeKeyReporter, msg.Reporter.String()),
 			sdk.NewAttribute(types.AttributeKeyReportReference, hex.EncodeToString([]byte(msg.ReportReference))),
 			sdk.NewAttribute(types.AttributeKeyEpoch, msg.Epoch.String()),
+			sdk.NewAttribute(types.AttributeKeyReportHash, hex.EncodeToString(msg.BLSSignature.TxData)),
 		),
 		sdk.NewEvent(
 			sdk.EventTypeMessage,
@@ -123,16 +147,39 @@ func handleMsgSlashingResourceNode(ctx sdk.Context, k keeper.Keeper, msg types.M
 		}
 	}
 
-	amt, nodeType, err := k.SlashingResourceNode(ctx, msg.NetworkAddress, msg.WalletAddress, msg.Slashing, msg.Suspend)
+	amt, stakeSlashed, nodeType, err := k.SlashingResourceNode(ctx, msg.NetworkAddress, msg.WalletAddress, msg.Slashing, msg.Suspend)
 	ctx.EventManager().EmitEvents(sdk.Events{
 		sdk.NewEvent(
 			types.EventTypeSlashing,
 			sdk.NewAttribute(types.AttributeKeyWalletAddress, msg.WalletAddress.String()),
 			sdk.NewAttribute(types.AttributeKeyNodeP2PAddress, msg.NetworkAddress.String()),
 			sdk.NewAttribute(types.AttributeKeyAmount, amt.String()),
+			sdk.NewAttribute(types.AttributeKeyStakeSlashed, stakeSlashed.String()),
 			sdk.NewAttribute(types.AttributeKeySlashingNodeType, nodeType.String()),
 			sdk.NewAttribute(types.AttributeKeyNodeSuspended, strconv.FormatBool(msg.Suspend)),
 		),
 	})
 	return &sdk.Result{Events: ctx.EventManager().Events()}, err
 }
+
+func handleMsgSetRewardPreference(ctx sdk.Context, k keeper.Keeper, msg types.MsgSetRewardPreference) (*sdk.Result, error) {
+	preference := types.NewRewardPreference(msg.WalletAddress, msg.Action, msg.TargetAddress, msg.NetworkAddress)
+	err := k.UpdateRewardPreference(ctx, preference)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeSetRewardPreference,
+			sdk.NewAttribute(types.AttributeKeyWalletAddress, msg.WalletAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyRewardAction, msg.Action),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.WalletAddress.String()),
+		),
+	})
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
@@ -0,0 +1,62 @@
+package keeper
+
+import (
+	"bytes"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stratosnet/stratos-chain/crypto/bls"
+	"github.com/stratosnet/stratos-chain/x/pot/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+)
+
+/*
+	VerifyVolumeReportSignature checks the BLS aggregate signature of a volume report.
+
+	1, TxData must be the hash of the report content (MsgVolumeReport.GetBLSSignBytes).
+	2, the signers must be distinct valid meta nodes, identified by their registered BLS public keys,
+	   and reach the quorum of 2/3 of the valid meta nodes.
+	3, the aggregate signature must be valid for TxData and all the signers' public keys.
+*/
+func (k Keeper) VerifyVolumeReportSignature(ctx sdk.Context, msg types.MsgVolumeReport) error {
+	blsSignature := msg.BLSSignature
+
+	if !bytes.Equal(blsSignature.TxData, tmhash.Sum(msg.GetBLSSignBytes())) {
+		return types.ErrBLSTxDataInvalid
+	}
+
+	validIndexingNodes := k.RegisterKeeper.GetAllValidIndexingNodes(ctx)
+	validBLSPubKeys := make(map[string]bool)
+	for _, indexingNode := range validIndexingNodes {
+		if len(indexingNode.BLSPubKey) > 0 {
+			validBLSPubKeys[string(indexingNode.BLSPubKey)] = true
+		}
+	}
+
+	signers := make(map[string]bool)
+	for _, pubKey := range blsSignature.PubKeys {
+		if !validBLSPubKeys[string(pubKey)] {
+			return sdkerrors.Wrap(types.ErrBLSPubkeysInvalid, "signer is not a valid meta node")
+		}
+		if signers[string(pubKey)] {
+			return sdkerrors.Wrap(types.ErrBLSPubkeysInvalid, "duplicate signer")
+		}
+		signers[string(pubKey)] = true
+	}
+
+	totalSpCount := len(validIndexingNodes)
+	signerCountRequired := totalSpCount*2/3 + 1
+	if len(signers) < signerCountRequired {
+		return sdkerrors.Wrapf(types.ErrBLSNotReachThreshold, "expected at least %d signers, got %d",
+			signerCountRequired, len(signers))
+	}
+
+	verified, err := bls.Verify(blsSignature.TxData, blsSignature.Signature, blsSignature.PubKeys...)
+	if err != nil {
+		return sdkerrors.Wrap(types.ErrBLSSignatureInvalid, err.Error())
+	}
+	if !verified {
+		return types.ErrBLSVerifyFailed
+	}
+	return nil
+}
@@ -2,23 +2,41 @@ package keeper
 
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	stratos "github.com/stratosnet/stratos-chain/types"
 	"github.com/stratosnet/stratos-chain/x/pot/types"
+	regtypes "github.com/stratosnet/stratos-chain/x/register/types"
 )
 
 func (k Keeper) DistributePotReward(ctx sdk.Context, trafficList []types.SingleWalletVolume, epoch sdk.Int) (totalConsumedOzone sdk.Dec, err error) {
+	res, err := k.distributePotReward(ctx, trafficList, epoch)
+	if err != nil {
+		return sdk.ZeroDec(), err
+	}
+	return res.TotalConsumedOzone, nil
+}
+
+// SimulateDistribution runs the pot reward distribution of the traffic list against a cached context that is
+// never written, and returns the distribute goal, the reward of each wallet and the balance returned to the pools.
+func (k Keeper) SimulateDistribution(ctx sdk.Context, trafficList []types.SingleWalletVolume, epoch sdk.Int) (types.DistributionSimulation, error) {
+	cacheCtx, _ := ctx.CacheContext()
+	return k.distributePotReward(cacheCtx.WithEventManager(sdk.NewEventManager()), trafficList, epoch)
+}
+
+func (k Keeper) distributePotReward(ctx sdk.Context, trafficList []types.SingleWalletVolume, epoch sdk.Int) (res types.DistributionSimulation, err error) {
+	var totalConsumedOzone sdk.Dec
 	distributeGoal := types.InitDistributeGoal()
 	rewardDetailMap := make(map[string]types.Reward) //key: wallet address
 
 	//1, calc traffic reward in total
 	totalConsumedOzone, distributeGoal, err = k.CalcTrafficRewardInTotal(ctx, trafficList, distributeGoal)
 	if err != nil {
-		return totalConsumedOzone, err
+		return res, err
 	}
 
 	//2, calc mining reward in total
 	distributeGoal, err = k.CalcMiningRewardInTotal(ctx, distributeGoal)
 	if err != nil && err != types.ErrOutOfIssuance {
-		return totalConsumedOzone, err
+		return res, err
 	}
 
 	/**
@@ -39,13 +57,13 @@ func (k Keeper) DistributePotReward(ctx sdk.Context, trafficList []types.SingleW
 	//5, deduct reward from provider account (the value of parameter of distributeGoal will not change)
 	err = k.deductRewardFromRewardProviderAccount(ctx, distributeGoal, epoch)
 	if err != nil {
-		return totalConsumedOzone, err
+		return res, err
 	}
 
 	//6, distribute skate reward to fee pool for validators
 	distributeGoalBalance, err = k.distributeValidatorRewardToFeePool(ctx, distributeGoalBalance)
 	if err != nil {
-		return totalConsumedOzone, err
+		return res, err
 	}
 
 	//7, IMPORTANT: sort map and convert to slice to keep the order
@@ -54,22 +72,22 @@ func (k Keeper) DistributePotReward(ctx sdk.Context, trafficList []types.SingleW
 	//8, distribute all rewards to resource nodes & indexing nodes
 	err = k.distributeRewardToSdsNodes(ctx, rewardDetailList, epoch)
 	if err != nil {
-		return totalConsumedOzone, err
+		return res, err
 	}
 
-	//9, return balance to traffic pool & mining pool
+	//9, record volume & reward of each reported wallet
+	k.recordWalletVolumes(ctx, trafficList, rewardDetailMap, epoch)
+
+	//10, return balance to traffic pool & mining pool
 	err = k.returnBalance(ctx, distributeGoalBalance, epoch)
 	if err != nil {
-		return totalConsumedOzone, err
+		return res, err
 	}
 
-	//10, mature rewards for all nodes
-	k.rewardMatureAndSubSlashing(ctx, epoch)
-
 	//11, save reported epoch
 	k.SetLastReportedEpoch(ctx, epoch)
 
-	return totalConsumedOzone, nil
+	return types.NewDistributionSimulation(epoch, totalConsumedOzone, distributeGoal, rewardDetailList, distributeGoalBalance), nil
 }
 
 func (k Keeper) deductRewardFromRewardProviderAccount(ctx sdk.Context, goal types.DistributeGoal, epoch sdk.Int) (err error) {
@@ -106,7 +124,7 @@ func (k Keeper) deductRewardFromRewardProviderAccount(ctx sdk.Context, goal type
 	oldTotalMinedToken := k.GetTotalMinedTokens(ctx)
 	newTotalMinedToken := oldTotalMinedToken.Add(totalRewardFromMiningPool)
 	k.SetTotalMinedTokens(ctx, newTotalMinedToken)
-	k.setMinedTokens(ctx, epoch, totalRewardFromMiningPool)
+	k.SetMinedTokens(ctx, epoch, totalRewardFromMiningPool)
 
 	// deduct traffic reward from prepay pool
 	totalUnIssuedPrepay := k.RegisterKeeper.GetTotalUnissuedPrepay(ctx)
@@ -147,7 +165,7 @@ func (k Keeper) returnBalance(ctx sdk.Context, goal types.DistributeGoal, curren
 	oldMinedToken := k.GetMinedTokens(ctx, currentEpoch)
 	newMinedToken := oldMinedToken.Sub(balanceOfMiningPool)
 	k.SetTotalMinedTokens(ctx, newTotalMinedToken)
-	k.setMinedTokens(ctx, currentEpoch, newMinedToken)
+	k.SetMinedTokens(ctx, currentEpoch, newMinedToken)
 
 	// return balance to prepay pool
 	totalUnIssuedPrepay := k.RegisterKeeper.GetTotalUnissuedPrepay(ctx)
@@ -210,7 +228,10 @@ func (k Keeper) GetTrafficReward(ctx sdk.Context, trafficList []types.SingleWall
 	if Lt.Equal(sdk.ZeroDec()) {
 		ctx.Logger().Info("remaining total uoz limit is 0")
 	}
-	R := S.Add(Pt).Mul(Y).Quo(Lt.Add(Y))
+	R := sdk.ZeroDec()
+	if Lt.Add(Y).IsPositive() {
+		R = S.Add(Pt).Mul(Y).Quo(Lt.Add(Y))
+	}
 	if R.Equal(sdk.ZeroDec()) {
 		ctx.Logger().Info("traffic reward to distribute is 0")
 	}
@@ -255,6 +276,22 @@ func (k Keeper) distributeRewardToSdsNodes(ctx sdk.Context, rewardDetailList []t
 	return nil
 }
 
+func (k Keeper) recordWalletVolumes(ctx sdk.Context, trafficList []types.SingleWalletVolume, rewardDetailMap map[string]types.Reward, epoch sdk.Int) {
+	for _, walletTraffic := range trafficList {
+		walletAddr := walletTraffic.WalletAddress
+		reward, ok := rewardDetailMap[walletAddr.String()]
+		if !ok {
+			reward = types.NewDefaultReward(walletAddr)
+		}
+		// a wallet may appear more than once in the traffic list
+		volume := walletTraffic.Volume
+		if record, found := k.GetWalletVolumeRecord(ctx, walletAddr, epoch); found {
+			volume = volume.Add(record.Volume)
+		}
+		k.SetWalletVolumeRecord(ctx, types.NewWalletVolumeRecord(epoch, walletAddr, volume, reward))
+	}
+}
+
 func (k Keeper) addNewIndividualAndUpdateImmatureTotal(ctx sdk.Context, account sdk.AccAddress, matureEpoch sdk.Int, newReward types.Reward) {
 	newIndividualTotal := newReward.RewardFromMiningPool.Add(newReward.RewardFromTrafficPool...)
 	oldImmatureTotal := k.GetImmatureTotalReward(ctx, account)
@@ -262,32 +299,77 @@ func (k Keeper) addNewIndividualAndUpdateImmatureTotal(ctx sdk.Context, account
 
 	k.SetIndividualReward(ctx, account, matureEpoch, newReward)
 	k.SetImmatureTotalReward(ctx, account, newImmatureTotal)
-}
 
-func (k Keeper) rewardMatureAndSubSlashing(ctx sdk.Context, currentEpoch sdk.Int) {
-
-	matureStartEpoch := k.GetLastReportedEpoch(ctx).Int64() + 1
-	matureEndEpoch := currentEpoch.Int64()
+	// the matured epoch advances while reporting stalls, so a late report may credit an epoch which has already matured
+	if matureEpoch.LTE(k.GetLastMaturedEpoch(ctx)) {
+		k.matureIndividualReward(ctx, account, matureEpoch, newReward)
+		k.applyRewardPreference(ctx, account)
+	}
+}
 
-	for i := matureStartEpoch; i <= matureEndEpoch; i++ {
-		k.IteratorIndividualReward(ctx, sdk.NewInt(i), func(walletAddress sdk.AccAddress, individualReward types.Reward) (stop bool) {
-			oldMatureTotal := k.GetMatureTotalReward(ctx, walletAddress)
-			oldImmatureTotal := k.GetImmatureTotalReward(ctx, walletAddress)
-			immatureToMature := individualReward.RewardFromMiningPool.Add(individualReward.RewardFromTrafficPool...)
+// RewardMature is called in EndBlocker every MaturationInterval blocks, independently of the volume reports.
+// The matured epoch catches up with the last reported epoch, and keeps advancing by one epoch per interval when reporting stalls.
+func (k Keeper) RewardMature(ctx sdk.Context) {
+	if ctx.BlockHeight()%k.MaturationInterval(ctx) != 0 {
+		return
+	}
+	matureEndEpoch := k.GetLastMaturedEpoch(ctx).Add(sdk.OneInt())
+	if lastReportedEpoch := k.GetLastReportedEpoch(ctx); lastReportedEpoch.GT(matureEndEpoch) {
+		matureEndEpoch = lastReportedEpoch
+	}
+	k.rewardMatureAndSubSlashing(ctx, matureEndEpoch)
+}
 
-			//deduct slashing amount from mature total pool
-			oldMatureTotalSubSlashing := k.RegisterKeeper.DeductSlashing(ctx, walletAddress, oldMatureTotal)
-			//deduct slashing amount from upcoming mature reward, don't need to deduct slashing from immatureTotal & individual
-			immatureToMatureSubSlashing := k.RegisterKeeper.DeductSlashing(ctx, walletAddress, immatureToMature)
+func (k Keeper) rewardMatureAndSubSlashing(ctx sdk.Context, matureEndEpoch sdk.Int) {
 
-			matureTotal := oldMatureTotalSubSlashing.Add(immatureToMatureSubSlashing...)
-			immatureTotal := oldImmatureTotal.Sub(immatureToMature)
+	matureStartEpoch := k.GetLastMaturedEpoch(ctx).Int64() + 1
+	var maturedWallets []sdk.AccAddress
+	matured := make(map[string]bool)
 
-			k.SetMatureTotalReward(ctx, walletAddress, matureTotal)
-			k.SetImmatureTotalReward(ctx, walletAddress, immatureTotal)
+	for i := matureStartEpoch; i <= matureEndEpoch.Int64(); i++ {
+		epoch := sdk.NewInt(i)
+		k.IteratorIndividualReward(ctx, epoch, func(walletAddress sdk.AccAddress, individualReward types.Reward) (stop bool) {
+			k.matureIndividualReward(ctx, walletAddress, epoch, individualReward)
+			if !matured[walletAddress.String()] {
+				matured[walletAddress.String()] = true
+				maturedWallets = append(maturedWallets, walletAddress)
+			}
 			return false
 		})
 	}
+	k.SetLastMaturedEpoch(ctx, matureEndEpoch)
+
+	// auto withdraw or restake, once the iteration is over
+	for _, walletAddress := range maturedWallets {
+		k.applyRewardPreference(ctx, walletAddress)
+	}
+}
+
+// matureIndividualReward moves the individual reward of the wallet from its immature total to its mature total
+func (k Keeper) matureIndividualReward(ctx sdk.Context, walletAddress sdk.AccAddress, epoch sdk.Int, individualReward types.Reward) {
+	oldMatureTotal := k.GetMatureTotalReward(ctx, walletAddress)
+	oldImmatureTotal := k.GetImmatureTotalReward(ctx, walletAddress)
+	immatureToMature := individualReward.RewardFromMiningPool.Add(individualReward.RewardFromTrafficPool...)
+
+	//deduct slashing amount from mature total pool
+	oldMatureTotalSubSlashing := k.RegisterKeeper.DeductSlashing(ctx, walletAddress, oldMatureTotal)
+	//deduct slashing amount from upcoming mature reward, don't need to deduct slashing from immatureTotal & individual
+	immatureToMatureSubSlashing := k.RegisterKeeper.DeductSlashing(ctx, walletAddress, immatureToMature)
+
+	matureTotal := oldMatureTotalSubSlashing.Add(immatureToMatureSubSlashing...)
+	immatureTotal := oldImmatureTotal.Sub(immatureToMature)
+
+	k.SetMatureTotalReward(ctx, walletAddress, matureTotal)
+	k.SetImmatureTotalReward(ctx, walletAddress, immatureTotal)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeRewardMature,
+			sdk.NewAttribute(types.AttributeKeyWalletAddress, walletAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyAmount, immatureToMatureSubSlashing.String()),
+			sdk.NewAttribute(types.AttributeKeyEpoch, epoch.String()),
+		),
+	)
 }
 
 // reward will mature 14 days since distribution. Each epoch interval is about 10 minutes.
@@ -332,10 +414,15 @@ func (k Keeper) CalcRewardForResourceNode(ctx sdk.Context, trafficList []types.S
 	// 1, calc stake reward
 	totalStakeOfResourceNodes := k.RegisterKeeper.GetResourceNodeBondedToken(ctx).Amount
 	resourceNodeList := k.RegisterKeeper.GetAllResourceNodes(ctx)
+	resourceNodesByOwner := make(map[string][]regtypes.ResourceNode)
 	for _, node := range resourceNodeList {
 		walletAddr := node.GetOwnerAddr()
+		resourceNodesByOwner[walletAddr.String()] = append(resourceNodesByOwner[walletAddr.String()], node)
 
-		shareOfToken := node.GetTokens().ToDec().Quo(totalStakeOfResourceNodes.ToDec())
+		shareOfToken := sdk.ZeroDec()
+		if totalStakeOfResourceNodes.IsPositive() {
+			shareOfToken = node.GetTokens().ToDec().Quo(totalStakeOfResourceNodes.ToDec())
+		}
 		stakeRewardFromMiningPool := sdk.NewCoin(k.RewardDenom(ctx),
 			distributeGoal.BlockChainRewardToResourceNodeFromMiningPool.Amount.ToDec().Mul(shareOfToken).TruncateInt())
 		stakeRewardFromTrafficPool := sdk.NewCoin(k.BondDenom(ctx),
@@ -344,6 +431,11 @@ func (k Keeper) CalcRewardForResourceNode(ctx sdk.Context, trafficList []types.S
 		totalUsedFromMiningPool = totalUsedFromMiningPool.Add(stakeRewardFromMiningPool)
 		totalUsedFromTrafficPool = totalUsedFromTrafficPool.Add(stakeRewardFromTrafficPool)
 
+		stakeRewardFromMiningPool, stakeRewardFromTrafficPool = k.splitRewardWithDelegators(ctx,
+			node.GetNetworkAddr(), node.GetTokens(), stakeRewardFromMiningPool, stakeRewardFromTrafficPool, rewardDetailMap)
+		stakeRewardFromMiningPool, stakeRewardFromTrafficPool = k.splitRewardWithBeneficiaries(node.GetCommission(),
+			node.GetBeneficiaries(), stakeRewardFromMiningPool, stakeRewardFromTrafficPool, rewardDetailMap)
+
 		if _, ok := rewardDetailMap[walletAddr.String()]; !ok {
 			reward := types.NewDefaultReward(walletAddr)
 			rewardDetailMap[walletAddr.String()] = reward
@@ -371,7 +463,10 @@ func (k Keeper) CalcRewardForResourceNode(ctx sdk.Context, trafficList []types.S
 		walletAddr := walletTraffic.WalletAddress
 		trafficVolume := walletTraffic.Volume
 
-		shareOfTraffic := trafficVolume.ToDec().Quo(totalConsumedOzone.ToDec())
+		shareOfTraffic := sdk.ZeroDec()
+		if totalConsumedOzone.IsPositive() {
+			shareOfTraffic = trafficVolume.ToDec().Quo(totalConsumedOzone.ToDec())
+		}
 		trafficRewardFromMiningPool := sdk.NewCoin(k.RewardDenom(ctx),
 			distributeGoal.TrafficRewardToResourceNodeFromMiningPool.Amount.ToDec().Mul(shareOfTraffic).TruncateInt())
 		trafficRewardFromTrafficPool := sdk.NewCoin(k.BondDenom(ctx),
@@ -380,6 +475,9 @@ func (k Keeper) CalcRewardForResourceNode(ctx sdk.Context, trafficList []types.S
 		totalUsedFromMiningPool = totalUsedFromMiningPool.Add(trafficRewardFromMiningPool)
 		totalUsedFromTrafficPool = totalUsedFromTrafficPool.Add(trafficRewardFromTrafficPool)
 
+		trafficRewardFromMiningPool, trafficRewardFromTrafficPool = k.splitTrafficRewardWithNodes(ctx,
+			resourceNodesByOwner[walletAddr.String()], trafficRewardFromMiningPool, trafficRewardFromTrafficPool, rewardDetailMap)
+
 		if _, ok := rewardDetailMap[walletAddr.String()]; !ok {
 			reward := types.NewDefaultReward(walletAddr)
 			rewardDetailMap[walletAddr.String()] = reward
@@ -414,7 +512,10 @@ func (k Keeper) CalcRewardForIndexingNode(ctx sdk.Context, distributeGoal types.
 		walletAddr := node.GetOwnerAddr()
 
 		// 1, calc stake reward
-		shareOfToken := node.GetTokens().ToDec().Quo(totalStakeOfIndexingNodes.ToDec())
+		shareOfToken := sdk.ZeroDec()
+		if totalStakeOfIndexingNodes.IsPositive() {
+			shareOfToken = node.GetTokens().ToDec().Quo(totalStakeOfIndexingNodes.ToDec())
+		}
 		stakeRewardFromMiningPool := sdk.NewCoin(k.RewardDenom(ctx),
 			distributeGoal.BlockChainRewardToIndexingNodeFromMiningPool.Amount.ToDec().Mul(shareOfToken).TruncateInt())
 		stakeRewardFromTrafficPool := sdk.NewCoin(k.BondDenom(ctx),
@@ -432,14 +533,20 @@ func (k Keeper) CalcRewardForIndexingNode(ctx sdk.Context, distributeGoal types.
 		totalUsedIndexingRewardFromMiningPool = totalUsedIndexingRewardFromMiningPool.Add(indexingRewardFromMiningPool)
 		totalUsedIndexingRewardFromTrafficPool = totalUsedIndexingRewardFromTrafficPool.Add(indexingRewardFromTrafficPool)
 
+		ownerRewardFromMiningPool, ownerRewardFromTrafficPool := k.splitRewardWithDelegators(ctx, node.GetNetworkAddr(),
+			node.GetTokens(), stakeRewardFromMiningPool.Add(indexingRewardFromMiningPool),
+			stakeRewardFromTrafficPool.Add(indexingRewardFromTrafficPool), rewardDetailMap)
+		ownerRewardFromMiningPool, ownerRewardFromTrafficPool = k.splitRewardWithBeneficiaries(node.GetCommission(),
+			node.GetBeneficiaries(), ownerRewardFromMiningPool, ownerRewardFromTrafficPool, rewardDetailMap)
+
 		if _, ok := rewardDetailMap[walletAddr.String()]; !ok {
 			reward := types.NewDefaultReward(walletAddr)
 			rewardDetailMap[walletAddr.String()] = reward
 		}
 
 		newReward := rewardDetailMap[walletAddr.String()]
-		newReward = newReward.AddRewardFromMiningPool(stakeRewardFromMiningPool.Add(indexingRewardFromMiningPool))
-		newReward = newReward.AddRewardFromTrafficPool(stakeRewardFromTrafficPool.Add(indexingRewardFromTrafficPool))
+		newReward = newReward.AddRewardFromMiningPool(ownerRewardFromMiningPool)
+		newReward = newReward.AddRewardFromTrafficPool(ownerRewardFromTrafficPool)
 		rewardDetailMap[walletAddr.String()] = newReward
 	}
 	// deduct used reward from distributeGoal
@@ -455,6 +562,107 @@ func (k Keeper) CalcRewardForIndexingNode(ctx sdk.Context, distributeGoal types.
 	return rewardDetailMap, distributeGoal
 }
 
+// splitTrafficRewardWithNodes splits the traffic reward of the wallet between the resource nodes it owns, in proportion
+// to their tokens, credits the delegators and the beneficiaries of each node with their share of it, and returns the
+// part left to the wallet. A wallet owning no bonded resource node keeps its whole traffic reward.
+func (k Keeper) splitTrafficRewardWithNodes(ctx sdk.Context, ownedNodes []regtypes.ResourceNode,
+	trafficRewardFromMiningPool, trafficRewardFromTrafficPool sdk.Coin, rewardDetailMap map[string]types.Reward,
+) (ownerRewardFromMiningPool sdk.Coin, ownerRewardFromTrafficPool sdk.Coin) {
+
+	ownerRewardFromMiningPool = trafficRewardFromMiningPool
+	ownerRewardFromTrafficPool = trafficRewardFromTrafficPool
+	ownedTokens := sdk.ZeroInt()
+	for _, node := range ownedNodes {
+		ownedTokens = ownedTokens.Add(node.GetTokens())
+	}
+	if !ownedTokens.IsPositive() {
+		return
+	}
+
+	for _, node := range ownedNodes {
+		shareOfNode := node.GetTokens().ToDec().Quo(ownedTokens.ToDec())
+		nodeRewardFromMiningPool := sdk.NewCoin(trafficRewardFromMiningPool.Denom,
+			trafficRewardFromMiningPool.Amount.ToDec().Mul(shareOfNode).TruncateInt())
+		nodeRewardFromTrafficPool := sdk.NewCoin(trafficRewardFromTrafficPool.Denom,
+			trafficRewardFromTrafficPool.Amount.ToDec().Mul(shareOfNode).TruncateInt())
+
+		nodeOwnerRewardFromMiningPool, nodeOwnerRewardFromTrafficPool := k.splitRewardWithDelegators(ctx,
+			node.GetNetworkAddr(), node.GetTokens(), nodeRewardFromMiningPool, nodeRewardFromTrafficPool, rewardDetailMap)
+		nodeOwnerRewardFromMiningPool, nodeOwnerRewardFromTrafficPool = k.splitRewardWithBeneficiaries(node.GetCommission(),
+			node.GetBeneficiaries(), nodeOwnerRewardFromMiningPool, nodeOwnerRewardFromTrafficPool, rewardDetailMap)
+
+		// the remainder of the split between the nodes stays with the wallet
+		ownerRewardFromMiningPool = ownerRewardFromMiningPool.Sub(nodeRewardFromMiningPool).Add(nodeOwnerRewardFromMiningPool)
+		ownerRewardFromTrafficPool = ownerRewardFromTrafficPool.Sub(nodeRewardFromTrafficPool).Add(nodeOwnerRewardFromTrafficPool)
+	}
+	return
+}
+
+// splitRewardWithDelegators credits the delegators of the node with their share of its reward,
+// in proportion to the tokens they delegated, and returns the part of the reward left to the node owner
+func (k Keeper) splitRewardWithDelegators(ctx sdk.Context, networkAddr stratos.SdsAddress, nodeTokens sdk.Int,
+	nodeRewardFromMiningPool, nodeRewardFromTrafficPool sdk.Coin, rewardDetailMap map[string]types.Reward,
+) (ownerRewardFromMiningPool sdk.Coin, ownerRewardFromTrafficPool sdk.Coin) {
+
+	ownerRewardFromMiningPool = nodeRewardFromMiningPool
+	ownerRewardFromTrafficPool = nodeRewardFromTrafficPool
+	if !nodeTokens.IsPositive() {
+		return
+	}
+
+	for _, delegation := range k.RegisterKeeper.GetNodeDelegations(ctx, networkAddr) {
+		shareOfDelegation := delegation.Amount.ToDec().Quo(nodeTokens.ToDec())
+		rewardFromMiningPool := sdk.NewCoin(nodeRewardFromMiningPool.Denom,
+			nodeRewardFromMiningPool.Amount.ToDec().Mul(shareOfDelegation).TruncateInt())
+		rewardFromTrafficPool := sdk.NewCoin(nodeRewardFromTrafficPool.Denom,
+			nodeRewardFromTrafficPool.Amount.ToDec().Mul(shareOfDelegation).TruncateInt())
+
+		ownerRewardFromMiningPool = ownerRewardFromMiningPool.Sub(rewardFromMiningPool)
+		ownerRewardFromTrafficPool = ownerRewardFromTrafficPool.Sub(rewardFromTrafficPool)
+
+		delegatorAddr := delegation.DelegatorAddress
+		if _, ok := rewardDetailMap[delegatorAddr.String()]; !ok {
+			rewardDetailMap[delegatorAddr.String()] = types.NewDefaultReward(delegatorAddr)
+		}
+		newReward := rewardDetailMap[delegatorAddr.String()]
+		newReward = newReward.AddRewardFromMiningPool(rewardFromMiningPool)
+		newReward = newReward.AddRewardFromTrafficPool(rewardFromTrafficPool)
+		rewardDetailMap[delegatorAddr.String()] = newReward
+	}
+	return
+}
+
+// splitRewardWithBeneficiaries keeps the commission of the node for its owner and splits the remainder of the reward
+// evenly between the beneficiaries of the node. Without beneficiaries, the whole reward is left to the owner.
+func (k Keeper) splitRewardWithBeneficiaries(commission regtypes.Commission, beneficiaries []sdk.AccAddress,
+	rewardFromMiningPool, rewardFromTrafficPool sdk.Coin, rewardDetailMap map[string]types.Reward,
+) (ownerRewardFromMiningPool sdk.Coin, ownerRewardFromTrafficPool sdk.Coin) {
+
+	ownerRewardFromMiningPool = rewardFromMiningPool
+	ownerRewardFromTrafficPool = rewardFromTrafficPool
+	if len(beneficiaries) == 0 {
+		return
+	}
+
+	beneficiaryCnt := sdk.NewInt(int64(len(beneficiaries)))
+	shareFromMiningPool := rewardFromMiningPool.Amount.Sub(commission.CommissionOf(rewardFromMiningPool.Amount)).Quo(beneficiaryCnt)
+	shareFromTrafficPool := rewardFromTrafficPool.Amount.Sub(commission.CommissionOf(rewardFromTrafficPool.Amount)).Quo(beneficiaryCnt)
+	for _, beneficiary := range beneficiaries {
+		// the remainder of the even split stays with the owner
+		ownerRewardFromMiningPool = ownerRewardFromMiningPool.Sub(sdk.NewCoin(rewardFromMiningPool.Denom, shareFromMiningPool))
+		ownerRewardFromTrafficPool = ownerRewardFromTrafficPool.Sub(sdk.NewCoin(rewardFromTrafficPool.Denom, shareFromTrafficPool))
+
+		if _, ok := rewardDetailMap[beneficiary.String()]; !ok {
+			rewardDetailMap[beneficiary.String()] = types.NewDefaultReward(beneficiary)
+		}
+		newReward := rewardDetailMap[beneficiary.String()]
+		newReward = newReward.AddRewardFromMiningPool(sdk.NewCoin(rewardFromMiningPool.Denom, shareFromMiningPool))
+		newReward = newReward.AddRewardFromTrafficPool(sdk.NewCoin(rewardFromTrafficPool.Denom, shareFromTrafficPool))
+		rewardDetailMap[beneficiary.String()] = newReward
+	}
+	return
+}
+
 func (k Keeper) GetTotalConsumedUoz(trafficList []types.SingleWalletVolume) sdk.Int {
 	totalTraffic := sdk.ZeroInt()
 	for _, vol := range trafficList {
@@ -471,6 +679,9 @@ func (k Keeper) splitRewardByStake(ctx sdk.Context, totalReward sdk.Int,
 	indexingNodeBondedTokens := k.RegisterKeeper.GetIndexingNodeBondedToken(ctx).Amount.ToDec()
 
 	totalBondedTokens := validatorBondedTokens.Add(resourceNodeBondedTokens).Add(indexingNodeBondedTokens)
+	if !totalBondedTokens.IsPositive() {
+		return sdk.ZeroInt(), sdk.ZeroInt(), sdk.ZeroInt()
+	}
 
 	validatorReward = totalReward.ToDec().Mul(validatorBondedTokens).Quo(totalBondedTokens).TruncateInt()
 	resourceNodeReward = totalReward.ToDec().Mul(resourceNodeBondedTokens).Quo(totalBondedTokens).TruncateInt()
@@ -8,6 +8,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/bank"
 	"github.com/cosmos/cosmos-sdk/x/staking"
+	"github.com/stratosnet/stratos-chain/crypto/bls"
 	stratos "github.com/stratosnet/stratos-chain/types"
 	"github.com/stratosnet/stratos-chain/x/pot/types"
 	"github.com/stratosnet/stratos-chain/x/register"
@@ -127,14 +128,17 @@ func Test(t *testing.T) {
 	createAccount(t, ctx, accountKeeper, bankKeeper, idxOwner2, sdk.NewCoins(initialStakeIdx2))
 	createAccount(t, ctx, accountKeeper, bankKeeper, idxOwner3, sdk.NewCoins(initialStakeIdx3))
 	//initialize sds node register msg
-	msgRes1 := register.NewMsgCreateResourceNode(addrRes1, pubKeyRes1, initialStakeRes1, resOwner1, register.NewDescription("sds://resourceNode1", "", "", "", ""), 4)
-	msgRes2 := register.NewMsgCreateResourceNode(addrRes2, pubKeyRes2, initialStakeRes2, resOwner2, register.NewDescription("sds://resourceNode2", "", "", "", ""), 4)
-	msgRes3 := register.NewMsgCreateResourceNode(addrRes3, pubKeyRes3, initialStakeRes3, resOwner3, register.NewDescription("sds://resourceNode3", "", "", "", ""), 4)
-	msgRes4 := register.NewMsgCreateResourceNode(addrRes4, pubKeyRes4, initialStakeRes4, resOwner4, register.NewDescription("sds://resourceNode4", "", "", "", ""), 4)
-	msgRes5 := register.NewMsgCreateResourceNode(addrRes5, pubKeyRes5, initialStakeRes5, resOwner5, register.NewDescription("sds://resourceNode5", "", "", "", ""), 4)
-	msgIdx1 := register.NewMsgCreateIndexingNode(addrIdx1, pubKeyIdx1, initialStakeIdx1, idxOwner1, register.NewDescription("sds://indexingNode1", "", "", "", ""))
-	msgIdx2 := register.NewMsgCreateIndexingNode(addrIdx2, pubKeyIdx2, initialStakeIdx2, idxOwner2, register.NewDescription("sds://indexingNode2", "", "", "", ""))
-	msgIdx3 := register.NewMsgCreateIndexingNode(addrIdx3, pubKeyIdx3, initialStakeIdx3, idxOwner3, register.NewDescription("sds://indexingNode3", "", "", "", ""))
+	msgRes1 := register.NewMsgCreateResourceNode(addrRes1, pubKeyRes1, initialStakeRes1, resOwner1, register.NewDescription("sds://resourceNode1", "", "", "", ""), 4, register.CommissionRates{}, nil)
+	msgRes2 := register.NewMsgCreateResourceNode(addrRes2, pubKeyRes2, initialStakeRes2, resOwner2, register.NewDescription("sds://resourceNode2", "", "", "", ""), 4, register.CommissionRates{}, nil)
+	msgRes3 := register.NewMsgCreateResourceNode(addrRes3, pubKeyRes3, initialStakeRes3, resOwner3, register.NewDescription("sds://resourceNode3", "", "", "", ""), 4, register.CommissionRates{}, nil)
+	msgRes4 := register.NewMsgCreateResourceNode(addrRes4, pubKeyRes4, initialStakeRes4, resOwner4, register.NewDescription("sds://resourceNode4", "", "", "", ""), 4, register.CommissionRates{}, nil)
+	msgRes5 := register.NewMsgCreateResourceNode(addrRes5, pubKeyRes5, initialStakeRes5, resOwner5, register.NewDescription("sds://resourceNode5", "", "", "", ""), 4, register.CommissionRates{}, nil)
+	blsPubKeyIdx1, blsProofIdx1 := bls.GenPubKeyAndProof()
+	msgIdx1 := register.NewMsgCreateIndexingNode(addrIdx1, pubKeyIdx1, initialStakeIdx1, idxOwner1, register.NewDescription("sds://indexingNode1", "", "", "", ""), blsPubKeyIdx1, blsProofIdx1, register.CommissionRates{}, nil)
+	blsPubKeyIdx2, blsProofIdx2 := bls.GenPubKeyAndProof()
+	msgIdx2 := register.NewMsgCreateIndexingNode(addrIdx2, pubKeyIdx2, initialStakeIdx2, idxOwner2, register.NewDescription("sds://indexingNode2", "", "", "", ""), blsPubKeyIdx2, blsProofIdx2, register.CommissionRates{}, nil)
+	blsPubKeyIdx3, blsProofIdx3 := bls.GenPubKeyAndProof()
+	msgIdx3 := register.NewMsgCreateIndexingNode(addrIdx3, pubKeyIdx3, initialStakeIdx3, idxOwner3, register.NewDescription("sds://indexingNode3", "", "", "", ""), blsPubKeyIdx3, blsProofIdx3, register.CommissionRates{}, nil)
 
 	//register sds nodes
 	registerHandler := register.NewHandler(registerKeeper)
@@ -802,3 +806,110 @@ func getFeePoolBalance(t *testing.T, ctx sdk.Context, k Keeper, bankKeeper bank.
 	coins := bankKeeper.GetCoins(ctx, feePoolAccAddr)
 	return coins
 }
+
+func TestRewardSplitWithDelegators(t *testing.T) {
+	ctx, accountKeeper, bankKeeper, k, _, _, _, registerKeeper := CreateTestInput(t, false)
+
+	createAccount(t, ctx, accountKeeper, bankKeeper, resOwner1, sdk.NewCoins(initialStakeRes1))
+	createAccount(t, ctx, accountKeeper, bankKeeper, resOwner2, sdk.NewCoins(initialStakeRes1))
+	msgRes1 := register.NewMsgCreateResourceNode(addrRes1, pubKeyRes1, initialStakeRes1, resOwner1, register.NewDescription("sds://resourceNode1", "", "", "", ""), 4, register.CommissionRates{}, nil)
+	_, err := register.NewHandler(registerKeeper)(ctx, msgRes1)
+	require.NoError(t, err)
+
+	// resOwner2 delegates as much as the owner staked, so it earns half of the stake & traffic rewards of the node
+	_, err = registerKeeper.Delegate(ctx, resOwner2, addrRes1, false, initialStakeRes1)
+	require.NoError(t, err)
+
+	distributeGoal := types.InitDistributeGoal()
+	distributeGoal.BlockChainRewardToResourceNodeFromMiningPool = sdk.NewCoin(k.RewardDenom(ctx), sdk.NewInt(1000))
+	distributeGoal.BlockChainRewardToResourceNodeFromTrafficPool = sdk.NewCoin(k.BondDenom(ctx), sdk.NewInt(2001))
+	distributeGoal.TrafficRewardToResourceNodeFromMiningPool = sdk.NewCoin(k.RewardDenom(ctx), sdk.NewInt(1000))
+	distributeGoal.TrafficRewardToResourceNodeFromTrafficPool = sdk.NewCoin(k.BondDenom(ctx), sdk.ZeroInt())
+	trafficList := []types.SingleWalletVolume{types.NewSingleWalletVolume(resOwner1, sdk.NewInt(100))}
+
+	rewardDetailMap, distributeGoal := k.CalcRewardForResourceNode(ctx, trafficList, distributeGoal, make(map[string]types.Reward))
+	require.True(t, distributeGoal.BlockChainRewardToResourceNodeFromMiningPool.IsZero())
+	require.True(t, distributeGoal.BlockChainRewardToResourceNodeFromTrafficPool.IsZero())
+	require.True(t, distributeGoal.TrafficRewardToResourceNodeFromMiningPool.IsZero())
+
+	ownerReward := rewardDetailMap[resOwner1.String()]
+	delegatorReward := rewardDetailMap[resOwner2.String()]
+	require.Equal(t, sdk.NewInt(1000), ownerReward.RewardFromMiningPool.AmountOf(k.RewardDenom(ctx)))
+	require.Equal(t, sdk.NewInt(1000), delegatorReward.RewardFromMiningPool.AmountOf(k.RewardDenom(ctx)))
+	// the owner keeps the remainder of the truncated share of the delegator
+	require.Equal(t, sdk.NewInt(1001), ownerReward.RewardFromTrafficPool.AmountOf(k.BondDenom(ctx)))
+	require.Equal(t, sdk.NewInt(1000), delegatorReward.RewardFromTrafficPool.AmountOf(k.BondDenom(ctx)))
+
+	/********************* the delegators of an indexing node share its meta node reward *********************/
+	indexingNode := register.NewIndexingNode(addrIdx1, pubKeyIdx1, idxOwner1, register.NewDescription("sds://indexingNode1", "", "", "", ""), ctx.BlockHeader().Time)
+	indexingNode.Tokens = initialStakeIdx1.Amount.MulRaw(2)
+	registerKeeper.SetIndexingNode(ctx, indexingNode)
+	registerKeeper.SetDelegation(ctx, register.NewDelegation(resOwner2, addrIdx1, true, initialStakeIdx1.Amount))
+
+	distributeGoal = types.InitDistributeGoal()
+	distributeGoal.BlockChainRewardToIndexingNodeFromMiningPool = sdk.NewCoin(k.RewardDenom(ctx), sdk.ZeroInt())
+	distributeGoal.BlockChainRewardToIndexingNodeFromTrafficPool = sdk.NewCoin(k.BondDenom(ctx), sdk.ZeroInt())
+	distributeGoal.MetaNodeRewardToIndexingNodeFromMiningPool = sdk.NewCoin(k.RewardDenom(ctx), sdk.NewInt(1000))
+	distributeGoal.MetaNodeRewardToIndexingNodeFromTrafficPool = sdk.NewCoin(k.BondDenom(ctx), sdk.ZeroInt())
+	rewardDetailMap, distributeGoal = k.CalcRewardForIndexingNode(ctx, distributeGoal, make(map[string]types.Reward))
+	require.True(t, distributeGoal.MetaNodeRewardToIndexingNodeFromMiningPool.IsZero())
+	require.Equal(t, sdk.NewInt(500), rewardDetailMap[idxOwner1.String()].RewardFromMiningPool.AmountOf(k.RewardDenom(ctx)))
+	require.Equal(t, sdk.NewInt(500), rewardDetailMap[resOwner2.String()].RewardFromMiningPool.AmountOf(k.RewardDenom(ctx)))
+}
+
+func TestRewardSplitWithBeneficiaries(t *testing.T) {
+	ctx, accountKeeper, bankKeeper, k, _, _, _, registerKeeper := CreateTestInput(t, false)
+
+	createAccount(t, ctx, accountKeeper, bankKeeper, resOwner1, sdk.NewCoins(initialStakeRes1))
+	// the owner keeps half of the rewards, the other half is split between two beneficiaries
+	commission := register.NewCommissionRates(sdk.NewDecWithPrec(5, 1), sdk.NewDecWithPrec(1, 1))
+	beneficiaries := []sdk.AccAddress{resOwner2, resOwner3}
+	msgRes1 := register.NewMsgCreateResourceNode(addrRes1, pubKeyRes1, initialStakeRes1, resOwner1, register.NewDescription("sds://resourceNode1", "", "", "", ""), 4, commission, beneficiaries)
+	_, err := register.NewHandler(registerKeeper)(ctx, msgRes1)
+	require.NoError(t, err)
+
+	distributeGoal := types.InitDistributeGoal()
+	distributeGoal.BlockChainRewardToResourceNodeFromMiningPool = sdk.NewCoin(k.RewardDenom(ctx), sdk.NewInt(1000))
+	distributeGoal.BlockChainRewardToResourceNodeFromTrafficPool = sdk.NewCoin(k.BondDenom(ctx), sdk.NewInt(1001))
+	distributeGoal.TrafficRewardToResourceNodeFromMiningPool = sdk.NewCoin(k.RewardDenom(ctx), sdk.NewInt(1000))
+	distributeGoal.TrafficRewardToResourceNodeFromTrafficPool = sdk.NewCoin(k.BondDenom(ctx), sdk.ZeroInt())
+	trafficList := []types.SingleWalletVolume{types.NewSingleWalletVolume(resOwner1, sdk.NewInt(100))}
+
+	rewardDetailMap, distributeGoal := k.CalcRewardForResourceNode(ctx, trafficList, distributeGoal, make(map[string]types.Reward))
+	require.True(t, distributeGoal.BlockChainRewardToResourceNodeFromMiningPool.IsZero())
+	require.True(t, distributeGoal.BlockChainRewardToResourceNodeFromTrafficPool.IsZero())
+	require.True(t, distributeGoal.TrafficRewardToResourceNodeFromMiningPool.IsZero())
+
+	// the stake & traffic rewards of the node are both split with the beneficiaries
+	ownerReward := rewardDetailMap[resOwner1.String()]
+	require.Equal(t, sdk.NewInt(1000), ownerReward.RewardFromMiningPool.AmountOf(k.RewardDenom(ctx)))
+	// the owner keeps the remainder of the even split between the beneficiaries
+	require.Equal(t, sdk.NewInt(501), ownerReward.RewardFromTrafficPool.AmountOf(k.BondDenom(ctx)))
+	for _, beneficiary := range beneficiaries {
+		beneficiaryReward := rewardDetailMap[beneficiary.String()]
+		require.Equal(t, sdk.NewInt(500), beneficiaryReward.RewardFromMiningPool.AmountOf(k.RewardDenom(ctx)))
+		require.Equal(t, sdk.NewInt(250), beneficiaryReward.RewardFromTrafficPool.AmountOf(k.BondDenom(ctx)))
+	}
+}
+
+func TestRewardMatureWithoutReports(t *testing.T) {
+	ctx, _, _, k, _, _, _, _ := CreateTestInput(t, false)
+	ctx = ctx.WithBlockHeight(k.MaturationInterval(ctx))
+	reward := sdk.NewCoins(sdk.NewCoin(k.BondDenom(ctx), sdk.NewInt(1000)))
+
+	// a chain which did not track the matured epoch yet matured up to the last reported epoch
+	k.SetLastReportedEpoch(ctx, sdk.OneInt())
+	require.Equal(t, sdk.OneInt(), k.GetLastMaturedEpoch(ctx))
+
+	// the matured epoch keeps advancing while no volume is reported
+	k.addNewIndividualAndUpdateImmatureTotal(ctx, resOwner1, sdk.NewInt(2), types.NewReward(resOwner1, sdk.Coins{}, reward))
+	k.RewardMature(ctx)
+	require.Equal(t, sdk.NewInt(2), k.GetLastMaturedEpoch(ctx))
+	require.Equal(t, reward, k.GetMatureTotalReward(ctx, resOwner1))
+	require.True(t, k.GetImmatureTotalReward(ctx, resOwner1).IsZero())
+
+	// a reward credited to an epoch which already matured matures at once
+	k.addNewIndividualAndUpdateImmatureTotal(ctx, resOwner2, sdk.NewInt(2), types.NewReward(resOwner2, sdk.Coins{}, reward))
+	require.Equal(t, reward, k.GetMatureTotalReward(ctx, resOwner2))
+	require.True(t, k.GetImmatureTotalReward(ctx, resOwner2).IsZero())
+}
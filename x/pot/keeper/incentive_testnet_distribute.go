@@ -338,7 +338,7 @@ func (k Keeper) returnBalanceForTestnet(ctx sdk.Context, goal types.DistributeGo
 	oldMinedToken := k.GetMinedTokens(ctx, epoch)
 	newMinedToken := oldMinedToken.Sub(balanceOfMiningPool)
 	k.SetTotalMinedTokens(ctx, newTotalMinedToken)
-	k.setMinedTokens(ctx, epoch, newMinedToken)
+	k.SetMinedTokens(ctx, epoch, newMinedToken)
 
 	// return balance to prepay pool
 	totalUnIssuedPrepay := k.RegisterKeeper.GetTotalUnissuedPrepay(ctx)
@@ -0,0 +1,101 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/stratosnet/stratos-chain/x/pot/types"
+)
+
+// RegisterInvariants registers all pot invariants
+func RegisterInvariants(ir sdk.InvariantRegistry, k Keeper) {
+	ir.RegisterRoute(types.ModuleName, "immature-total",
+		ImmatureTotalInvariant(k))
+	ir.RegisterRoute(types.ModuleName, "distributed-rewards",
+		DistributedRewardsInvariant(k))
+}
+
+// AllInvariants runs all invariants of the pot module.
+func AllInvariants(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		res, stop := ImmatureTotalInvariant(k)(ctx)
+		if stop {
+			return res, stop
+		}
+
+		return DistributedRewardsInvariant(k)(ctx)
+	}
+}
+
+// ImmatureTotalInvariant checks that the immature total of each wallet equals
+// the sum of its individual rewards which have not matured yet
+func ImmatureTotalInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		lastMaturedEpoch := k.GetLastMaturedEpoch(ctx)
+		expected := make(map[string]sdk.Coins)
+		k.IteratorAllIndividualRewards(ctx, func(epoch sdk.Int, individualReward types.Reward) (stop bool) {
+			if epoch.LTE(lastMaturedEpoch) {
+				return false
+			}
+			walletAddr := individualReward.WalletAddress.String()
+			expected[walletAddr] = expected[walletAddr].
+				Add(individualReward.RewardFromMiningPool...).
+				Add(individualReward.RewardFromTrafficPool...)
+			return false
+		})
+
+		var msg string
+		count := 0
+		k.IteratorImmatureTotal(ctx, func(walletAddress sdk.AccAddress, immatureTotal sdk.Coins) (stop bool) {
+			walletAddr := walletAddress.String()
+			if !immatureTotal.IsEqual(expected[walletAddr]) {
+				count++
+				msg += fmt.Sprintf("\t%v has an immature total of %v, sum of immature rewards is %v\n",
+					walletAddr, immatureTotal, expected[walletAddr])
+			}
+			delete(expected, walletAddr)
+			return false
+		})
+		for walletAddr, rewards := range expected {
+			if !rewards.IsZero() {
+				count++
+				msg += fmt.Sprintf("\t%v has no immature total, sum of immature rewards is %v\n", walletAddr, rewards)
+			}
+		}
+		broken := count != 0
+
+		return sdk.FormatInvariant(types.ModuleName, "immature-total", fmt.Sprintf(
+			"%d wallets with an invalid immature total found\n%s", count, msg)), broken
+	}
+}
+
+// DistributedRewardsInvariant checks that the immature & mature totals of all wallets
+// never exceed the rewards distributed so far, since withdrawals & slashing only reduce them
+func DistributedRewardsInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		distributed := sdk.Coins{}
+		k.IteratorAllIndividualRewards(ctx, func(_ sdk.Int, individualReward types.Reward) (stop bool) {
+			distributed = distributed.
+				Add(individualReward.RewardFromMiningPool...).
+				Add(individualReward.RewardFromTrafficPool...)
+			return false
+		})
+
+		totals := sdk.Coins{}
+		k.IteratorImmatureTotal(ctx, func(_ sdk.AccAddress, immatureTotal sdk.Coins) (stop bool) {
+			totals = totals.Add(immatureTotal...)
+			return false
+		})
+		k.IteratorMatureTotal(ctx, func(_ sdk.AccAddress, matureTotal sdk.Coins) (stop bool) {
+			totals = totals.Add(matureTotal...)
+			return false
+		})
+		broken := !distributed.IsAllGTE(totals)
+
+		return sdk.FormatInvariant(types.ModuleName, "distributed-rewards", fmt.Sprintf(
+			"\tsum of immature & mature totals: %v\n"+
+				"\tsum of distributed rewards: %v\n",
+			totals, distributed)), broken
+	}
+}
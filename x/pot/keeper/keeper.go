@@ -57,7 +57,7 @@ func (k Keeper) Logger(ctx sdk.Context) log.Logger {
 func (k Keeper) VolumeReport(ctx sdk.Context, walletVolumes []types.SingleWalletVolume, reporter stratos.SdsAddress,
 	epoch sdk.Int, reportReference string, txHash string) (totalConsumedOzone sdk.Dec, err error) {
 	//record volume report
-	reportRecord := types.NewReportRecord(reporter, reportReference, txHash)
+	reportRecord := types.NewReportRecord(reporter, reportReference, txHash, walletVolumes)
 	k.SetVolumeReport(ctx, epoch, reportRecord)
 	//distribute POT reward
 	//TODO: recovery when shift to main net
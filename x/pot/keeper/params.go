@@ -1,6 +1,8 @@
 package keeper
 
 import (
+	"time"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/stratosnet/stratos-chain/x/pot/types"
 )
@@ -16,6 +18,17 @@ func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
 	k.paramSpace.SetParamSet(ctx, &params)
 }
 
+// MigrateParams sets the params missing from the param space of a chain started before they were added
+// to their default values, the other params are left unchanged
+func (k Keeper) MigrateParams(ctx sdk.Context) {
+	defaultParams := types.DefaultParams()
+	for _, pair := range defaultParams.ParamSetPairs() {
+		if !k.paramSpace.Has(ctx, pair.Key) {
+			k.paramSpace.Set(ctx, pair.Key, pair.Value)
+		}
+	}
+}
+
 // BondDenom - Bondable coin denomination
 func (k Keeper) BondDenom(ctx sdk.Context) (res string) {
 	k.paramSpace.Get(ctx, types.KeyBondDenom, &res)
@@ -37,6 +50,31 @@ func (k Keeper) MiningRewardParams(ctx sdk.Context) (res []types.MiningRewardPar
 	return
 }
 
+func (k Keeper) VolumeReportThreshold(ctx sdk.Context) (res sdk.Dec) {
+	k.paramSpace.Get(ctx, types.KeyVolumeReportThreshold, &res)
+	return
+}
+
+func (k Keeper) MaturationInterval(ctx sdk.Context) (res int64) {
+	k.paramSpace.Get(ctx, types.KeyMaturationInterval, &res)
+	return
+}
+
+func (k Keeper) StakeSlashFraction(ctx sdk.Context) (res sdk.Dec) {
+	k.paramSpace.Get(ctx, types.KeyStakeSlashFraction, &res)
+	return
+}
+
+func (k Keeper) RedistributeSlashedStake(ctx sdk.Context) (res bool) {
+	k.paramSpace.Get(ctx, types.KeyRedistributeSlashedStake, &res)
+	return
+}
+
+func (k Keeper) SuspendDuration(ctx sdk.Context) (res time.Duration) {
+	k.paramSpace.Get(ctx, types.KeySuspendDuration, &res)
+	return
+}
+
 func (k Keeper) GetMiningRewardParamByMinedToken(ctx sdk.Context, minedToken sdk.Coin) (types.MiningRewardParam, error) {
 	miningRewardParams := k.MiningRewardParams(ctx)
 	for _, param := range miningRewardParams {
@@ -17,6 +17,9 @@ const (
 	QueryPotRewardsByReportEpoch = "query_pot_rewards_by_report_epoch"
 	QueryPotRewardsByWalletAddr  = "query_pot_rewards_by_wallet_address"
 	QueryPotSlashingByWalletAddr = "query_pot_slashing_by_wallet_address"
+	QueryWalletVolumesByEpoch    = "query_wallet_volumes_by_epoch"
+	QueryWalletVolumeHistory     = "query_wallet_volume_history"
+	QuerySimulateDistribution    = "query_simulate_distribution"
 	QueryDefaultLimit            = 100
 )
 
@@ -32,6 +35,12 @@ func NewQuerier(k Keeper) sdk.Querier {
 			return queryPotRewardsByWalletAddress(ctx, req, k)
 		case QueryPotSlashingByWalletAddr:
 			return queryPotSlashingByWalletAddress(ctx, req, k)
+		case QueryWalletVolumesByEpoch:
+			return queryWalletVolumesByEpoch(ctx, req, k)
+		case QueryWalletVolumeHistory:
+			return queryWalletVolumeHistory(ctx, req, k)
+		case QuerySimulateDistribution:
+			return querySimulateDistribution(ctx, req, k)
 		default:
 			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "unknown pot query endpoint")
 		}
@@ -127,3 +136,106 @@ func queryPotSlashingByWalletAddress(ctx sdk.Context, req abci.RequestQuery, k K
 
 	return []byte(k.RegisterKeeper.GetSlashing(ctx, addr).String()), nil
 }
+
+// queryWalletVolumesByEpoch fetches the volume & reward of each wallet reported at the supplied epoch.
+func queryWalletVolumesByEpoch(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryWalletVolumesByEpochParams
+	err := k.cdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	reportRecord := k.GetVolumeReport(ctx, params.Epoch)
+	if reportRecord.TxHash == "" {
+		e := sdkerrors.Wrapf(types.ErrCannotFindReport,
+			fmt.Sprintf("no volume report found at epoch %s. Current epoch is %s",
+				params.Epoch.String(), k.GetLastReportedEpoch(ctx).String()))
+		return nil, e
+	}
+
+	res := k.getWalletVolumesByEpoch(ctx, params, reportRecord)
+	bz, err := codec.MarshalJSONIndent(k.cdc, res)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
+
+func (k Keeper) getWalletVolumesByEpoch(ctx sdk.Context, params types.QueryWalletVolumesByEpochParams,
+	reportRecord types.VolumeReportRecord) (res []types.WalletVolumeRecord) {
+
+	reported := make(map[string]bool)
+	for _, walletVolume := range reportRecord.WalletVolumes {
+		walletAddr := walletVolume.WalletAddress
+		if reported[walletAddr.String()] {
+			continue
+		}
+		reported[walletAddr.String()] = true
+
+		record, found := k.GetWalletVolumeRecord(ctx, walletAddr, params.Epoch)
+		if !found {
+			record = types.NewWalletVolumeRecord(params.Epoch, walletAddr, walletVolume.Volume, types.NewDefaultReward(walletAddr))
+		}
+		res = append(res, record)
+	}
+
+	start, end := client.Paginate(len(res), params.Page, params.Limit, QueryDefaultLimit)
+	if start < 0 || end < 0 {
+		return []types.WalletVolumeRecord{}
+	}
+	return res[start:end]
+}
+
+// queryWalletVolumeHistory fetches the volume & reward of a wallet across all reported epochs.
+func queryWalletVolumeHistory(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryWalletVolumeHistoryParams
+	err := k.cdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	res := k.getWalletVolumeHistory(ctx, params)
+	bz, err := codec.MarshalJSONIndent(k.cdc, res)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
+
+func (k Keeper) getWalletVolumeHistory(ctx sdk.Context, params types.QueryWalletVolumeHistoryParams) (res []types.WalletVolumeRecord) {
+	k.IteratorWalletVolumeRecord(ctx, params.WalletAddress, func(record types.WalletVolumeRecord) (stop bool) {
+		res = append(res, record)
+		return false
+	})
+
+	start, end := client.Paginate(len(res), params.Page, params.Limit, QueryDefaultLimit)
+	if start < 0 || end < 0 {
+		return []types.WalletVolumeRecord{}
+	}
+	return res[start:end]
+}
+
+// querySimulateDistribution computes the pot reward distribution of a traffic list without changing the state.
+func querySimulateDistribution(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QuerySimulateDistributionParams
+	err := k.cdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+	if params.Epoch.IsNil() || !params.Epoch.IsPositive() {
+		return nil, types.ErrEpochNotPositive
+	}
+	if err := types.ValidateWalletVolumes(params.WalletVolumes); err != nil {
+		return nil, err
+	}
+
+	res, err := k.SimulateDistribution(ctx, params.WalletVolumes, params.Epoch)
+	if err != nil {
+		return nil, err
+	}
+	bz, err := codec.MarshalJSONIndent(k.cdc, res)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
@@ -0,0 +1,125 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stratosnet/stratos-chain/x/pot/types"
+)
+
+func (k Keeper) SetRewardPreference(ctx sdk.Context, preference types.RewardPreference) {
+	store := ctx.KVStore(k.storeKey)
+	b := k.cdc.MustMarshalBinaryLengthPrefixed(preference)
+	store.Set(types.GetRewardPreferenceKey(preference.WalletAddress), b)
+}
+
+func (k Keeper) GetRewardPreference(ctx sdk.Context, walletAddress sdk.AccAddress) (preference types.RewardPreference, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	b := store.Get(types.GetRewardPreferenceKey(walletAddress))
+	if b == nil {
+		return preference, false
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(b, &preference)
+	return preference, true
+}
+
+func (k Keeper) DeleteRewardPreference(ctx sdk.Context, walletAddress sdk.AccAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetRewardPreferenceKey(walletAddress))
+}
+
+// GetAllRewardPreferences returns the reward preferences of all the wallets, used during genesis dump
+func (k Keeper) GetAllRewardPreferences(ctx sdk.Context) (preferences []types.RewardPreference) {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, types.RewardPreferenceKeyPrefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var preference types.RewardPreference
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &preference)
+		preferences = append(preferences, preference)
+	}
+	return preferences
+}
+
+// UpdateRewardPreference sets the action taken on the mature rewards of the wallet, an empty action removes the preference.
+// Restaking requires the resource node to be owned by the wallet.
+func (k Keeper) UpdateRewardPreference(ctx sdk.Context, preference types.RewardPreference) error {
+	if preference.Action == "" {
+		k.DeleteRewardPreference(ctx, preference.WalletAddress)
+		return nil
+	}
+	if preference.Action == types.RewardActionRestake {
+		resourceNode, found := k.RegisterKeeper.GetResourceNode(ctx, preference.NetworkAddress)
+		if !found {
+			return types.ErrInvalidAddress
+		}
+		if !resourceNode.OwnerAddress.Equals(preference.WalletAddress) {
+			return types.ErrNotTheOwner
+		}
+	}
+	k.SetRewardPreference(ctx, preference)
+	return nil
+}
+
+// applyRewardPreference withdraws or restakes the whole mature total of the wallet according to its preference.
+// A failed action leaves the rewards mature, they can still be withdrawn manually.
+func (k Keeper) applyRewardPreference(ctx sdk.Context, walletAddress sdk.AccAddress) {
+	preference, found := k.GetRewardPreference(ctx, walletAddress)
+	if !found {
+		return
+	}
+	matureTotal := k.GetMatureTotalReward(ctx, walletAddress)
+	if matureTotal.IsZero() {
+		return
+	}
+
+	cacheCtx, write := ctx.CacheContext()
+	var err error
+	switch preference.Action {
+	case types.RewardActionWithdraw:
+		err = k.Withdraw(cacheCtx, matureTotal, walletAddress, preference.TargetAddress)
+	case types.RewardActionRestake:
+		err = k.restake(cacheCtx, matureTotal, preference)
+	}
+	if err != nil {
+		k.Logger(ctx).Error("failed to apply reward preference", "wallet", walletAddress.String(),
+			"action", preference.Action, "err", err.Error())
+		return
+	}
+	write()
+
+	eventType := types.EventTypeAutoWithdraw
+	targetAddress := preference.TargetAddress.String()
+	if preference.Action == types.RewardActionRestake {
+		eventType = types.EventTypeAutoRestake
+		targetAddress = preference.NetworkAddress.String()
+	}
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			eventType,
+			sdk.NewAttribute(types.AttributeKeyWalletAddress, walletAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyTargetAddress, targetAddress),
+			sdk.NewAttribute(types.AttributeKeyAmount, matureTotal.String()),
+		),
+	)
+}
+
+// restake withdraws the mature rewards to the wallet, then stakes the bond denom part to the resource node
+func (k Keeper) restake(ctx sdk.Context, matureTotal sdk.Coins, preference types.RewardPreference) error {
+	resourceNode, found := k.RegisterKeeper.GetResourceNode(ctx, preference.NetworkAddress)
+	if !found {
+		return types.ErrInvalidAddress
+	}
+	if !resourceNode.OwnerAddress.Equals(preference.WalletAddress) {
+		return types.ErrNotTheOwner
+	}
+
+	err := k.Withdraw(ctx, matureTotal, preference.WalletAddress, preference.WalletAddress)
+	if err != nil {
+		return err
+	}
+	stakeAmount := matureTotal.AmountOf(k.BondDenom(ctx))
+	if !stakeAmount.IsPositive() {
+		return nil
+	}
+	_, err = k.RegisterKeeper.AddResourceNodeStake(ctx, resourceNode, sdk.NewCoin(k.BondDenom(ctx), stakeAmount))
+	return err
+}
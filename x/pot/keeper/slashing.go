@@ -1,6 +1,8 @@
 package keeper
 
 import (
+	"time"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	stratos "github.com/stratosnet/stratos-chain/types"
 	"github.com/stratosnet/stratos-chain/x/pot/types"
@@ -8,22 +10,43 @@ import (
 )
 
 /*
-	This function only record slashing amount.
+	This function records slashing amount, and slashes the stake when StakeSlashFraction is positive.
 
 	Deduct slashing amount when:
 	1, calculate upcoming mature reward, deduct from mature_total & upcoming mature reward.
 	2, unstaking indexing node.
 	3, unstaking resource node.
+
+	The stake slashing burns the fraction of the node tokens and unbonding entries,
+	or sends it to the foundation account when RedistributeSlashedStake is set.
 */
 func (k Keeper) SlashingResourceNode(ctx sdk.Context, p2pAddr stratos.SdsAddress, walletAddr sdk.AccAddress,
-	ozAmt sdk.Int, suspend bool) (amt sdk.Int, nodeType regtypes.NodeType, err error) {
+	ozAmt sdk.Int, suspend bool) (amt sdk.Int, stakeSlashed sdk.Int, nodeType regtypes.NodeType, err error) {
 
 	node, ok := k.RegisterKeeper.GetResourceNode(ctx, p2pAddr)
 	if !ok {
-		return sdk.ZeroInt(), regtypes.NodeType(0), regtypes.ErrNoResourceNodeFound
+		return sdk.ZeroInt(), sdk.ZeroInt(), regtypes.NodeType(0), regtypes.ErrNoResourceNodeFound
+	}
+
+	stakeSlashed, err = k.slashResourceNodeStake(ctx, node)
+	if err != nil {
+		return sdk.ZeroInt(), sdk.ZeroInt(), regtypes.NodeType(0), err
 	}
+	node, _ = k.RegisterKeeper.GetResourceNode(ctx, p2pAddr)
 
+	// each suspension restarts the min suspend period before the node can be unsuspended
+	wasSuspended := node.Suspend
+	if suspend {
+		k.RegisterKeeper.SetNodeSuspendedTime(ctx, p2pAddr, ctx.BlockHeader().Time)
+	}
 	node.Suspend = suspend
+	k.RegisterKeeper.DeleteNodeSuspendedUntil(ctx, p2pAddr)
+	if suspend && k.SuspendDuration(ctx) > 0 {
+		// the owner can not unsuspend the node before the end of the suspend duration
+		suspendedUntil := ctx.BlockHeader().Time.Add(k.SuspendDuration(ctx))
+		k.RegisterKeeper.SetNodeSuspendedUntil(ctx, p2pAddr, suspendedUntil)
+		k.InsertSuspendedNodeQueue(ctx, p2pAddr, suspendedUntil)
+	}
 
 	//slashing amt is equivalent to reward traffic calculation
 	_, slash := k.GetTrafficReward(ctx, []types.SingleWalletVolume{{
@@ -33,11 +56,79 @@ func (k Keeper) SlashingResourceNode(ctx sdk.Context, p2pAddr stratos.SdsAddress
 
 	oldSlashing := k.RegisterKeeper.GetSlashing(ctx, walletAddr)
 
-	// only slashing the reward token for now.
 	newSlashing := oldSlashing.Add(slash.TruncateInt())
 
 	k.RegisterKeeper.SetResourceNode(ctx, node)
 	k.RegisterKeeper.SetSlashing(ctx, walletAddr, newSlashing)
+	if wasSuspended && !suspend {
+		if err = k.RegisterKeeper.ClearNodeSuspension(ctx, p2pAddr, false); err != nil {
+			return sdk.ZeroInt(), sdk.ZeroInt(), regtypes.NodeType(0), err
+		}
+	}
+
+	return slash.TruncateInt(), stakeSlashed, node.NodeType, nil
+}
+
+func (k Keeper) slashResourceNodeStake(ctx sdk.Context, node regtypes.ResourceNode) (sdk.Int, error) {
+	fraction := k.StakeSlashFraction(ctx)
+	if !fraction.IsPositive() {
+		return sdk.ZeroInt(), nil
+	}
+	slashed, err := k.RegisterKeeper.SlashResourceNodeStake(ctx, node, fraction)
+	if err != nil || !slashed.IsPositive() || !k.RedistributeSlashedStake(ctx) {
+		return slashed, err
+	}
 
-	return slash.TruncateInt(), node.NodeType, nil
+	foundationAccountAddr := k.SupplyKeeper.GetModuleAddress(types.FoundationAccount)
+	_, err = k.BankKeeper.AddCoins(ctx, foundationAccountAddr, sdk.NewCoins(sdk.NewCoin(k.BondDenom(ctx), slashed)))
+	if err != nil {
+		return sdk.ZeroInt(), err
+	}
+	return slashed, nil
+}
+
+// InsertSuspendedNodeQueue schedules the unsuspension of the node at the suspension expiry recorded by the register module
+func (k Keeper) InsertSuspendedNodeQueue(ctx sdk.Context, p2pAddr stratos.SdsAddress, suspendedUntil time.Time) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetSuspendedNodeQueueKey(suspendedUntil, p2pAddr), p2pAddr.Bytes())
+}
+
+// UnsuspendMatureNodes is called in EndBlocker, it unsuspends the nodes whose suspend duration is over.
+// The queue entries of the suspensions which have been cleared or renewed since are skipped.
+func (k Keeper) UnsuspendMatureNodes(ctx sdk.Context) {
+	store := ctx.KVStore(k.storeKey)
+	iter := store.Iterator(types.SuspendedNodeQueueKeyPrefix,
+		sdk.PrefixEndBytes(types.GetSuspendedNodeQueueTimeKey(ctx.BlockHeader().Time)))
+
+	var keys [][]byte
+	var p2pAddrs []stratos.SdsAddress
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, iter.Key())
+		p2pAddrs = append(p2pAddrs, stratos.SdsAddress(iter.Value()))
+	}
+	iter.Close()
+	for _, key := range keys {
+		store.Delete(key)
+	}
+
+	for _, p2pAddr := range p2pAddrs {
+		suspendedUntil, found := k.RegisterKeeper.GetNodeSuspendedUntil(ctx, p2pAddr)
+		if !found || suspendedUntil.After(ctx.BlockHeader().Time) {
+			continue
+		}
+		node, found := k.RegisterKeeper.GetResourceNode(ctx, p2pAddr)
+		if !found || !node.Suspend {
+			continue
+		}
+		if err := k.RegisterKeeper.ClearNodeSuspension(ctx, p2pAddr, false); err != nil {
+			continue
+		}
+
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				types.EventTypeUnsuspend,
+				sdk.NewAttribute(types.AttributeKeyNodeP2PAddress, p2pAddr.String()),
+			),
+		)
+	}
 }
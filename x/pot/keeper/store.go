@@ -1,6 +1,8 @@
 package keeper
 
 import (
+	"bytes"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/stratosnet/stratos-chain/x/pot/types"
 )
@@ -21,7 +23,7 @@ func (k Keeper) GetTotalMinedTokens(ctx sdk.Context) (totalMinedToken sdk.Coin)
 	return
 }
 
-func (k Keeper) setMinedTokens(ctx sdk.Context, epoch sdk.Int, minedToken sdk.Coin) {
+func (k Keeper) SetMinedTokens(ctx sdk.Context, epoch sdk.Int, minedToken sdk.Coin) {
 	store := ctx.KVStore(k.storeKey)
 	b := k.cdc.MustMarshalBinaryLengthPrefixed(minedToken)
 	store.Set(types.GetMinedTokensKey(epoch), b)
@@ -37,6 +39,24 @@ func (k Keeper) GetMinedTokens(ctx sdk.Context, epoch sdk.Int) (minedToken sdk.C
 	return
 }
 
+// IteratorMinedTokens iterates the mining rewards distributed for each epoch
+func (k Keeper) IteratorMinedTokens(ctx sdk.Context, handler func(epoch sdk.Int, minedToken sdk.Coin) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, types.MinedTokensKeyPrefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		epoch, ok := sdk.NewIntFromString(string(iter.Key()[len(types.MinedTokensKeyPrefix):]))
+		if !ok {
+			continue
+		}
+		var minedToken sdk.Coin
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &minedToken)
+		if handler(epoch, minedToken) {
+			break
+		}
+	}
+}
+
 func (k Keeper) SetLastReportedEpoch(ctx sdk.Context, epoch sdk.Int) {
 	store := ctx.KVStore(k.storeKey)
 	b := k.cdc.MustMarshalBinaryLengthPrefixed(epoch)
@@ -53,6 +73,23 @@ func (k Keeper) GetLastReportedEpoch(ctx sdk.Context) (epoch sdk.Int) {
 	return
 }
 
+func (k Keeper) SetLastMaturedEpoch(ctx sdk.Context, epoch sdk.Int) {
+	store := ctx.KVStore(k.storeKey)
+	b := k.cdc.MustMarshalBinaryLengthPrefixed(epoch)
+	store.Set(types.LastMaturedEpochKey, b)
+}
+
+func (k Keeper) GetLastMaturedEpoch(ctx sdk.Context) (epoch sdk.Int) {
+	store := ctx.KVStore(k.storeKey)
+	b := store.Get(types.LastMaturedEpochKey)
+	if b == nil {
+		// chains started before the matured epoch was tracked matured the rewards up to the last reported epoch
+		return k.GetLastReportedEpoch(ctx)
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(b, &epoch)
+	return
+}
+
 func (k Keeper) SetIndividualReward(ctx sdk.Context, walletAddress sdk.AccAddress, epoch sdk.Int, value types.Reward) {
 	store := ctx.KVStore(k.storeKey)
 	b := k.cdc.MustMarshalBinaryLengthPrefixed(value)
@@ -69,6 +106,26 @@ func (k Keeper) GetIndividualReward(ctx sdk.Context, walletAddress sdk.AccAddres
 	return value, true
 }
 
+// IteratorAllIndividualRewards iterates the individual rewards of all the epochs
+func (k Keeper) IteratorAllIndividualRewards(ctx sdk.Context, handler func(epoch sdk.Int, individualReward types.Reward) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, types.IndividualRewardKeyPrefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		// key: prefix{epoch}_{address}
+		key := iter.Key()[len(types.IndividualRewardKeyPrefix):]
+		epoch, ok := sdk.NewIntFromString(string(key[:bytes.IndexByte(key, '_')]))
+		if !ok {
+			continue
+		}
+		var individualReward types.Reward
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &individualReward)
+		if handler(epoch, individualReward) {
+			break
+		}
+	}
+}
+
 func (k Keeper) SetMatureTotalReward(ctx sdk.Context, walletAddress sdk.AccAddress, value sdk.Coins) {
 	store := ctx.KVStore(k.storeKey)
 	b := k.cdc.MustMarshalBinaryLengthPrefixed(value)
@@ -117,3 +174,65 @@ func (k Keeper) SetVolumeReport(ctx sdk.Context, epoch sdk.Int, reportRecord typ
 	bz := k.cdc.MustMarshalBinaryLengthPrefixed(reportRecord)
 	store.Set(storeKey, bz)
 }
+
+// IteratorVolumeReport iterates the volume report records of all the reported epochs
+func (k Keeper) IteratorVolumeReport(ctx sdk.Context, handler func(epoch sdk.Int, reportRecord types.VolumeReportRecord) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, types.VolumeReportStoreKeyPrefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		epoch, ok := sdk.NewIntFromString(string(iter.Key()[len(types.VolumeReportStoreKeyPrefix):]))
+		if !ok {
+			continue
+		}
+		var reportRecord types.VolumeReportRecord
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &reportRecord)
+		if handler(epoch, reportRecord) {
+			break
+		}
+	}
+}
+
+func (k Keeper) SetWalletVolumeRecord(ctx sdk.Context, record types.WalletVolumeRecord) {
+	store := ctx.KVStore(k.storeKey)
+	b := k.cdc.MustMarshalBinaryLengthPrefixed(record)
+	store.Set(types.GetWalletVolumeKey(record.WalletAddress, record.Epoch), b)
+}
+
+func (k Keeper) GetWalletVolumeRecord(ctx sdk.Context, walletAddress sdk.AccAddress, epoch sdk.Int) (record types.WalletVolumeRecord, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	b := store.Get(types.GetWalletVolumeKey(walletAddress, epoch))
+	if b == nil {
+		return record, false
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(b, &record)
+	return record, true
+}
+
+// IteratorWalletVolumeRecord iterates the volume records of a wallet in epoch order
+func (k Keeper) IteratorWalletVolumeRecord(ctx sdk.Context, walletAddress sdk.AccAddress, handler func(record types.WalletVolumeRecord) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, types.GetWalletVolumeIteratorKey(walletAddress))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var record types.WalletVolumeRecord
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &record)
+		if handler(record) {
+			break
+		}
+	}
+}
+
+// IteratorAllWalletVolumeRecords iterates the volume records of all the wallets
+func (k Keeper) IteratorAllWalletVolumeRecords(ctx sdk.Context, handler func(record types.WalletVolumeRecord) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, types.WalletVolumeKeyPrefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var record types.WalletVolumeRecord
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &record)
+		if handler(record) {
+			break
+		}
+	}
+}
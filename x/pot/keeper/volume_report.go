@@ -0,0 +1,225 @@
+package keeper
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	stratos "github.com/stratosnet/stratos-chain/types"
+	"github.com/stratosnet/stratos-chain/x/pot/types"
+)
+
+func (k Keeper) SetVolumeReportSubmission(ctx sdk.Context, submission types.VolumeReportSubmission) {
+	store := ctx.KVStore(k.storeKey)
+	b := k.cdc.MustMarshalBinaryLengthPrefixed(submission)
+	store.Set(types.GetVolumeReportSubmissionKey(submission.Epoch, submission.Reporter), b)
+}
+
+func (k Keeper) HasVolumeReportSubmission(ctx sdk.Context, epoch sdk.Int, reporter stratos.SdsAddress) bool {
+	store := ctx.KVStore(k.storeKey)
+	return store.Has(types.GetVolumeReportSubmissionKey(epoch, reporter))
+}
+
+func (k Keeper) DeleteVolumeReportSubmission(ctx sdk.Context, epoch sdk.Int, reporter stratos.SdsAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetVolumeReportSubmissionKey(epoch, reporter))
+}
+
+// GetAllVolumeReportSubmissions returns the submitted reports waiting for consensus, ordered by epoch
+func (k Keeper) GetAllVolumeReportSubmissions(ctx sdk.Context) (submissions []types.VolumeReportSubmission) {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, types.VolumeReportSubmissionKeyPrefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var submission types.VolumeReportSubmission
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &submission)
+		submissions = append(submissions, submission)
+	}
+	return submissions
+}
+
+func (k Keeper) GetReporterMismatchCount(ctx sdk.Context, reporter stratos.SdsAddress) (count int64) {
+	store := ctx.KVStore(k.storeKey)
+	b := store.Get(types.GetReporterMismatchCountKey(reporter))
+	if b == nil {
+		return 0
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(b, &count)
+	return
+}
+
+func (k Keeper) SetReporterMismatchCount(ctx sdk.Context, reporter stratos.SdsAddress, count int64) {
+	store := ctx.KVStore(k.storeKey)
+	b := k.cdc.MustMarshalBinaryLengthPrefixed(count)
+	store.Set(types.GetReporterMismatchCountKey(reporter), b)
+}
+
+// GetAllReporterMismatchCounts returns the mismatch counts of all the reporters, used during genesis dump
+func (k Keeper) GetAllReporterMismatchCounts(ctx sdk.Context) (counts []types.ReporterMismatchCount) {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, types.ReporterMismatchCountKeyPrefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		reporter := stratos.SdsAddress(iter.Key()[len(types.ReporterMismatchCountKeyPrefix):])
+		var count int64
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &count)
+		counts = append(counts, types.NewReporterMismatchCount(reporter, count))
+	}
+	return counts
+}
+
+// SubmitVolumeReport records the report of a meta node. The report is finalized by FinalizeVolumeReports
+// once enough meta nodes submitted the same report of the epoch.
+func (k Keeper) SubmitVolumeReport(ctx sdk.Context, msg types.MsgVolumeReport, txHash string) error {
+	if k.HasVolumeReportSubmission(ctx, msg.Epoch, msg.Reporter) {
+		return types.ErrDuplicateVolumeReport
+	}
+	submission := types.NewVolumeReportSubmission(msg.Epoch, msg.Reporter, msg.BLSSignature.TxData, msg.WalletVolumes,
+		msg.ConsumerVolumes, msg.ReportReference, txHash)
+	k.SetVolumeReportSubmission(ctx, submission)
+	return nil
+}
+
+// VolumeReportQuorum returns the number of meta nodes that must submit the same report of an epoch
+func (k Keeper) VolumeReportQuorum(ctx sdk.Context) int64 {
+	validMetaNodeCount := int64(len(k.RegisterKeeper.GetAllValidIndexingNodes(ctx)))
+	quorum := k.VolumeReportThreshold(ctx).MulInt64(validMetaNodeCount).Ceil().TruncateInt64()
+	if quorum < 1 {
+		return 1
+	}
+	return quorum
+}
+
+/*
+	FinalizeVolumeReports is called in EndBlocker.
+
+	1, the submissions of epochs which are already reported are dropped.
+	2, an epoch is finalized once the quorum of meta nodes submitted the same report,
+	   the report is then recorded and the pot rewards are distributed.
+	3, the reporters disagreeing with the finalized report are flagged.
+*/
+func (k Keeper) FinalizeVolumeReports(ctx sdk.Context) {
+	submissions := k.GetAllVolumeReportSubmissions(ctx)
+	quorum := k.VolumeReportQuorum(ctx)
+
+	for start := 0; start < len(submissions); {
+		end := start + 1
+		for end < len(submissions) && submissions[end].Epoch.Equal(submissions[start].Epoch) {
+			end++
+		}
+		k.finalizeEpochVolumeReport(ctx, submissions[start:end], quorum)
+		start = end
+	}
+}
+
+func (k Keeper) finalizeEpochVolumeReport(ctx sdk.Context, submissions []types.VolumeReportSubmission, quorum int64) {
+	epoch := submissions[0].Epoch
+	if epoch.LTE(k.GetLastReportedEpoch(ctx)) {
+		k.deleteVolumeReportSubmissions(ctx, submissions)
+		return
+	}
+
+	// find the report submitted by most reporters, the submissions are ordered by reporter address
+	// so that ties are broken by the report reaching the highest count first in that order
+	agreedCounts := make(map[string]int64)
+	var agreed types.VolumeReportSubmission
+	var agreedCount int64
+	for _, submission := range submissions {
+		reportHash := string(submission.ReportHash)
+		agreedCounts[reportHash]++
+		if agreedCounts[reportHash] > agreedCount {
+			agreed = submission
+			agreedCount = agreedCounts[reportHash]
+		}
+	}
+	if agreedCount < quorum {
+		return
+	}
+
+	// distribute with a cached context so that a failed distribution leaves no partial state,
+	// the submissions are then kept and the epoch is retried in the next block
+	cacheCtx, write := ctx.CacheContext()
+	totalConsumedOzone, err := k.distributeAgreedVolumeReport(cacheCtx, agreed)
+	if err != nil {
+		k.Logger(ctx).Error("failed to finalize volume report", "epoch", epoch.String(), "err", err.Error())
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				types.EventTypeVolumeReportFailed,
+				sdk.NewAttribute(types.AttributeKeyEpoch, epoch.String()),
+				sdk.NewAttribute(types.AttributeKeyReportHash, hex.EncodeToString(agreed.ReportHash)),
+				sdk.NewAttribute(types.AttributeKeyError, err.Error()),
+			),
+		)
+		return
+	}
+	write()
+	k.deleteVolumeReportSubmissions(ctx, submissions)
+	ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeVolumeReport,
+			sdk.NewAttribute(types.AttributeKeyTotalConsumedOzone, totalConsumedOzone.String()),
+			sdk.NewAttribute(types.AttributeKeyReportReference, hex.EncodeToString([]byte(agreed.ReportReference))),
+			sdk.NewAttribute(types.AttributeKeyEpoch, epoch.String()),
+			sdk.NewAttribute(types.AttributeKeyAgreedReporters, strconv.FormatInt(agreedCount, 10)),
+		),
+	)
+
+	for _, submission := range submissions {
+		if bytes.Equal(submission.ReportHash, agreed.ReportHash) {
+			continue
+		}
+		k.SetReporterMismatchCount(ctx, submission.Reporter, k.GetReporterMismatchCount(ctx, submission.Reporter)+1)
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				types.EventTypeVolumeReportMismatch,
+				sdk.NewAttribute(types.AttributeKeyReporter, submission.Reporter.String()),
+				sdk.NewAttribute(types.AttributeKeyEpoch, epoch.String()),
+				sdk.NewAttribute(types.AttributeKeyReportHash, hex.EncodeToString(submission.ReportHash)),
+			),
+		)
+	}
+}
+
+// distributeAgreedVolumeReport records the agreed report and distributes its pot rewards. As it runs in EndBlocker,
+// a panic of the distribution is turned into an error instead of halting the chain.
+func (k Keeper) distributeAgreedVolumeReport(ctx sdk.Context, agreed types.VolumeReportSubmission) (totalConsumedOzone sdk.Dec, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = sdkerrors.Wrapf(types.ErrDistributionFailed, "%v", r)
+		}
+	}()
+
+	totalConsumedOzone, err = k.VolumeReport(ctx, agreed.WalletVolumes, agreed.Reporter, agreed.Epoch, agreed.ReportReference, agreed.TxHash)
+	if err != nil {
+		return totalConsumedOzone, err
+	}
+	k.ConsumeOzone(ctx, agreed.ConsumerVolumes, agreed.Epoch)
+	return totalConsumedOzone, nil
+}
+
+// ConsumeOzone debits the uoz consumed by each consumer wallet from its ozone balance.
+// The balance may have been consumed by an earlier epoch since the report was submitted, the debit is then capped at the balance.
+func (k Keeper) ConsumeOzone(ctx sdk.Context, consumerVolumes []types.SingleWalletVolume, epoch sdk.Int) {
+	for _, consumer := range consumerVolumes {
+		debited := k.RegisterKeeper.DebitOzoneBalance(ctx, consumer.WalletAddress, consumer.Volume)
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				types.EventTypeConsumeOzone,
+				sdk.NewAttribute(types.AttributeKeyWalletAddress, consumer.WalletAddress.String()),
+				sdk.NewAttribute(types.AttributeKeyEpoch, epoch.String()),
+				sdk.NewAttribute(types.AttributeKeyConsumedOzone, consumer.Volume.String()),
+				sdk.NewAttribute(types.AttributeKeyDebitedOzone, debited.String()),
+			),
+		)
+	}
+}
+
+func (k Keeper) deleteVolumeReportSubmissions(ctx sdk.Context, submissions []types.VolumeReportSubmission) {
+	for _, submission := range submissions {
+		k.DeleteVolumeReportSubmission(ctx, submission.Epoch, submission.Reporter)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	authexported "github.com/cosmos/cosmos-sdk/x/auth/exported"
 	"github.com/cosmos/cosmos-sdk/x/mock"
+	"github.com/stratosnet/stratos-chain/crypto/bls"
 	stratos "github.com/stratosnet/stratos-chain/types"
 	"github.com/stratosnet/stratos-chain/x/register"
 	"github.com/stretchr/testify/require"
@@ -108,6 +109,8 @@ var (
 	idxNodeNetworkId3    = stratos.SdsAddress(idxNodePubKey3.Address())
 	idxNodeInitialStake3 = sdk.NewInt(5 * stos2ustos)
 
+	idxNodeBLSPrivKeys = genBLSPrivKeys(3)
+
 	valOpPrivKey1 = secp256k1.GenPrivKey()
 	valOpPubKey1  = valOpPrivKey1.PubKey()
 	valOpValAddr1 = sdk.ValAddress(valOpPubKey1.Address())
@@ -224,6 +227,25 @@ func setupAllResourceNodes() []register.ResourceNode {
 	return resourceNodes
 }
 
+func genBLSPrivKeys(n int) (privKeys [][]byte) {
+	for i := 0; i < n; i++ {
+		privKey, _, err := bls.NewKeyPair()
+		if err != nil {
+			panic(err)
+		}
+		privKeys = append(privKeys, privKey)
+	}
+	return privKeys
+}
+
+func blsPubKeyFromPrivKey(privKey []byte) []byte {
+	pubKey, err := bls.PubKeyFromPrivKey(privKey)
+	if err != nil {
+		panic(err)
+	}
+	return pubKey
+}
+
 func setupAllIndexingNodes() []register.IndexingNode {
 	var indexingNodes []register.IndexingNode
 
@@ -240,6 +262,14 @@ func setupAllIndexingNodes() []register.IndexingNode {
 	indexingNode2.Status = sdk.Bonded
 	indexingNode3.Status = sdk.Bonded
 
+	indexingNode1.Suspend = false
+	indexingNode2.Suspend = false
+	indexingNode3.Suspend = false
+
+	indexingNode1.BLSPubKey = blsPubKeyFromPrivKey(idxNodeBLSPrivKeys[0])
+	indexingNode2.BLSPubKey = blsPubKeyFromPrivKey(idxNodeBLSPrivKeys[1])
+	indexingNode3.BLSPubKey = blsPubKeyFromPrivKey(idxNodeBLSPrivKeys[2])
+
 	indexingNodes = append(indexingNodes, indexingNode1)
 	indexingNodes = append(indexingNodes, indexingNode2)
 	indexingNodes = append(indexingNodes, indexingNode3)
@@ -11,6 +11,7 @@ func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgWithdraw{}, "pot/WithdrawTx", nil)
 	cdc.RegisterConcrete(MsgFoundationDeposit{}, "pot/FoundationDepositTx", nil)
 	cdc.RegisterConcrete(MsgSlashingResourceNode{}, "pot/SlashingResourceNodeTx", nil)
+	cdc.RegisterConcrete(MsgSetRewardPreference{}, "pot/SetRewardPreferenceTx", nil)
 }
 
 // ModuleCdc defines the module codec
@@ -207,3 +207,23 @@ func (r Reward) String() string {
   		RewardFromTrafficPool:	%s
 	}`, r.WalletAddress, r.RewardFromMiningPool.String(), r.RewardFromTrafficPool.String())
 }
+
+// DistributionSimulation is the outcome of a pot reward distribution computed without changing the state
+type DistributionSimulation struct {
+	Epoch              sdk.Int        `json:"epoch" yaml:"epoch"`
+	TotalConsumedOzone sdk.Dec        `json:"total_consumed_ozone" yaml:"total_consumed_ozone"`
+	DistributeGoal     DistributeGoal `json:"distribute_goal" yaml:"distribute_goal"`
+	Rewards            []Reward       `json:"rewards" yaml:"rewards"`
+	ReturnedBalance    DistributeGoal `json:"returned_balance" yaml:"returned_balance"` // balance returned to traffic pool & mining pool
+}
+
+func NewDistributionSimulation(epoch sdk.Int, totalConsumedOzone sdk.Dec, distributeGoal DistributeGoal, rewards []Reward,
+	returnedBalance DistributeGoal) DistributionSimulation {
+	return DistributionSimulation{
+		Epoch:              epoch,
+		TotalConsumedOzone: totalConsumedOzone,
+		DistributeGoal:     distributeGoal,
+		Rewards:            rewards,
+		ReturnedBalance:    returnedBalance,
+	}
+}
@@ -33,4 +33,16 @@ var (
 	ErrCannotFindReport                  = sdkerrors.Register(ModuleName, 26, "Can not find report")
 	ErrCannotFindReward                  = sdkerrors.Register(ModuleName, 27, "Can not find Pot rewards")
 	ErrInvalidAddress                    = sdkerrors.Register(ModuleName, 28, "invalid address")
+	ErrBLSVerifyFailed                   = sdkerrors.Register(ModuleName, 29, "BLS signature verification failed")
+	ErrBLSNotReachThreshold              = sdkerrors.Register(ModuleName, 30, "BLS signers do not reach the meta node quorum")
+	ErrDuplicateVolumeReport             = sdkerrors.Register(ModuleName, 31, "volume report of the epoch already submitted by the reporter")
+	ErrInvalidRewardAction               = sdkerrors.Register(ModuleName, 32, "reward action must be either withdraw or restake")
+	ErrInvalidDenom                      = sdkerrors.Register(ModuleName, 33, "invalid coin denomination")
+	ErrInvalidGenesisEpoch               = sdkerrors.Register(ModuleName, 34, "invalid epoch in genesis")
+	ErrInvalidGenesisAmount              = sdkerrors.Register(ModuleName, 35, "invalid amount in genesis")
+	ErrDuplicateConsumer                 = sdkerrors.Register(ModuleName, 36, "consumer wallet reported more than once")
+	ErrInsufficientOzoneBalance          = sdkerrors.Register(ModuleName, 37, "consumed uoz exceeds the ozone balance of the wallet")
+	ErrZeroTotalVolume                   = sdkerrors.Register(ModuleName, 38, "total volume of the report is zero")
+	ErrDistributionFailed                = sdkerrors.Register(ModuleName, 39, "failed to distribute the pot rewards of the volume report")
+	ErrFoundationBalanceMismatch         = sdkerrors.Register(ModuleName, 40, "foundation account balance does not match the genesis")
 )
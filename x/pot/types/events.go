@@ -2,10 +2,19 @@ package types
 
 // pot module event types
 const (
-	EventTypeVolumeReport      = "volume_report"
-	EventTypeWithdraw          = "withdraw"
-	EventTypeFoundationDeposit = "foundation_deposit"
-	EventTypeSlashing          = "slashing"
+	EventTypeVolumeReport         = "volume_report"
+	EventTypeSubmitVolumeReport   = "submit_volume_report"
+	EventTypeVolumeReportMismatch = "volume_report_mismatch"
+	EventTypeVolumeReportFailed   = "volume_report_failed"
+	EventTypeConsumeOzone         = "consume_ozone"
+	EventTypeRewardMature         = "reward_mature"
+	EventTypeWithdraw             = "withdraw"
+	EventTypeSetRewardPreference  = "set_reward_preference"
+	EventTypeAutoWithdraw         = "auto_withdraw"
+	EventTypeAutoRestake          = "auto_restake"
+	EventTypeFoundationDeposit    = "foundation_deposit"
+	EventTypeSlashing             = "slashing"
+	EventTypeUnsuspend            = "unsuspend"
 
 	AttributeKeyEpoch              = "epoch"
 	AttributeKeyReportReference    = "report_reference"
@@ -15,6 +24,15 @@ const (
 	AttributeKeyNodeP2PAddress     = "p2p_address"
 	AttributeKeySlashingNodeType   = "slashing_type"
 	AttributeKeyNodeSuspended      = "suspend"
+	AttributeKeyStakeSlashed       = "stake_slashed"
+	AttributeKeyReporter           = "reporter"
+	AttributeKeyReportHash         = "report_hash"
+	AttributeKeyAgreedReporters    = "agreed_reporters"
+	AttributeKeyRewardAction       = "reward_action"
+	AttributeKeyTargetAddress      = "target_address"
+	AttributeKeyConsumedOzone      = "consumed_ozone"
+	AttributeKeyDebitedOzone       = "debited_ozone"
+	AttributeKeyError              = "error"
 
 	AttributeValueCategory = ModuleName
 )
@@ -2,6 +2,8 @@ package types
 
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	stratos "github.com/stratosnet/stratos-chain/types"
 )
 
 type GenesisState struct {
@@ -10,7 +12,17 @@ type GenesisState struct {
 	LastReportedEpoch    int64           `json:"last_reported_epoch" yaml:"last_reported_epoch"`
 	ImmatureTotalInfo    []ImmatureTotal `json:"immature_total_info" yaml:"immature_total_info"`
 	MatureTotalInfo      []MatureTotal   `json:"mature_total_info" yaml:"mature_total_info"`
-	IndividualRewardInfo []Reward        `json:"individual_reward_info" yaml:"individual_reward_info"`
+	IndividualRewardInfo []Reward        `json:"individual_reward_info" yaml:"individual_reward_info"` // rewards maturing right after the last reported epoch
+
+	LastMaturedEpoch         int64                    `json:"last_matured_epoch" yaml:"last_matured_epoch"`
+	IndividualRewards        []IndividualReward       `json:"individual_rewards" yaml:"individual_rewards"`
+	MinedTokens              []MinedToken             `json:"mined_tokens" yaml:"mined_tokens"`
+	VolumeReports            []EpochVolumeReport      `json:"volume_reports" yaml:"volume_reports"`
+	WalletVolumeRecords      []WalletVolumeRecord     `json:"wallet_volume_records" yaml:"wallet_volume_records"`
+	VolumeReportSubmissions  []VolumeReportSubmission `json:"volume_report_submissions" yaml:"volume_report_submissions"`
+	ReporterMismatchCounts   []ReporterMismatchCount  `json:"reporter_mismatch_counts" yaml:"reporter_mismatch_counts"`
+	RewardPreferences        []RewardPreference       `json:"reward_preferences" yaml:"reward_preferences"`
+	FoundationAccountBalance sdk.Coins                `json:"foundation_account_balance" yaml:"foundation_account_balance"` // checked against the foundation account when not empty
 }
 
 // NewGenesisState creates a new GenesisState object
@@ -42,6 +54,146 @@ func DefaultGenesisState() GenesisState {
 
 // ValidateGenesis validates the pot genesis parameters
 func ValidateGenesis(data GenesisState) error {
+	params := data.Params
+	if err := params.ValidateBasic(); err != nil {
+		return err
+	}
+
+	if err := validateCoin(data.TotalMinedToken, params.RewardDenom); err != nil {
+		return err
+	}
+	if data.LastReportedEpoch < 0 || data.LastMaturedEpoch < 0 {
+		return ErrInvalidGenesisEpoch
+	}
+	lastReportedEpoch := sdk.NewInt(data.LastReportedEpoch)
+
+	for _, immatureTotal := range data.ImmatureTotalInfo {
+		if err := validateRewardCoins(immatureTotal.WalletAddress, immatureTotal.Value, params.BondDenom, params.RewardDenom); err != nil {
+			return err
+		}
+	}
+	for _, matureTotal := range data.MatureTotalInfo {
+		if err := validateRewardCoins(matureTotal.WalletAddress, matureTotal.Value, params.BondDenom, params.RewardDenom); err != nil {
+			return err
+		}
+	}
+
+	// individual rewards are recorded at the epoch they mature, at most MatureEpoch after the last reported epoch
+	lastMatureEpoch := lastReportedEpoch.Add(sdk.NewInt(params.MatureEpoch))
+	for _, individual := range data.IndividualRewards {
+		if err := validateEpoch(individual.Epoch, lastMatureEpoch); err != nil {
+			return err
+		}
+		if err := validateReward(individual.Reward, params); err != nil {
+			return err
+		}
+	}
+	for _, individual := range data.IndividualRewardInfo {
+		if err := validateReward(individual, params); err != nil {
+			return err
+		}
+	}
+
+	for _, minedToken := range data.MinedTokens {
+		if err := validateEpoch(minedToken.Epoch, lastReportedEpoch); err != nil {
+			return err
+		}
+		if err := validateCoin(minedToken.MinedToken, params.RewardDenom); err != nil {
+			return err
+		}
+	}
+
+	for _, report := range data.VolumeReports {
+		if err := validateEpoch(report.Epoch, lastReportedEpoch); err != nil {
+			return err
+		}
+	}
+
+	for _, record := range data.WalletVolumeRecords {
+		if err := validateEpoch(record.Epoch, lastReportedEpoch); err != nil {
+			return err
+		}
+		if record.Volume.IsNil() || record.Volume.IsNegative() {
+			return ErrNegativeVolume
+		}
+	}
+
+	for _, submission := range data.VolumeReportSubmissions {
+		if submission.Epoch.IsNil() || !submission.Epoch.IsPositive() {
+			return ErrEpochNotPositive
+		}
+		if submission.Reporter.Empty() {
+			return ErrEmptyReporterAddr
+		}
+	}
+
+	for _, preference := range data.RewardPreferences {
+		if preference.WalletAddress.Empty() {
+			return ErrInvalidAddress
+		}
+		if preference.Action != RewardActionWithdraw && preference.Action != RewardActionRestake {
+			return ErrInvalidRewardAction
+		}
+	}
+
+	if !data.FoundationAccountBalance.IsValid() {
+		return ErrInvalidGenesisAmount
+	}
+	return nil
+}
+
+// ValidateFoundationAccountBalance checks the exported foundation account balance against the balance of the account in the genesis
+func ValidateFoundationAccountBalance(data GenesisState, foundationAccountBalance sdk.Coins) error {
+	if data.FoundationAccountBalance.Empty() {
+		return nil
+	}
+	if !foundationAccountBalance.IsEqual(data.FoundationAccountBalance) {
+		return sdkerrors.Wrapf(ErrFoundationBalanceMismatch, "foundation account balance %v does not match the genesis balance %v",
+			foundationAccountBalance, data.FoundationAccountBalance)
+	}
+	return nil
+}
+
+func validateReward(reward Reward, params Params) error {
+	if err := validateRewardCoins(reward.WalletAddress, reward.RewardFromMiningPool, params.RewardDenom); err != nil {
+		return err
+	}
+	return validateRewardCoins(reward.WalletAddress, reward.RewardFromTrafficPool, params.BondDenom)
+}
+
+func validateEpoch(epoch sdk.Int, maxEpoch sdk.Int) error {
+	if epoch.IsNil() || !epoch.IsPositive() || epoch.GT(maxEpoch) {
+		return sdkerrors.Wrapf(ErrInvalidGenesisEpoch, "epoch %v exceeds %v", epoch, maxEpoch)
+	}
+	return nil
+}
+
+func validateCoin(coin sdk.Coin, denom string) error {
+	if coin.Denom != denom {
+		return sdkerrors.Wrapf(ErrInvalidDenom, "expected %s, got %s", denom, coin.Denom)
+	}
+	if coin.Amount.IsNil() || coin.IsNegative() {
+		return ErrInvalidGenesisAmount
+	}
+	return nil
+}
+
+func validateRewardCoins(walletAddress sdk.AccAddress, coins sdk.Coins, denoms ...string) error {
+	if walletAddress.Empty() {
+		return ErrInvalidAddress
+	}
+	if !coins.IsValid() {
+		return ErrInvalidGenesisAmount
+	}
+	for _, coin := range coins {
+		valid := false
+		for _, denom := range denoms {
+			valid = valid || coin.Denom == denom
+		}
+		if !valid {
+			return sdkerrors.Wrapf(ErrInvalidDenom, "unexpected reward denomination %s", coin.Denom)
+		}
+	}
 	return nil
 }
 
@@ -68,3 +220,54 @@ func NewMatureTotal(walletAddress sdk.AccAddress, value sdk.Coins) MatureTotal {
 		Value:         value,
 	}
 }
+
+// IndividualReward is the reward of a wallet that matures at the epoch
+type IndividualReward struct {
+	Epoch  sdk.Int `json:"epoch" yaml:"epoch"`
+	Reward Reward  `json:"reward" yaml:"reward"`
+}
+
+func NewIndividualReward(epoch sdk.Int, reward Reward) IndividualReward {
+	return IndividualReward{
+		Epoch:  epoch,
+		Reward: reward,
+	}
+}
+
+// MinedToken is the mining reward distributed for the epoch
+type MinedToken struct {
+	Epoch      sdk.Int  `json:"epoch" yaml:"epoch"`
+	MinedToken sdk.Coin `json:"mined_token" yaml:"mined_token"`
+}
+
+func NewMinedToken(epoch sdk.Int, minedToken sdk.Coin) MinedToken {
+	return MinedToken{
+		Epoch:      epoch,
+		MinedToken: minedToken,
+	}
+}
+
+// EpochVolumeReport is the volume report record finalized for the epoch
+type EpochVolumeReport struct {
+	Epoch  sdk.Int            `json:"epoch" yaml:"epoch"`
+	Record VolumeReportRecord `json:"record" yaml:"record"`
+}
+
+func NewEpochVolumeReport(epoch sdk.Int, record VolumeReportRecord) EpochVolumeReport {
+	return EpochVolumeReport{
+		Epoch:  epoch,
+		Record: record,
+	}
+}
+
+type ReporterMismatchCount struct {
+	Reporter stratos.SdsAddress `json:"reporter" yaml:"reporter"`
+	Count    int64              `json:"count" yaml:"count"`
+}
+
+func NewReporterMismatchCount(reporter stratos.SdsAddress, count int64) ReporterMismatchCount {
+	return ReporterMismatchCount{
+		Reporter: reporter,
+		Count:    count,
+	}
+}
@@ -1,7 +1,10 @@
 package types
 
 import (
+	"time"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	stratos "github.com/stratosnet/stratos-chain/types"
 )
 
 const (
@@ -26,8 +29,15 @@ var (
 	IndividualRewardKeyPrefix    = []byte{0x13} // key: prefix{address}_{epoch}, the amount that is matured at {epoch}
 	MatureTotalRewardKeyPrefix   = []byte{0x14} // key: prefix{address}
 	ImmatureTotalRewardKeyPrefix = []byte{0x15} // key: prefix{address}
+	LastMaturedEpochKey          = []byte{0x16}
+	RewardPreferenceKeyPrefix    = []byte{0x17} // key: prefix{address}
+	SuspendedNodeQueueKeyPrefix  = []byte{0x18} // key: prefix{time}{p2p_address}
 
 	VolumeReportStoreKeyPrefix = []byte{0x41} // VolumeReportStoreKeyPrefix prefix for volumeReport store
+	WalletVolumeKeyPrefix      = []byte{0x42} // key: prefix{address}{epoch}, the volume & reward of a wallet reported at {epoch}
+
+	VolumeReportSubmissionKeyPrefix = []byte{0x43} // key: prefix{epoch}{reporter}, the reports waiting for consensus
+	ReporterMismatchCountKeyPrefix  = []byte{0x44} // key: prefix{reporter}, the number of reports disagreeing with the finalized ones
 )
 
 func GetMinedTokensKey(epoch sdk.Int) []byte {
@@ -64,8 +74,54 @@ func GetMatureTotalRewardKey(acc sdk.AccAddress) []byte {
 	return key
 }
 
+func GetRewardPreferenceKey(acc sdk.AccAddress) []byte {
+	key := append(RewardPreferenceKeyPrefix, acc.Bytes()...)
+	return key
+}
+
 // GetImmatureTotalRewardKey prefix{address}
 func GetImmatureTotalRewardKey(acc sdk.AccAddress) []byte {
 	key := append(ImmatureTotalRewardKeyPrefix, acc.Bytes()...)
 	return key
 }
+
+// GetWalletVolumeKey prefix{address}{epoch}, epoch is big endian encoded to keep the records of a wallet in epoch order
+func GetWalletVolumeKey(acc sdk.AccAddress, epoch sdk.Int) []byte {
+	key := GetWalletVolumeIteratorKey(acc)
+	key = append(key, sdk.Uint64ToBigEndian(epoch.Uint64())...)
+	return key
+}
+
+// GetWalletVolumeIteratorKey prefix{address}
+func GetWalletVolumeIteratorKey(acc sdk.AccAddress) []byte {
+	key := append(WalletVolumeKeyPrefix, acc.Bytes()...)
+	return key
+}
+
+// GetVolumeReportSubmissionKey prefix{epoch}{reporter}, epoch is big endian encoded to finalize reports in epoch order
+func GetVolumeReportSubmissionKey(epoch sdk.Int, reporter stratos.SdsAddress) []byte {
+	key := GetVolumeReportSubmissionIteratorKey(epoch)
+	key = append(key, reporter.Bytes()...)
+	return key
+}
+
+// GetVolumeReportSubmissionIteratorKey prefix{epoch}
+func GetVolumeReportSubmissionIteratorKey(epoch sdk.Int) []byte {
+	key := append(VolumeReportSubmissionKeyPrefix, sdk.Uint64ToBigEndian(epoch.Uint64())...)
+	return key
+}
+
+// GetReporterMismatchCountKey prefix{reporter}
+func GetReporterMismatchCountKey(reporter stratos.SdsAddress) []byte {
+	key := append(ReporterMismatchCountKeyPrefix, reporter.Bytes()...)
+	return key
+}
+
+// GetSuspendedNodeQueueKey orders the suspended nodes by the time they are unsuspended
+func GetSuspendedNodeQueueKey(timestamp time.Time, p2pAddr stratos.SdsAddress) []byte {
+	return append(GetSuspendedNodeQueueTimeKey(timestamp), p2pAddr.Bytes()...)
+}
+
+func GetSuspendedNodeQueueTimeKey(timestamp time.Time) []byte {
+	return append(SuspendedNodeQueueKeyPrefix, sdk.FormatTimeBytes(timestamp)...)
+}
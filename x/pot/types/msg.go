@@ -9,6 +9,7 @@ const (
 	VolumeReportMsgType      = "volume_report"
 	WithdrawMsgType          = "withdraw"
 	FoundationDepositMsgType = "foundation_deposit"
+	RewardPreferenceMsgType  = "set_reward_preference"
 )
 
 // verify interface at compile time
@@ -17,10 +18,12 @@ var (
 	_ sdk.Msg = &MsgWithdraw{}
 	_ sdk.Msg = &MsgFoundationDeposit{}
 	_ sdk.Msg = &MsgSlashingResourceNode{}
+	_ sdk.Msg = &MsgSetRewardPreference{}
 )
 
 type MsgVolumeReport struct {
 	WalletVolumes   []SingleWalletVolume `json:"wallet_volumes" yaml:"wallet_volumes"`     // volume report
+	ConsumerVolumes []SingleWalletVolume `json:"consumer_volumes" yaml:"consumer_volumes"` // uoz consumed by each consumer wallet
 	Reporter        stratos.SdsAddress   `json:"reporter" yaml:"reporter"`                 // node p2p address of the reporter
 	Epoch           sdk.Int              `json:"epoch" yaml:"epoch"`                       // volume report epoch
 	ReportReference string               `json:"report_reference" yaml:"report_reference"` // volume report reference
@@ -31,6 +34,7 @@ type MsgVolumeReport struct {
 // NewMsgVolumeReport creates a new MsgVolumeReport instance
 func NewMsgVolumeReport(
 	walletVolumes []SingleWalletVolume,
+	consumerVolumes []SingleWalletVolume,
 	reporter stratos.SdsAddress,
 	epoch sdk.Int,
 	reportReference string,
@@ -39,6 +43,7 @@ func NewMsgVolumeReport(
 ) MsgVolumeReport {
 	return MsgVolumeReport{
 		WalletVolumes:   walletVolumes,
+		ConsumerVolumes: consumerVolumes,
 		Reporter:        reporter,
 		Epoch:           epoch,
 		ReportReference: reportReference,
@@ -51,7 +56,7 @@ type QueryVolumeReportRecord struct {
 	Reporter        sdk.AccAddress
 	ReportReference string
 	TxHash          string
-	walletVolumes   []SingleWalletVolume
+	WalletVolumes   []SingleWalletVolume
 }
 
 func NewQueryVolumeReportRecord(reporter sdk.AccAddress, reportReference string, txHash string, walletVolumes []SingleWalletVolume) QueryVolumeReportRecord {
@@ -59,10 +64,23 @@ func NewQueryVolumeReportRecord(reporter sdk.AccAddress, reportReference string,
 		Reporter:        reporter,
 		ReportReference: reportReference,
 		TxHash:          txHash,
-		walletVolumes:   walletVolumes,
+		WalletVolumes:   walletVolumes,
 	}
 }
 
+// GetBLSSignBytes returns the bytes of the report content signed by the meta nodes with their BLS keys.
+// The reporter fields are left out so that any meta node of the quorum can submit the report.
+func (msg MsgVolumeReport) GetBLSSignBytes() []byte {
+	signMsg := MsgVolumeReport{
+		WalletVolumes:   msg.WalletVolumes,
+		ConsumerVolumes: msg.ConsumerVolumes,
+		Epoch:           msg.Epoch,
+		ReportReference: msg.ReportReference,
+	}
+	bz := ModuleCdc.MustMarshalJSON(signMsg)
+	return sdk.MustSortJSON(bz)
+}
+
 // Route Implement
 func (msg MsgVolumeReport) Route() string { return RouterKey }
 
@@ -82,6 +100,28 @@ func (msg MsgVolumeReport) GetSignBytes() []byte {
 	return sdk.MustSortJSON(bz)
 }
 
+// ValidateWalletVolumes checks that the reported volumes are non-negative and that their total is positive,
+// the pot reward distribution divides by the total volume
+func ValidateWalletVolumes(walletVolumes []SingleWalletVolume) error {
+	if !(len(walletVolumes) > 0) {
+		return ErrEmptyWalletVolumes
+	}
+	totalVolume := sdk.ZeroInt()
+	for _, item := range walletVolumes {
+		if item.Volume.IsNil() || item.Volume.IsNegative() {
+			return ErrNegativeVolume
+		}
+		if item.WalletAddress.Empty() {
+			return ErrMissingWalletAddress
+		}
+		totalVolume = totalVolume.Add(item.Volume)
+	}
+	if !totalVolume.IsPositive() {
+		return ErrZeroTotalVolume
+	}
+	return nil
+}
+
 // ValidateBasic validity check for the AnteHandler
 func (msg MsgVolumeReport) ValidateBasic() error {
 	if msg.Reporter.Empty() {
@@ -102,13 +142,22 @@ func (msg MsgVolumeReport) ValidateBasic() error {
 		return ErrEmptyReporterOwnerAddr
 	}
 
-	for _, item := range msg.WalletVolumes {
-		if item.Volume.IsNegative() {
+	if err := ValidateWalletVolumes(msg.WalletVolumes); err != nil {
+		return err
+	}
+
+	consumers := make(map[string]bool)
+	for _, item := range msg.ConsumerVolumes {
+		if item.Volume.IsNil() || item.Volume.IsNegative() {
 			return ErrNegativeVolume
 		}
 		if item.WalletAddress.Empty() {
 			return ErrMissingWalletAddress
 		}
+		if consumers[item.WalletAddress.String()] {
+			return ErrDuplicateConsumer
+		}
+		consumers[item.WalletAddress.String()] = true
 	}
 
 	if len(msg.BLSSignature.Signature) == 0 {
@@ -117,6 +166,9 @@ func (msg MsgVolumeReport) ValidateBasic() error {
 	if len(msg.BLSSignature.TxData) == 0 {
 		return ErrBLSTxDataInvalid
 	}
+	if len(msg.BLSSignature.PubKeys) == 0 {
+		return ErrBLSPubkeysInvalid
+	}
 	for _, pubKey := range msg.BLSSignature.PubKeys {
 		if len(pubKey) == 0 {
 			return ErrBLSPubkeysInvalid
@@ -267,3 +319,59 @@ func (m MsgSlashingResourceNode) GetSignBytes() []byte {
 func (m MsgSlashingResourceNode) GetSigners() []sdk.AccAddress {
 	return m.ReporterOwner
 }
+
+// MsgSetRewardPreference sets the action taken on the mature rewards of a wallet, an empty action restores manual withdrawal
+type MsgSetRewardPreference struct {
+	WalletAddress  sdk.AccAddress     `json:"wallet_address" yaml:"wallet_address"`
+	Action         string             `json:"action" yaml:"action"`
+	TargetAddress  sdk.AccAddress     `json:"target_address" yaml:"target_address"`
+	NetworkAddress stratos.SdsAddress `json:"network_address" yaml:"network_address"`
+}
+
+func NewMsgSetRewardPreference(walletAddress sdk.AccAddress, action string, targetAddress sdk.AccAddress,
+	networkAddress stratos.SdsAddress) MsgSetRewardPreference {
+	return MsgSetRewardPreference{
+		WalletAddress:  walletAddress,
+		Action:         action,
+		TargetAddress:  targetAddress,
+		NetworkAddress: networkAddress,
+	}
+}
+
+// Route Implement
+func (msg MsgSetRewardPreference) Route() string { return RouterKey }
+
+// GetSigners Implement
+func (msg MsgSetRewardPreference) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.WalletAddress}
+}
+
+// Type Implement
+func (msg MsgSetRewardPreference) Type() string { return RewardPreferenceMsgType }
+
+// GetSignBytes gets the bytes for the message signer to sign on
+func (msg MsgSetRewardPreference) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic validity check for the AnteHandler
+func (msg MsgSetRewardPreference) ValidateBasic() error {
+	if msg.WalletAddress.Empty() {
+		return ErrMissingWalletAddress
+	}
+	switch msg.Action {
+	case "":
+	case RewardActionWithdraw:
+		if msg.TargetAddress.Empty() {
+			return ErrMissingTargetAddress
+		}
+	case RewardActionRestake:
+		if msg.NetworkAddress.Empty() {
+			return ErrInvalidAddress
+		}
+	default:
+		return ErrInvalidRewardAction
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/params"
@@ -16,6 +17,19 @@ const (
 	DefaultBondDenom   = "ustos"
 	DefaultRewardDenom = "utros"
 	DefaultMatureEpoch = 2016
+	// DefaultMaturationInterval about one epoch (10 minutes) with 5 seconds blocks
+	DefaultMaturationInterval = 120
+)
+
+// DefaultVolumeReportThreshold is the share of valid meta nodes that must submit the same volume report of an epoch
+var DefaultVolumeReportThreshold = sdk.NewDec(2).QuoInt64(3)
+
+var (
+	// DefaultStakeSlashFraction disables the slashing of the stake, only the slashing debt is recorded
+	DefaultStakeSlashFraction       = sdk.ZeroDec()
+	DefaultRedistributeSlashedStake = false
+	// DefaultSuspendDuration keeps a suspended node suspended until it is unsuspended explicitly
+	DefaultSuspendDuration time.Duration = 0
 )
 
 // Parameter store keys
@@ -24,6 +38,13 @@ var (
 	KeyRewardDenom        = []byte("RewardDenom")
 	KeyMatureEpoch        = []byte("matureEpoch")
 	KeyMiningRewardParams = []byte("MiningRewardParams")
+
+	KeyVolumeReportThreshold = []byte("VolumeReportThreshold")
+	KeyMaturationInterval    = []byte("MaturationInterval")
+
+	KeyStakeSlashFraction       = []byte("StakeSlashFraction")
+	KeyRedistributeSlashedStake = []byte("RedistributeSlashedStake")
+	KeySuspendDuration          = []byte("SuspendDuration")
 )
 
 var _ subspace.ParamSet = &Params{}
@@ -34,6 +55,13 @@ type Params struct {
 	RewardDenom        string              `json:"reward_denom" yaml:"reward_denom"`
 	MatureEpoch        int64               `json:"mature_epoch" yaml:"mature_epoch"`
 	MiningRewardParams []MiningRewardParam `json:"mining_reward_params" yaml:"mining_reward_params"`
+
+	VolumeReportThreshold sdk.Dec `json:"volume_report_threshold" yaml:"volume_report_threshold"` // share of valid meta nodes that must agree on a volume report
+	MaturationInterval    int64   `json:"maturation_interval" yaml:"maturation_interval"`         // number of blocks between two reward maturations
+
+	StakeSlashFraction       sdk.Dec       `json:"stake_slash_fraction" yaml:"stake_slash_fraction"`             // fraction of the stake slashed from a resource node, 0 disables
+	RedistributeSlashedStake bool          `json:"redistribute_slashed_stake" yaml:"redistribute_slashed_stake"` // send the slashed stake to the foundation account instead of burning it
+	SuspendDuration          time.Duration `json:"suspend_duration" yaml:"suspend_duration"`                     // time a node stays suspended, 0 until it is unsuspended
 }
 
 // ParamKeyTable for pot module
@@ -42,12 +70,20 @@ func ParamKeyTable() params.KeyTable {
 }
 
 // NewParams creates a new Params object
-func NewParams(bondDenom string, rewardDenom string, matureEpoch int64, miningRewardParams []MiningRewardParam) Params {
+func NewParams(bondDenom string, rewardDenom string, matureEpoch int64, miningRewardParams []MiningRewardParam,
+	volumeReportThreshold sdk.Dec, maturationInterval int64, stakeSlashFraction sdk.Dec, redistributeSlashedStake bool,
+	suspendDuration time.Duration) Params {
 	return Params{
-		BondDenom:          bondDenom,
-		RewardDenom:        rewardDenom,
-		MatureEpoch:        matureEpoch,
-		MiningRewardParams: miningRewardParams,
+		BondDenom:             bondDenom,
+		RewardDenom:           rewardDenom,
+		MatureEpoch:           matureEpoch,
+		MiningRewardParams:    miningRewardParams,
+		VolumeReportThreshold: volumeReportThreshold,
+		MaturationInterval:    maturationInterval,
+
+		StakeSlashFraction:       stakeSlashFraction,
+		RedistributeSlashedStake: redistributeSlashedStake,
+		SuspendDuration:          suspendDuration,
 	}
 }
 
@@ -89,7 +125,8 @@ func DefaultParams() Params {
 		sdk.NewCoin(DefaultRewardDenom, sdk.NewInt(40000000000000000)),
 		sdk.NewCoin(DefaultRewardDenom, sdk.NewInt(2500000000)),
 		sdk.NewInt(7000), sdk.NewInt(1000), sdk.NewInt(2000)))
-	return NewParams(DefaultBondDenom, DefaultRewardDenom, DefaultMatureEpoch, miningRewardParams)
+	return NewParams(DefaultBondDenom, DefaultRewardDenom, DefaultMatureEpoch, miningRewardParams, DefaultVolumeReportThreshold,
+		DefaultMaturationInterval, DefaultStakeSlashFraction, DefaultRedistributeSlashedStake, DefaultSuspendDuration)
 }
 
 // String implements the stringer interface for Params
@@ -98,8 +135,14 @@ func (p Params) String() string {
 	BondDenom:			%s
     RewardDenom:	%s
 	MatureEpoch:        %d
-  	MiningRewardParams:	%s`,
-		p.BondDenom, p.RewardDenom, p.MatureEpoch, p.MiningRewardParams)
+  	MiningRewardParams:	%s
+	VolumeReportThreshold:	%s
+	MaturationInterval:	%d
+	StakeSlashFraction:	%s
+	RedistributeSlashedStake:	%t
+	SuspendDuration:	%s`,
+		p.BondDenom, p.RewardDenom, p.MatureEpoch, p.MiningRewardParams, p.VolumeReportThreshold, p.MaturationInterval,
+		p.StakeSlashFraction, p.RedistributeSlashedStake, p.SuspendDuration)
 }
 
 // ParamSetPairs - Implements params.ParamSet
@@ -109,6 +152,11 @@ func (p *Params) ParamSetPairs() params.ParamSetPairs {
 		params.NewParamSetPair(KeyRewardDenom, &p.RewardDenom, validateRewardDenom),
 		params.NewParamSetPair(KeyMatureEpoch, &p.MatureEpoch, validateMatureEpoch),
 		params.NewParamSetPair(KeyMiningRewardParams, &p.MiningRewardParams, validateMiningRewardParams),
+		params.NewParamSetPair(KeyVolumeReportThreshold, &p.VolumeReportThreshold, validateVolumeReportThreshold),
+		params.NewParamSetPair(KeyMaturationInterval, &p.MaturationInterval, validateMaturationInterval),
+		params.NewParamSetPair(KeyStakeSlashFraction, &p.StakeSlashFraction, validateStakeSlashFraction),
+		params.NewParamSetPair(KeyRedistributeSlashedStake, &p.RedistributeSlashedStake, validateRedistributeSlashedStake),
+		params.NewParamSetPair(KeySuspendDuration, &p.SuspendDuration, validateSuspendDuration),
 	}
 }
 
@@ -161,6 +209,67 @@ func validateMiningRewardParams(i interface{}) error {
 	return nil
 }
 
+func validateVolumeReportThreshold(i interface{}) error {
+	v, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v.IsNil() || !v.IsPositive() || v.GT(sdk.OneDec()) {
+		return fmt.Errorf("volume report threshold must be in (0, 1]: %s", v)
+	}
+
+	return nil
+}
+
+func validateMaturationInterval(i interface{}) error {
+	v, ok := i.(int64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v <= 0 {
+		return fmt.Errorf("maturation interval must be positive: %d", v)
+	}
+
+	return nil
+}
+
+func validateStakeSlashFraction(i interface{}) error {
+	v, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v.IsNil() || v.IsNegative() || v.GT(sdk.OneDec()) {
+		return fmt.Errorf("stake slash fraction must be in [0, 1]: %s", v)
+	}
+
+	return nil
+}
+
+func validateRedistributeSlashedStake(i interface{}) error {
+	_, ok := i.(bool)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	return nil
+}
+
+func validateSuspendDuration(i interface{}) error {
+	v, ok := i.(time.Duration)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v < 0 {
+		return fmt.Errorf("suspend duration must not be negative: %s", v)
+	}
+
+	return nil
+}
+
 func (p Params) ValidateBasic() error {
 	if err := validateBondDenom(p.BondDenom); err != nil {
 		return err
@@ -171,5 +280,17 @@ func (p Params) ValidateBasic() error {
 	if err := validateMatureEpoch(p.MatureEpoch); err != nil {
 		return err
 	}
+	if err := validateVolumeReportThreshold(p.VolumeReportThreshold); err != nil {
+		return err
+	}
+	if err := validateMaturationInterval(p.MaturationInterval); err != nil {
+		return err
+	}
+	if err := validateStakeSlashFraction(p.StakeSlashFraction); err != nil {
+		return err
+	}
+	if err := validateSuspendDuration(p.SuspendDuration); err != nil {
+		return err
+	}
 	return nil
 }
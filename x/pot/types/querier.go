@@ -62,6 +62,49 @@ func NewQueryPotRewardsByWalletAddrParams(page, limit int, walletAddr sdk.AccAdd
 	}
 }
 
+type QueryWalletVolumesByEpochParams struct {
+	Page  int
+	Limit int
+	Epoch sdk.Int
+}
+
+// NewQueryWalletVolumesByEpochParams creates a new instance of QueryWalletVolumesByEpochParams
+func NewQueryWalletVolumesByEpochParams(page, limit int, epoch sdk.Int) QueryWalletVolumesByEpochParams {
+	return QueryWalletVolumesByEpochParams{
+		Page:  page,
+		Limit: limit,
+		Epoch: epoch,
+	}
+}
+
+type QueryWalletVolumeHistoryParams struct {
+	Page          int
+	Limit         int
+	WalletAddress sdk.AccAddress
+}
+
+// NewQueryWalletVolumeHistoryParams creates a new instance of QueryWalletVolumeHistoryParams
+func NewQueryWalletVolumeHistoryParams(page, limit int, walletAddress sdk.AccAddress) QueryWalletVolumeHistoryParams {
+	return QueryWalletVolumeHistoryParams{
+		Page:          page,
+		Limit:         limit,
+		WalletAddress: walletAddress,
+	}
+}
+
+type QuerySimulateDistributionParams struct {
+	Epoch         sdk.Int              `json:"epoch" yaml:"epoch"`
+	WalletVolumes []SingleWalletVolume `json:"wallet_volumes" yaml:"wallet_volumes"`
+}
+
+// NewQuerySimulateDistributionParams creates a new instance of QuerySimulateDistributionParams
+func NewQuerySimulateDistributionParams(epoch sdk.Int, walletVolumes []SingleWalletVolume) QuerySimulateDistributionParams {
+	return QuerySimulateDistributionParams{
+		Epoch:         epoch,
+		WalletVolumes: walletVolumes,
+	}
+}
+
 type ReportInfo struct {
 	Epoch     sdk.Int
 	Reference string
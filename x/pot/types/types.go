@@ -21,6 +21,29 @@ func NewSingleWalletVolume(
 	}
 }
 
+const (
+	RewardActionWithdraw = "withdraw" // mature rewards are withdrawn to the target address
+	RewardActionRestake  = "restake"  // mature rewards are staked to the resource node of the wallet
+)
+
+// RewardPreference is the action taken on the mature rewards of a wallet at maturity
+type RewardPreference struct {
+	WalletAddress  sdk.AccAddress     `json:"wallet_address" yaml:"wallet_address"`
+	Action         string             `json:"action" yaml:"action"`
+	TargetAddress  sdk.AccAddress     `json:"target_address" yaml:"target_address"`   // used by withdraw
+	NetworkAddress stratos.SdsAddress `json:"network_address" yaml:"network_address"` // used by restake
+}
+
+func NewRewardPreference(walletAddress sdk.AccAddress, action string, targetAddress sdk.AccAddress,
+	networkAddress stratos.SdsAddress) RewardPreference {
+	return RewardPreference{
+		WalletAddress:  walletAddress,
+		Action:         action,
+		TargetAddress:  targetAddress,
+		NetworkAddress: networkAddress,
+	}
+}
+
 type MiningRewardParam struct {
 	TotalMinedValveStart                sdk.Coin `json:"total_mined_valve_start" yaml:"total_mined_valve_start"`
 	TotalMinedValveEnd                  sdk.Coin `json:"total_mined_valve_end" yaml:"total_mined_valve_end"`
@@ -46,13 +69,58 @@ type VolumeReportRecord struct {
 	Reporter        stratos.SdsAddress
 	ReportReference string
 	TxHash          string
+	WalletVolumes   []SingleWalletVolume
 }
 
-func NewReportRecord(reporter stratos.SdsAddress, reportReference string, txHash string) VolumeReportRecord {
+func NewReportRecord(reporter stratos.SdsAddress, reportReference string, txHash string, walletVolumes []SingleWalletVolume) VolumeReportRecord {
 	return VolumeReportRecord{
 		Reporter:        reporter,
 		ReportReference: reportReference,
 		TxHash:          txHash,
+		WalletVolumes:   walletVolumes,
+	}
+}
+
+// VolumeReportSubmission is a volume report submitted by a meta node, waiting for the other meta nodes to agree on it
+type VolumeReportSubmission struct {
+	Epoch           sdk.Int              `json:"epoch" yaml:"epoch"`
+	Reporter        stratos.SdsAddress   `json:"reporter" yaml:"reporter"`
+	ReportHash      []byte               `json:"report_hash" yaml:"report_hash"`
+	WalletVolumes   []SingleWalletVolume `json:"wallet_volumes" yaml:"wallet_volumes"`
+	ConsumerVolumes []SingleWalletVolume `json:"consumer_volumes" yaml:"consumer_volumes"`
+	ReportReference string               `json:"report_reference" yaml:"report_reference"`
+	TxHash          string               `json:"tx_hash" yaml:"tx_hash"`
+}
+
+func NewVolumeReportSubmission(epoch sdk.Int, reporter stratos.SdsAddress, reportHash []byte, walletVolumes []SingleWalletVolume,
+	consumerVolumes []SingleWalletVolume, reportReference string, txHash string) VolumeReportSubmission {
+	return VolumeReportSubmission{
+		Epoch:           epoch,
+		Reporter:        reporter,
+		ReportHash:      reportHash,
+		WalletVolumes:   walletVolumes,
+		ConsumerVolumes: consumerVolumes,
+		ReportReference: reportReference,
+		TxHash:          txHash,
+	}
+}
+
+// WalletVolumeRecord is the traffic of a wallet reported at an epoch and the reward it earned from that report
+type WalletVolumeRecord struct {
+	Epoch                 sdk.Int        `json:"epoch" yaml:"epoch"`
+	WalletAddress         sdk.AccAddress `json:"wallet_address" yaml:"wallet_address"`
+	Volume                sdk.Int        `json:"volume" yaml:"volume"` //uoz
+	RewardFromMiningPool  sdk.Coins      `json:"reward_from_mining_pool" yaml:"reward_from_mining_pool"`
+	RewardFromTrafficPool sdk.Coins      `json:"reward_from_traffic_pool" yaml:"reward_from_traffic_pool"`
+}
+
+func NewWalletVolumeRecord(epoch sdk.Int, walletAddress sdk.AccAddress, volume sdk.Int, reward Reward) WalletVolumeRecord {
+	return WalletVolumeRecord{
+		Epoch:                 epoch,
+		WalletAddress:         walletAddress,
+		Volume:                volume,
+		RewardFromMiningPool:  reward.RewardFromMiningPool,
+		RewardFromTrafficPool: reward.RewardFromTrafficPool,
 	}
 }
 
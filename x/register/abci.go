@@ -6,13 +6,13 @@ import (
 	abci "github.com/tendermint/tendermint/abci/types"
 )
 
-// BeginBlocker check for infraction evidence or downtime of validators
-// on every begin block
+// BeginBlocker records the uoz price & supply of the block
 func BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock, k keeper.Keeper) {
-	// 	TODO: fill out if your application requires beginblock, if not you can delete this function
+	k.TrackUozHistory(ctx)
 }
 
 // EndBlocker called every block, process inflation, update validator set.
 func EndBlocker(ctx sdk.Context, k keeper.Keeper) []abci.ValidatorUpdate {
+	k.RemoveExpiredRegistrationVotePools(ctx)
 	return k.BlockRegisteredNodesUpdates(ctx)
 }
@@ -16,8 +16,12 @@ const (
 )
 
 var (
-	NewKeeper     = keeper.NewKeeper
-	RegisterCodec = types.RegisterCodec
+	NewKeeper                      = keeper.NewKeeper
+	RegisterInvariants             = keeper.RegisterInvariants
+	AllInvariants                  = keeper.AllInvariants
+	ResourceNodePoolsInvariant     = keeper.ResourceNodePoolsInvariant
+	NonNegativeOzoneLimitInvariant = keeper.NonNegativeOzoneLimitInvariant
+	RegisterCodec                  = types.RegisterCodec
 
 	ErrInvalid                  = types.ErrInvalid
 	ErrInvalidNetworkAddr       = types.ErrInvalidNetworkAddr
@@ -34,15 +38,27 @@ var (
 	ErrInvalidOwnerAddr         = types.ErrInvalidOwnerAddr
 	ErrInvalidApproverAddr      = types.ErrInvalidVoterAddr
 	ErrInvalidApproverStatus    = types.ErrInvalidVoterStatus
+	ErrNodeNotSuspended         = types.ErrNodeNotSuspended
+	ErrMinSuspendPeriod         = types.ErrMinSuspendPeriod
+	ErrSuspensionNotExpired     = types.ErrSuspensionNotExpired
+	ErrSlashingNotCleared       = types.ErrSlashingNotCleared
+	ErrNodeSuspended            = types.ErrNodeSuspended
 
-	DefaultParams            = types.DefaultParams
-	DefaultGenesisState      = types.DefaultGenesisState
-	NewGenesisState          = types.NewGenesisState
-	NewResourceNode          = types.NewResourceNode
-	NewIndexingNode          = types.NewIndexingNode
-	NewDescription           = types.NewDescription
-	NewMsgCreateResourceNode = types.NewMsgCreateResourceNode
-	NewMsgCreateIndexingNode = types.NewMsgCreateIndexingNode
+	DefaultParams             = types.DefaultParams
+	DefaultGenesisState       = types.DefaultGenesisState
+	NewGenesisState           = types.NewGenesisState
+	NewResourceNode           = types.NewResourceNode
+	NewIndexingNode           = types.NewIndexingNode
+	NewDescription            = types.NewDescription
+	NewMsgCreateResourceNode  = types.NewMsgCreateResourceNode
+	NewMsgCreateIndexingNode  = types.NewMsgCreateIndexingNode
+	NewMsgUnsuspendNode       = types.NewMsgUnsuspendNode
+	NewMsgDelegate            = types.NewMsgDelegate
+	NewMsgUndelegate          = types.NewMsgUndelegate
+	NewMsgRedelegateNodeStake = types.NewMsgRedelegateNodeStake
+	NewDelegation             = types.NewDelegation
+	NewCommissionRates        = types.NewCommissionRates
+	NewCommission             = types.NewCommission
 
 	GetGenesisStateFromAppState = types.GetGenesisStateFromAppState
 
@@ -50,13 +66,27 @@ var (
 )
 
 type (
-	Keeper                = keeper.Keeper
-	ResourceNode          = types.ResourceNode
-	IndexingNode          = types.IndexingNode
-	Description           = types.Description
-	GenesisIndexingNode   = types.GenesisIndexingNode
-	Slashing              = types.Slashing
-	MsgCreateResourceNode = types.MsgCreateResourceNode
-	MsgCreateIndexingNode = types.MsgCreateIndexingNode
-	VoteOpinion           = types.VoteOpinion
+	Keeper                 = keeper.Keeper
+	ResourceNode           = types.ResourceNode
+	IndexingNode           = types.IndexingNode
+	Description            = types.Description
+	GenesisIndexingNode    = types.GenesisIndexingNode
+	Slashing               = types.Slashing
+	OzoneBalance           = types.OzoneBalance
+	UozSnapshot            = types.UozSnapshot
+	MsgCreateResourceNode  = types.MsgCreateResourceNode
+	MsgCreateIndexingNode  = types.MsgCreateIndexingNode
+	MsgUnsuspendNode       = types.MsgUnsuspendNode
+	MsgDelegate            = types.MsgDelegate
+	MsgUndelegate          = types.MsgUndelegate
+	MsgRedelegateNodeStake = types.MsgRedelegateNodeStake
+	Delegation             = types.Delegation
+	Delegations            = types.Delegations
+	Redelegation           = types.Redelegation
+	Redelegations          = types.Redelegations
+	CommissionRates        = types.CommissionRates
+	Commission             = types.Commission
+
+	IndexingNodeRegistrationVotePool = types.IndexingNodeRegistrationVotePool
+	VoteOpinion                      = types.VoteOpinion
 )
@@ -33,6 +33,8 @@ func TestRegister(t *testing.T) {
 		resOwnerAddr3,
 		NewDescription("sds://resourceNode3", "", "", "", ""),
 		types.STORAGE,
+		types.CommissionRates{},
+		nil,
 	)
 	t.Log("registerResNodeMsg: ", registerResNodeMsg)
 
@@ -3,6 +3,7 @@ package register
 import (
 	"os"
 	"testing"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
@@ -12,6 +13,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/staking"
 	"github.com/cosmos/cosmos-sdk/x/supply"
 	supplyexported "github.com/cosmos/cosmos-sdk/x/supply/exported"
+	"github.com/stratosnet/stratos-chain/crypto/bls"
 	"github.com/stratosnet/stratos-chain/helpers"
 	stratos "github.com/stratosnet/stratos-chain/types"
 	"github.com/stratosnet/stratos-chain/x/register/types"
@@ -50,7 +52,7 @@ func Test(t *testing.T) {
 	/********************* send register resource node msg *********************/
 	header = abci.Header{Height: mApp.LastBlockHeight() + 1}
 	ctx = mApp.BaseApp.NewContext(true, header)
-	registerResNodeMsg := types.NewMsgCreateResourceNode(resNodeNetworkId2, resNodePubKey2, sdk.NewCoin(k.BondDenom(ctx), resNodeInitStake), resOwnerAddr2, NewDescription("sds://resourceNode2", "", "", "", ""), 4)
+	registerResNodeMsg := types.NewMsgCreateResourceNode(resNodeNetworkId2, resNodePubKey2, sdk.NewCoin(k.BondDenom(ctx), resNodeInitStake), resOwnerAddr2, NewDescription("sds://resourceNode2", "", "", "", ""), 4, types.CommissionRates{}, nil)
 	resNodeOwnerAcc2 := mApp.AccountKeeper.GetAccount(ctx, resOwnerAddr2)
 	accNumOwner := resNodeOwnerAcc2.GetAccountNumber()
 	accSeqOwner := resNodeOwnerAcc2.GetSequence()
@@ -77,7 +79,9 @@ func Test(t *testing.T) {
 	/********************* send register indexing node msg *********************/
 	header = abci.Header{Height: mApp.LastBlockHeight() + 1}
 	ctx = mApp.BaseApp.NewContext(true, header)
-	registerIdxNodeMsg := types.NewMsgCreateIndexingNode(idxNodeNetworkId3, idxNodePubKey3, sdk.NewCoin(k.BondDenom(ctx), idxNodeInitStake), idxOwnerAddr3, NewDescription("sds://indexingNode3", "", "", "", ""))
+	idxNodeBLSPubKey3, idxNodeBLSProof3 := bls.GenPubKeyAndProof()
+	registerIdxNodeMsg := types.NewMsgCreateIndexingNode(idxNodeNetworkId3, idxNodePubKey3, sdk.NewCoin(k.BondDenom(ctx), idxNodeInitStake), idxOwnerAddr3, NewDescription("sds://indexingNode3", "", "", "", ""),
+		idxNodeBLSPubKey3, idxNodeBLSProof3, types.CommissionRates{}, nil)
 	idxOwnerAcc3 := mApp.AccountKeeper.GetAccount(ctx, idxOwnerAddr3)
 	accNumOwner = idxOwnerAcc3.GetAccountNumber()
 	accSeqOwner = idxOwnerAcc3.GetSequence()
@@ -126,6 +130,373 @@ func Test(t *testing.T) {
 
 }
 
+func TestExpiredRegistrationVotePool(t *testing.T) {
+	mApp, k, bankKeeper, _ := getMockApp(t)
+	accounts := setupAccounts(mApp)
+	mock.SetGenesis(mApp, accounts)
+
+	header := abci.Header{Height: mApp.LastBlockHeight() + 1, Time: time.Now()}
+	ctx := mApp.BaseApp.NewContext(true, header)
+
+	// indexing node 2 is unbonded in genesis, its registration vote pool expires in one hour
+	idxNodeNetworkId2 := stratos.SdsAddress(idxNodeAddr2)
+	votePool := types.NewRegistrationVotePool(idxNodeNetworkId2, []stratos.SdsAddress{idxNodeNetworkId1}, nil, header.Time.Add(time.Hour))
+	k.SetIndexingNodeRegistrationVotePool(ctx, votePool)
+	require.Len(t, ExportGenesis(ctx, k).VotePools, 1)
+
+	k.RemoveExpiredRegistrationVotePools(ctx)
+	_, found := k.GetIndexingNodeRegistrationVotePool(ctx, idxNodeNetworkId2)
+	require.True(t, found)
+
+	/********************* the stake is refunded once the vote pool expired *********************/
+	ctx = ctx.WithBlockTime(header.Time.Add(time.Hour + time.Second))
+	lastOwnerBalance := bankKeeper.GetCoins(ctx, idxOwnerAddr2).AmountOf(k.BondDenom(ctx))
+	k.RemoveExpiredRegistrationVotePools(ctx)
+
+	_, found = k.GetIndexingNodeRegistrationVotePool(ctx, idxNodeNetworkId2)
+	require.False(t, found)
+	_, found = k.GetIndexingNode(ctx, idxNodeNetworkId2)
+	require.False(t, found)
+	require.Equal(t, sdk.NewCoin(k.BondDenom(ctx), sdk.ZeroInt()), k.GetIndexingNodeNotBondedToken(ctx))
+	require.Equal(t, lastOwnerBalance.Add(idxNodeInitStake), bankKeeper.GetCoins(ctx, idxOwnerAddr2).AmountOf(k.BondDenom(ctx)))
+	require.Empty(t, ExportGenesis(ctx, k).VotePools)
+	msg, broken := AllInvariants(k)(ctx)
+	require.False(t, broken, msg)
+}
+
+func TestUozHistory(t *testing.T) {
+	mApp, k, _, _ := getMockApp(t)
+	accounts := setupAccounts(mApp)
+	mock.SetGenesis(mApp, accounts)
+
+	header := abci.Header{Height: mApp.LastBlockHeight() + 1, Time: time.Now()}
+	ctx := mApp.BaseApp.NewContext(true, header)
+	params := k.GetParams(ctx)
+	params.UozHistoryEntries = 3
+	k.SetParams(ctx, params)
+
+	/********************* only the snapshots of the last UozHistoryEntries blocks are kept *********************/
+	for height := int64(1); height <= 5; height++ {
+		k.SetTotalUnissuedPrepay(ctx, sdk.NewCoin(k.BondDenom(ctx), sdk.NewInt(height*1000)))
+		k.TrackUozHistory(ctx.WithBlockHeight(height))
+	}
+	snapshots := k.GetUozSnapshots(ctx, 0, 10)
+	require.Len(t, snapshots, 3)
+	for i, snapshot := range snapshots {
+		height := int64(i + 3)
+		require.Equal(t, height, snapshot.Height)
+		require.Equal(t, sdk.NewInt(height*1000), snapshot.TotalUnissuedPrepay)
+	}
+	require.Equal(t, k.CurrUozPrice(ctx), snapshots[2].UozPrice)
+	require.Len(t, k.GetUozSnapshots(ctx, 4, 4), 1)
+
+	/********************* the history is cleared once disabled *********************/
+	params.UozHistoryEntries = 0
+	k.SetParams(ctx, params)
+	k.TrackUozHistory(ctx.WithBlockHeight(6))
+	require.Empty(t, k.GetUozSnapshots(ctx, 0, 10))
+}
+
+func TestExportImportGenesis(t *testing.T) {
+	mApp, k, _, _ := getMockApp(t)
+	accounts := setupAccounts(mApp)
+	mock.SetGenesis(mApp, accounts)
+
+	header := abci.Header{Height: mApp.LastBlockHeight() + 1, Time: time.Now().UTC()}
+	ctx := mApp.BaseApp.NewContext(true, header)
+
+	/********************* setup an in-flight unbonding, a vote pool, a suspension, a slashing & the uoz history *********************/
+	resourceNode1, _ := k.GetResourceNode(ctx, resNodeNetworkId1)
+	_, completionTime, err := k.UnbondResourceNode(ctx, resourceNode1, resNodeInitStake.QuoRaw(2))
+	require.NoError(t, err)
+	votePool := types.NewRegistrationVotePool(stratos.SdsAddress(idxNodeAddr2), []stratos.SdsAddress{idxNodeNetworkId1}, nil, header.Time.Add(time.Hour))
+	k.SetIndexingNodeRegistrationVotePool(ctx, votePool)
+	k.SetNodeSuspendedTime(ctx, resNodeNetworkId3, header.Time)
+	k.SetSlashing(ctx, resOwnerAddr3, sdk.NewInt(100))
+	k.TrackUozHistory(ctx.WithBlockHeight(1))
+	k.TrackUozHistory(ctx.WithBlockHeight(2))
+
+	exported := ExportGenesis(ctx, k)
+	require.NoError(t, types.ValidateGenesis(exported))
+	require.Len(t, exported.UnbondingNodes, 1)
+	require.Len(t, exported.UozSnapshots, 2)
+	bz := types.ModuleCdc.MustMarshalJSON(exported)
+
+	/********************* import the exported genesis into a new app *********************/
+	mApp2, k2, _, _ := getMockApp(t)
+	mock.SetGenesis(mApp2, accounts)
+	ctx2 := mApp2.BaseApp.NewContext(true, header)
+	var imported types.GenesisState
+	types.ModuleCdc.MustUnmarshalJSON(bz, &imported)
+	InitGenesis(ctx2, k2, imported)
+
+	require.Equal(t, string(bz), string(types.ModuleCdc.MustMarshalJSON(ExportGenesis(ctx2, k2))))
+	require.Equal(t, k.GetUnbondingNodeQueueTimeSlice(ctx, completionTime), k2.GetUnbondingNodeQueueTimeSlice(ctx2, completionTime))
+	require.Equal(t, k.GetResourceNodeNotBondedToken(ctx), k2.GetResourceNodeNotBondedToken(ctx2))
+	require.Equal(t, k.CurrUozPrice(ctx), k2.CurrUozPrice(ctx2))
+	require.Equal(t, k.GetUozSnapshots(ctx, 1, 2), k2.GetUozSnapshots(ctx2, 1, 2))
+
+	/********************* the pools still match the nodes, until they are tampered with *********************/
+	msg, broken := AllInvariants(k)(ctx)
+	require.False(t, broken, msg)
+	msg, broken = AllInvariants(k2)(ctx2)
+	require.False(t, broken, msg)
+
+	k2.SetResourceNodeNotBondedToken(ctx2, k2.GetResourceNodeNotBondedToken(ctx2).Add(sdk.NewCoin(k2.BondDenom(ctx2), sdk.OneInt())))
+	_, broken = ResourceNodePoolsInvariant(k2)(ctx2)
+	require.True(t, broken)
+	k2.SetRemainingOzoneLimit(ctx2, sdk.NewInt(-1))
+	_, broken = NonNegativeOzoneLimitInvariant(k2)(ctx2)
+	require.True(t, broken)
+
+	/********************* a node with an invalid status breaks the invariant instead of panicking *********************/
+	invalidNode := k.GetAllResourceNodes(ctx)[0]
+	invalidNode.Status = sdk.BondStatus(0xff)
+	k.SetResourceNode(ctx, invalidNode)
+	require.NotPanics(t, func() { msg, broken = ResourceNodePoolsInvariant(k)(ctx) })
+	require.True(t, broken)
+	require.Contains(t, msg, "1 nodes with an invalid status found")
+}
+
+func TestDelegation(t *testing.T) {
+	mApp, k, bankKeeper, _ := getMockApp(t)
+	accounts := setupAccounts(mApp)
+	mock.SetGenesis(mApp, accounts)
+
+	header := abci.Header{Height: mApp.LastBlockHeight() + 1, Time: time.Now().UTC()}
+	ctx := mApp.BaseApp.NewContext(true, header)
+	handler := NewHandler(k)
+	bondDenom := k.BondDenom(ctx)
+	delegated := sdk.NewInt(1000000)
+
+	/********************* the owner of resource node 2 delegates to resource node 1 *********************/
+	_, err := handler(ctx, NewMsgDelegate(resNodeNetworkId1, resOwnerAddr1, false, sdk.NewCoin(bondDenom, delegated)))
+	require.Equal(t, types.ErrSelfDelegation, err)
+	_, err = handler(ctx, NewMsgDelegate(idxNodeNetworkId2, resOwnerAddr2, true, sdk.NewCoin(bondDenom, delegated)))
+	require.Equal(t, types.ErrNodeNotBonded, err)
+
+	bondedBefore := k.GetResourceNodeBondedToken(ctx).Amount
+	_, err = handler(ctx, NewMsgDelegate(resNodeNetworkId1, resOwnerAddr2, false, sdk.NewCoin(bondDenom, delegated)))
+	require.NoError(t, err)
+	delegation, found := k.GetDelegation(ctx, resNodeNetworkId1, resOwnerAddr2)
+	require.True(t, found)
+	require.Equal(t, delegated, delegation.Amount)
+	resourceNode1, _ := k.GetResourceNode(ctx, resNodeNetworkId1)
+	require.Equal(t, resNodeInitStake.Add(delegated), resourceNode1.Tokens)
+	require.Equal(t, bondedBefore.Add(delegated), k.GetResourceNodeBondedToken(ctx).Amount)
+	require.Equal(t, resOwnerInitBalance.Sub(delegated), bankKeeper.GetCoins(ctx, resOwnerAddr2).AmountOf(bondDenom))
+
+	/********************* the owner can not unbond the delegated stake *********************/
+	_, _, err = k.UnbondResourceNode(ctx, resourceNode1, resNodeInitStake.AddRaw(1))
+	require.Equal(t, types.ErrInsufficientBalance, err)
+
+	/********************* the undelegated tokens go through the unbonding queue back to the delegator *********************/
+	_, err = handler(ctx, NewMsgUndelegate(resNodeNetworkId1, resOwnerAddr2, false, sdk.NewCoin(bondDenom, delegated.AddRaw(1))))
+	require.Equal(t, types.ErrInsufficientDelegation, err)
+	res, err := handler(ctx, NewMsgUndelegate(resNodeNetworkId1, resOwnerAddr2, false, sdk.NewCoin(bondDenom, delegated.QuoRaw(2))))
+	require.NoError(t, err)
+	var completionTime time.Time
+	types.ModuleCdc.MustUnmarshalBinaryLengthPrefixed(res.Data, &completionTime)
+	delegation, _ = k.GetDelegation(ctx, resNodeNetworkId1, resOwnerAddr2)
+	require.Equal(t, delegated.QuoRaw(2), delegation.Amount)
+	resourceNode1, _ = k.GetResourceNode(ctx, resNodeNetworkId1)
+	require.Equal(t, resNodeInitStake, k.GetOwnerBondedTokens(ctx, resNodeNetworkId1, resourceNode1.Tokens))
+
+	ctx = ctx.WithBlockTime(completionTime)
+	err = k.CompleteUnbonding(ctx, resNodeNetworkId1)
+	require.NoError(t, err)
+	require.Equal(t, resOwnerInitBalance.Sub(delegated.QuoRaw(2)), bankKeeper.GetCoins(ctx, resOwnerAddr2).AmountOf(bondDenom))
+	resourceNode1, _ = k.GetResourceNode(ctx, resNodeNetworkId1)
+	require.Equal(t, resNodeInitStake.Add(delegated.QuoRaw(2)), resourceNode1.Tokens)
+
+	/********************* the delegations bear their share of a slashing *********************/
+	_, err = k.SlashResourceNodeStake(ctx, resourceNode1, sdk.NewDecWithPrec(5, 1))
+	require.NoError(t, err)
+	delegation, _ = k.GetDelegation(ctx, resNodeNetworkId1, resOwnerAddr2)
+	require.Equal(t, delegated.QuoRaw(4), delegation.Amount)
+
+	exported := ExportGenesis(ctx, k)
+	require.NoError(t, types.ValidateGenesis(exported))
+	require.Len(t, exported.Delegations, 1)
+	msg, broken := AllInvariants(k)(ctx)
+	require.False(t, broken, msg)
+
+	/********************* removing the node can not exceed the max unbonding entries of the node *********************/
+	params := k.GetParams(ctx)
+	maxEntries := params.MaxEntries
+	params.MaxEntries = 1
+	k.SetParams(ctx, params)
+	_, err = handler(ctx, types.NewMsgRemoveResourceNode(resNodeNetworkId1, resOwnerAddr1))
+	require.Equal(t, types.ErrMaxUnbondingNodeEntries, err)
+	params.MaxEntries = maxEntries
+	k.SetParams(ctx, params)
+
+	/********************* removing the node undelegates the delegations along with the stake of the owner *********************/
+	delegatorBalance := bankKeeper.GetCoins(ctx, resOwnerAddr2).AmountOf(bondDenom)
+	res, err = handler(ctx, types.NewMsgRemoveResourceNode(resNodeNetworkId1, resOwnerAddr1))
+	require.NoError(t, err)
+	types.ModuleCdc.MustUnmarshalBinaryLengthPrefixed(res.Data, &completionTime)
+	_, found = k.GetDelegation(ctx, resNodeNetworkId1, resOwnerAddr2)
+	require.False(t, found)
+	resourceNode1, _ = k.GetResourceNode(ctx, resNodeNetworkId1)
+	require.Equal(t, sdk.Unbonding, resourceNode1.Status)
+	require.Equal(t, resourceNode1.Tokens, k.GetUnbondingNodeBalance(ctx, resNodeNetworkId1))
+	msg, broken = AllInvariants(k)(ctx)
+	require.False(t, broken, msg)
+
+	ctx = ctx.WithBlockTime(completionTime)
+	err = k.CompleteUnbonding(ctx, resNodeNetworkId1)
+	require.NoError(t, err)
+	require.Equal(t, delegatorBalance.Add(delegated.QuoRaw(4)), bankKeeper.GetCoins(ctx, resOwnerAddr2).AmountOf(bondDenom))
+	_, found = k.GetResourceNode(ctx, resNodeNetworkId1)
+	require.False(t, found)
+}
+
+func TestCommission(t *testing.T) {
+	mApp, k, _, _ := getMockApp(t)
+	accounts := setupAccounts(mApp)
+	mock.SetGenesis(mApp, accounts)
+
+	blockTime := time.Now().UTC()
+	header := abci.Header{Height: mApp.LastBlockHeight() + 1, Time: blockTime}
+	ctx := mApp.BaseApp.NewContext(true, header)
+	handler := NewHandler(k)
+	description := NewDescription("sds://resourceNode2", "", "", "", "")
+
+	/********************* the commission and the beneficiaries are checked at creation *********************/
+	invalidMsg := types.NewMsgCreateResourceNode(resNodeNetworkId2, resNodePubKey2, sdk.NewCoin(k.BondDenom(ctx), resNodeInitStake), resOwnerAddr2,
+		description, types.STORAGE, NewCommissionRates(sdk.NewDecWithPrec(15, 1), sdk.NewDecWithPrec(1, 1)), nil)
+	require.Equal(t, types.ErrInvalidCommissionRate, invalidMsg.ValidateBasic())
+	invalidMsg = types.NewMsgCreateResourceNode(resNodeNetworkId2, resNodePubKey2, sdk.NewCoin(k.BondDenom(ctx), resNodeInitStake), resOwnerAddr2,
+		description, types.STORAGE, types.CommissionRates{}, []sdk.AccAddress{resOwnerAddr3, resOwnerAddr3})
+	require.Equal(t, types.ErrInvalidBeneficiaries, invalidMsg.ValidateBasic())
+
+	_, err := handler(ctx, types.NewMsgCreateResourceNode(resNodeNetworkId2, resNodePubKey2, sdk.NewCoin(k.BondDenom(ctx), resNodeInitStake),
+		resOwnerAddr2, description, types.STORAGE, NewCommissionRates(sdk.NewDecWithPrec(5, 1), sdk.NewDecWithPrec(1, 1)),
+		[]sdk.AccAddress{resOwnerAddr3}))
+	require.NoError(t, err)
+	resourceNode2, found := k.GetResourceNode(ctx, resNodeNetworkId2)
+	require.True(t, found)
+	require.Equal(t, sdk.NewDecWithPrec(5, 1), resourceNode2.Commission.Rate)
+	require.Equal(t, []sdk.AccAddress{resOwnerAddr3}, resourceNode2.Beneficiaries)
+
+	/********************* the rate can change once a day, by at most the max change rate *********************/
+	newRate := sdk.NewDecWithPrec(6, 1)
+	_, err = handler(ctx, types.NewMsgUpdateResourceNode(description, types.STORAGE, resNodeNetworkId2, resOwnerAddr2, &newRate, nil, false))
+	require.Equal(t, types.ErrCommissionUpdateTime, err)
+
+	ctx = ctx.WithBlockTime(blockTime.Add(types.CommissionUpdatePeriod))
+	tooHighRate := sdk.NewDecWithPrec(7, 1)
+	_, err = handler(ctx, types.NewMsgUpdateResourceNode(description, types.STORAGE, resNodeNetworkId2, resOwnerAddr2, &tooHighRate, nil, false))
+	require.Equal(t, types.ErrCommissionGTMaxChangeRate, err)
+	_, err = handler(ctx, types.NewMsgUpdateResourceNode(description, types.STORAGE, resNodeNetworkId2, resOwnerAddr2, &newRate,
+		[]sdk.AccAddress{resOwnerAddr1, resOwnerAddr3}, false))
+	require.NoError(t, err)
+
+	resourceNode2, _ = k.GetResourceNode(ctx, resNodeNetworkId2)
+	require.Equal(t, newRate, resourceNode2.Commission.Rate)
+	require.Equal(t, ctx.BlockHeader().Time, resourceNode2.Commission.UpdateTime)
+	require.Equal(t, []sdk.AccAddress{resOwnerAddr1, resOwnerAddr3}, resourceNode2.Beneficiaries)
+	require.NoError(t, resourceNode2.Validate())
+
+	/********************* the beneficiaries are kept by an empty list, and removed when cleared *********************/
+	require.Equal(t, types.ErrInvalidBeneficiaries, types.NewMsgUpdateResourceNode(description, types.STORAGE, resNodeNetworkId2,
+		resOwnerAddr2, nil, []sdk.AccAddress{resOwnerAddr1}, true).ValidateBasic())
+	_, err = handler(ctx, types.NewMsgUpdateResourceNode(description, types.STORAGE, resNodeNetworkId2, resOwnerAddr2, nil, nil, false))
+	require.NoError(t, err)
+	resourceNode2, _ = k.GetResourceNode(ctx, resNodeNetworkId2)
+	require.Equal(t, []sdk.AccAddress{resOwnerAddr1, resOwnerAddr3}, resourceNode2.Beneficiaries)
+	_, err = handler(ctx, types.NewMsgUpdateResourceNode(description, types.STORAGE, resNodeNetworkId2, resOwnerAddr2, nil, nil, true))
+	require.NoError(t, err)
+	resourceNode2, _ = k.GetResourceNode(ctx, resNodeNetworkId2)
+	require.Empty(t, resourceNode2.Beneficiaries)
+	require.NoError(t, resourceNode2.Validate())
+}
+
+func TestRedelegateNodeStake(t *testing.T) {
+	mApp, k, _, _ := getMockApp(t)
+	accounts := setupAccounts(mApp)
+	mock.SetGenesis(mApp, accounts)
+
+	header := abci.Header{Height: mApp.LastBlockHeight() + 1, Time: time.Now().UTC()}
+	ctx := mApp.BaseApp.NewContext(true, header)
+	handler := NewHandler(k)
+	bondDenom := k.BondDenom(ctx)
+	moved := resNodeInitStake.QuoRaw(4)
+
+	/********************* only the owner can move the stake of its node *********************/
+	_, err := handler(ctx, NewMsgRedelegateNodeStake(resOwnerAddr2, resNodeNetworkId1, resNodeNetworkId2, false,
+		sdk.NewCoin(bondDenom, moved), resNodePubKey2))
+	require.Equal(t, types.ErrInvalidOwnerAddr, err)
+
+	/********************* the stake can not escape the slashing of the owner nor of a suspended node *********************/
+	k.SetSlashing(ctx, resOwnerAddr1, sdk.NewInt(1))
+	_, err = handler(ctx, NewMsgRedelegateNodeStake(resOwnerAddr1, resNodeNetworkId1, resNodeNetworkId2, false,
+		sdk.NewCoin(bondDenom, moved), resNodePubKey2))
+	require.Equal(t, types.ErrSlashingNotCleared, err)
+	k.SetSlashing(ctx, resOwnerAddr1, sdk.ZeroInt())
+
+	resourceNode1, _ := k.GetResourceNode(ctx, resNodeNetworkId1)
+	resourceNode1.Suspend = true
+	k.SetResourceNode(ctx, resourceNode1)
+	_, err = handler(ctx, NewMsgRedelegateNodeStake(resOwnerAddr1, resNodeNetworkId1, resNodeNetworkId2, false,
+		sdk.NewCoin(bondDenom, moved), resNodePubKey2))
+	require.Equal(t, types.ErrNodeSuspended, err)
+	resourceNode1.Suspend = false
+	k.SetResourceNode(ctx, resourceNode1)
+
+	/********************* the stake moves to a new node without changing the pools nor the ozone limit *********************/
+	bondedBefore := k.GetResourceNodeBondedToken(ctx).Amount
+	ozoneLimitBefore := k.GetRemainingOzoneLimit(ctx)
+	res, err := handler(ctx, NewMsgRedelegateNodeStake(resOwnerAddr1, resNodeNetworkId1, resNodeNetworkId2, false,
+		sdk.NewCoin(bondDenom, moved), resNodePubKey2))
+	require.NoError(t, err)
+	var completionTime time.Time
+	types.ModuleCdc.MustUnmarshalBinaryLengthPrefixed(res.Data, &completionTime)
+
+	resourceNode1, _ = k.GetResourceNode(ctx, resNodeNetworkId1)
+	require.Equal(t, resNodeInitStake.Sub(moved), resourceNode1.Tokens)
+	resourceNode2, found := k.GetResourceNode(ctx, resNodeNetworkId2)
+	require.True(t, found)
+	require.Equal(t, moved, resourceNode2.Tokens)
+	require.Equal(t, resOwnerAddr1, resourceNode2.OwnerAddress)
+	require.Equal(t, bondedBefore, k.GetResourceNodeBondedToken(ctx).Amount)
+	require.Equal(t, ozoneLimitBefore, k.GetRemainingOzoneLimit(ctx))
+
+	redelegation, found := k.GetRedelegation(ctx, resNodeNetworkId1, resNodeNetworkId2)
+	require.True(t, found)
+	require.Len(t, redelegation.Entries, 1)
+	require.Equal(t, moved, redelegation.Entries[0].Balance)
+
+	/********************* the stake being redelegated can not move on before the redelegation completes *********************/
+	_, err = handler(ctx, NewMsgRedelegateNodeStake(resOwnerAddr1, resNodeNetworkId2, resNodeNetworkId1, false,
+		sdk.NewCoin(bondDenom, sdk.OneInt()), nil))
+	require.Equal(t, types.ErrTransitiveRedelegation, err)
+
+	/********************* the entries of a node pair are bounded *********************/
+	for i := 1; i < int(k.MaxEntries(ctx)); i++ {
+		_, err = handler(ctx, NewMsgRedelegateNodeStake(resOwnerAddr1, resNodeNetworkId1, resNodeNetworkId2, false,
+			sdk.NewCoin(bondDenom, sdk.OneInt()), nil))
+		require.NoError(t, err)
+	}
+	_, err = handler(ctx, NewMsgRedelegateNodeStake(resOwnerAddr1, resNodeNetworkId1, resNodeNetworkId2, false,
+		sdk.NewCoin(bondDenom, sdk.OneInt()), nil))
+	require.Equal(t, types.ErrMaxRedelegationEntries, err)
+
+	exported := ExportGenesis(ctx, k)
+	require.NoError(t, types.ValidateGenesis(exported))
+	require.Len(t, exported.Redelegations, 1)
+	msg, broken := AllInvariants(k)(ctx)
+	require.False(t, broken, msg)
+
+	/********************* the mature redelegations are removed at the end of the block *********************/
+	ctx = ctx.WithBlockTime(completionTime)
+	k.BlockRegisteredNodesUpdates(ctx)
+	_, found = k.GetRedelegation(ctx, resNodeNetworkId1, resNodeNetworkId2)
+	require.False(t, found)
+	require.False(t, k.HasReceivingRedelegation(ctx, resNodeNetworkId2))
+}
+
 func getMockApp(t *testing.T) (*mock.App, Keeper, bank.Keeper, supply.Keeper) {
 	mApp := mock.NewApp()
 
@@ -210,6 +581,7 @@ func getInitChainer(mapp *mock.App, keeper Keeper, accountKeeper auth.AccountKee
 			initialUOzonePrice,
 			sdk.ZeroInt(),
 			make([]Slashing, 0),
+			make([]IndexingNodeRegistrationVotePool, 0),
 		)
 
 		InitGenesis(ctx, keeper, registerGenesis)
@@ -22,6 +22,17 @@ const (
 	FlagCandidateNetworkAddress = "candidate-network-address"
 	FlagOpinion                 = "opinion"
 	FlagVoterNetworkAddress     = "voter-network-address"
+
+	FlagIsIndexingNode = "indexing-node"
+	FlagDelegator      = "delegator"
+
+	FlagBLSPubKey            = "bls-pub-key"
+	FlagBLSProofOfPossession = "bls-pop"
+
+	FlagCommissionRate          = "commission-rate"
+	FlagCommissionMaxChangeRate = "commission-max-change-rate"
+	FlagBeneficiaries           = "beneficiaries"
+	FlagClearBeneficiaries      = "clear-beneficiaries"
 )
 
 // common flagsets to add to various functions
@@ -38,6 +49,10 @@ var (
 	FsCandidateOwnerAddress   = flag.NewFlagSet("", flag.ContinueOnError)
 	FsOpinion                 = flag.NewFlagSet("", flag.ContinueOnError)
 	FsVoterNetworkAddress     = flag.NewFlagSet("", flag.ContinueOnError)
+	FsBLSPubKey               = flag.NewFlagSet("", flag.ContinueOnError)
+	FsIsIndexingNode          = flag.NewFlagSet("", flag.ContinueOnError)
+	FsCommissionCreate        = flag.NewFlagSet("", flag.ContinueOnError)
+	FsCommissionUpdate        = flag.NewFlagSet("", flag.ContinueOnError)
 )
 
 func init() {
@@ -61,4 +76,16 @@ func init() {
 	FsCandidateOwnerAddress.String(FlagCandidateOwnerAddress, "The owner address of the candidate PP node", "")
 	FsOpinion.Bool(FlagOpinion, false, "Opinion of the vote for the registration of Indexing node.")
 	FsVoterNetworkAddress.String(FlagVoterNetworkAddress, "The address of the PP node that made the vote.", "")
+
+	FsIsIndexingNode.Bool(FlagIsIndexingNode, false, "Whether the node is an indexing node")
+
+	FsBLSPubKey.String(FlagBLSPubKey, "", "The hex encoded BLS public key of the indexing node")
+	FsBLSPubKey.String(FlagBLSProofOfPossession, "", "The hex encoded BLS proof of possession of the BLS public key")
+
+	FsCommissionCreate.String(FlagCommissionRate, "", "The share of the node rewards kept by the owner, the remainder goes to the beneficiaries (default 1)")
+	FsCommissionCreate.String(FlagCommissionMaxChangeRate, "", "The maximum change of the commission rate per day, cannot be changed later (default 1)")
+	FsCommissionCreate.String(FlagBeneficiaries, "", "Comma separated addresses sharing the node rewards left after the commission")
+	FsCommissionUpdate.String(FlagCommissionRate, "", "The new commission rate of the node, empty to keep the current one")
+	FsCommissionUpdate.String(FlagBeneficiaries, "", "Comma separated new beneficiaries of the node, empty to keep the current ones")
+	FsCommissionUpdate.Bool(FlagClearBeneficiaries, false, "Remove the current beneficiaries of the node, the owner then keeps all the rewards")
 }
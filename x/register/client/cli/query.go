@@ -9,6 +9,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -33,6 +34,8 @@ func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
 			// this line is used by starport scaffolding # 1
 			GetCmdQueryResourceNode(queryRoute, cdc),
 			GetCmdQueryIndexingNodeList(queryRoute, cdc),
+			GetCmdQueryDelegations(queryRoute, cdc),
+			GetCmdQueryRedelegations(queryRoute, cdc),
 		)...,
 	)
 
@@ -156,3 +159,84 @@ func QueryIndexingNodes(cliCtx context.CLIContext, queryRoute, networkAddr strin
 	}
 	return cliCtx.QueryWithData(route, bz)
 }
+
+// GetCmdQueryDelegations implements the query delegations by node and/or delegator command.
+func GetCmdQueryDelegations(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delegations [flags]",
+		Short: "Query delegations to a node and/or of a delegator",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			var (
+				networkAddr   stratos.SdsAddress
+				delegatorAddr sdk.AccAddress
+				err           error
+			)
+			if str := viper.GetString(FlagNetworkAddress); str != "" {
+				networkAddr, err = stratos.SdsAddressFromBech32(str)
+				if err != nil {
+					return err
+				}
+			}
+			if str := viper.GetString(FlagDelegator); str != "" {
+				delegatorAddr, err = sdk.AccAddressFromBech32(str)
+				if err != nil {
+					return err
+				}
+			}
+
+			bz, err := cliCtx.Codec.MarshalJSON(types.NewQueryDelegationsParams(networkAddr, delegatorAddr))
+			if err != nil {
+				return err
+			}
+			route := fmt.Sprintf("custom/%s/%s", queryRoute, keeper.QueryDelegations)
+			res, _, err := cliCtx.QueryWithData(route, bz)
+			if err != nil {
+				return err
+			}
+
+			var delegations types.Delegations
+			cdc.MustUnmarshalJSON(res, &delegations)
+			return cliCtx.PrintOutput(delegations)
+		},
+	}
+	cmd.Flags().String(FlagNetworkAddress, "", "(optional) The network address of the node")
+	cmd.Flags().String(FlagDelegator, "", "(optional) The address of the delegator")
+	return cmd
+}
+
+// GetCmdQueryRedelegations implements the query redelegations of node stake command.
+func GetCmdQueryRedelegations(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "redelegations [owner_address]",
+		Short: "Query the node stake redelegations in progress, optionally of a single owner",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			var ownerAddr sdk.AccAddress
+			if len(args) > 0 {
+				var err error
+				ownerAddr, err = sdk.AccAddressFromBech32(args[0])
+				if err != nil {
+					return err
+				}
+			}
+
+			bz, err := cliCtx.Codec.MarshalJSON(types.NewQueryRedelegationsParams(ownerAddr))
+			if err != nil {
+				return err
+			}
+			route := fmt.Sprintf("custom/%s/%s", queryRoute, keeper.QueryRedelegations)
+			res, _, err := cliCtx.QueryWithData(route, bz)
+			if err != nil {
+				return err
+			}
+
+			var reds types.Redelegations
+			cdc.MustUnmarshalJSON(res, &reds)
+			return cliCtx.PrintOutput(reds)
+		},
+	}
+}
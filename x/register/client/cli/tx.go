@@ -2,9 +2,11 @@ package cli
 
 import (
 	"bufio"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/context"
@@ -17,6 +19,7 @@ import (
 	"github.com/spf13/viper"
 	stratos "github.com/stratosnet/stratos-chain/types"
 	"github.com/stratosnet/stratos-chain/x/register/types"
+	"github.com/tendermint/tendermint/crypto"
 )
 
 // GetTxCmd returns the transaction commands for this module
@@ -40,6 +43,12 @@ func GetTxCmd(cdc *codec.Codec) *cobra.Command {
 		UpdateIndexingNodeCmd(cdc),
 		UpdateIndexingNodeStakeCmd(cdc),
 		IndexingNodeRegistrationVoteCmd(cdc),
+
+		UnsuspendNodeCmd(cdc),
+
+		DelegateCmd(cdc),
+		UndelegateCmd(cdc),
+		RedelegateNodeStakeCmd(cdc),
 	)...)
 
 	return registerTxCmd
@@ -74,6 +83,7 @@ func CreateResourceNodeCmd(cdc *codec.Codec) *cobra.Command {
 	cmd.Flags().AddFlagSet(FsNetworkAddress)
 	cmd.Flags().AddFlagSet(FsNodeType)
 	cmd.Flags().AddFlagSet(FsDescription)
+	cmd.Flags().AddFlagSet(FsCommissionCreate)
 
 	_ = cmd.MarkFlagRequired(flags.FlagFrom)
 	_ = cmd.MarkFlagRequired(FlagAmount)
@@ -137,10 +147,14 @@ func CreateIndexingNodeCmd(cdc *codec.Codec) *cobra.Command {
 	cmd.Flags().AddFlagSet(FsAmount)
 	cmd.Flags().AddFlagSet(FsNetworkAddress)
 	cmd.Flags().AddFlagSet(FsDescription)
+	cmd.Flags().AddFlagSet(FsBLSPubKey)
+	cmd.Flags().AddFlagSet(FsCommissionCreate)
 
 	_ = cmd.MarkFlagRequired(flags.FlagFrom)
 	_ = cmd.MarkFlagRequired(FlagAmount)
 	_ = cmd.MarkFlagRequired(FlagPubKey)
+	_ = cmd.MarkFlagRequired(FlagBLSPubKey)
+	_ = cmd.MarkFlagRequired(FlagBLSProofOfPossession)
 
 	return cmd
 }
@@ -207,7 +221,12 @@ func buildCreateResourceNodeMsg(cliCtx context.CLIContext, txBldr auth.TxBuilder
 	if t := types.NodeType(nodeTypeRef).Type(); t == "UNKNOWN" {
 		return txBldr, nil, types.ErrNodeType
 	}
-	msg := types.NewMsgCreateResourceNode(networkAddr, pubKey, amount, ownerAddr, desc, types.NodeType(nodeTypeRef))
+	commission, beneficiaries, err := getCommissionRatesFromFlags()
+	if err != nil {
+		return txBldr, nil, err
+	}
+	msg := types.NewMsgCreateResourceNode(networkAddr, pubKey, amount, ownerAddr, desc, types.NodeType(nodeTypeRef),
+		commission, beneficiaries)
 	return txBldr, msg, nil
 }
 
@@ -266,7 +285,16 @@ func buildCreateIndexingNodeMsg(cliCtx context.CLIContext, txBldr auth.TxBuilder
 		viper.GetString(FlagSecurityContact),
 		viper.GetString(FlagDetails),
 	)
-	msg := types.NewMsgCreateIndexingNode(networkAddr, pubKey, amount, ownerAddr, desc)
+	blsPubKey, blsProofOfPossession, err := getBLSPubKeyFromFlags()
+	if err != nil {
+		return txBldr, nil, err
+	}
+	commission, beneficiaries, err := getCommissionRatesFromFlags()
+	if err != nil {
+		return txBldr, nil, err
+	}
+	msg := types.NewMsgCreateIndexingNode(networkAddr, pubKey, amount, ownerAddr, desc, blsPubKey, blsProofOfPossession,
+		commission, beneficiaries)
 	return txBldr, msg, nil
 }
 
@@ -342,6 +370,87 @@ func RemoveIndexingNodeCmd(cdc *codec.Codec) *cobra.Command {
 	return cmd
 }
 
+// UnsuspendNodeCmd will unsuspend a resource node, or an indexing node when --indexing-node is set
+func UnsuspendNodeCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unsuspend-node [network_address] [owner_address]",
+		Args:  cobra.ExactArgs(2),
+		Short: "unsuspend resource node or indexing node",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInputAndFrom(inBuf, args[1]).WithCodec(cdc)
+
+			networkAddr, err := stratos.SdsAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			ownerAddr := cliCtx.GetFromAddress()
+
+			msg := types.NewMsgUnsuspendNode(networkAddr, ownerAddr, viper.GetBool(FlagIsIndexingNode))
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	cmd.Flags().AddFlagSet(FsIsIndexingNode)
+	return cmd
+}
+
+// DelegateCmd will delegate tokens to a resource node, or to an indexing node when --indexing-node is set
+func DelegateCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delegate [network_address] [amount] [delegator_address]",
+		Args:  cobra.ExactArgs(3),
+		Short: "delegate tokens to a resource node or an indexing node",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInputAndFrom(inBuf, args[2]).WithCodec(cdc)
+
+			networkAddr, err := stratos.SdsAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			amount, err := sdk.ParseCoin(args[1])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgDelegate(networkAddr, cliCtx.GetFromAddress(), viper.GetBool(FlagIsIndexingNode), amount)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	cmd.Flags().AddFlagSet(FsIsIndexingNode)
+	return cmd
+}
+
+// UndelegateCmd will withdraw tokens delegated to a resource node, or to an indexing node when --indexing-node is set
+func UndelegateCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "undelegate [network_address] [amount] [delegator_address]",
+		Args:  cobra.ExactArgs(3),
+		Short: "withdraw tokens delegated to a resource node or an indexing node",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInputAndFrom(inBuf, args[2]).WithCodec(cdc)
+
+			networkAddr, err := stratos.SdsAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			amount, err := sdk.ParseCoin(args[1])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgUndelegate(networkAddr, cliCtx.GetFromAddress(), viper.GetBool(FlagIsIndexingNode), amount)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	cmd.Flags().AddFlagSet(FsIsIndexingNode)
+	return cmd
+}
+
 // IndexingNodeRegistrationVoteCmd Indexing node registration need to be approved by 2/3 of existing indexing nodes
 func IndexingNodeRegistrationVoteCmd(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
@@ -416,6 +525,7 @@ func UpdateResourceNodeCmd(cdc *codec.Codec) *cobra.Command {
 	cmd.Flags().AddFlagSet(FsDescription)
 	cmd.Flags().AddFlagSet(FsNodeType)
 	cmd.Flags().AddFlagSet(FsNetworkAddress)
+	cmd.Flags().AddFlagSet(FsCommissionUpdate)
 
 	_ = cmd.MarkFlagRequired(FlagNetworkAddress)
 	_ = cmd.MarkFlagRequired(FlagMoniker)
@@ -450,7 +560,12 @@ func buildUpdateResourceNodeMsg(cliCtx context.CLIContext, txBldr auth.TxBuilder
 	if t := types.NodeType(nodeType).Type(); t == "UNKNOWN" {
 		return txBldr, nil, types.ErrNodeType
 	}
-	msg := types.NewMsgUpdateResourceNode(desc, types.NodeType(nodeType), nodeAddr, ownerAddr)
+	commissionRate, beneficiaries, err := getCommissionUpdateFromFlags()
+	if err != nil {
+		return txBldr, nil, err
+	}
+	msg := types.NewMsgUpdateResourceNode(desc, types.NodeType(nodeType), nodeAddr, ownerAddr, commissionRate, beneficiaries,
+		viper.GetBool(FlagClearBeneficiaries))
 	return txBldr, msg, nil
 }
 
@@ -473,6 +588,8 @@ func UpdateIndexingNodeCmd(cdc *codec.Codec) *cobra.Command {
 	cmd.Flags().AddFlagSet(FsNetworkAddress)
 	cmd.Flags().AddFlagSet(FsDescription)
 	cmd.Flags().AddFlagSet(FsNetworkAddress)
+	cmd.Flags().AddFlagSet(FsBLSPubKey)
+	cmd.Flags().AddFlagSet(FsCommissionUpdate)
 
 	_ = cmd.MarkFlagRequired(FlagNetworkAddress)
 	_ = cmd.MarkFlagRequired(FlagMoniker)
@@ -502,6 +619,124 @@ func buildUpdateIndexingNodeMsg(cliCtx context.CLIContext, txBldr auth.TxBuilder
 
 	ownerAddr := cliCtx.GetFromAddress()
 
-	msg := types.NewMsgUpdateIndexingNode(desc, nodeAddr, ownerAddr)
+	blsPubKey, blsProofOfPossession, err := getBLSPubKeyFromFlags()
+	if err != nil {
+		return txBldr, nil, err
+	}
+
+	commissionRate, beneficiaries, err := getCommissionUpdateFromFlags()
+	if err != nil {
+		return txBldr, nil, err
+	}
+
+	msg := types.NewMsgUpdateIndexingNode(desc, nodeAddr, ownerAddr, blsPubKey, blsProofOfPossession, commissionRate, beneficiaries,
+		viper.GetBool(FlagClearBeneficiaries))
 	return txBldr, msg, nil
 }
+
+// getBLSPubKeyFromFlags decodes the hex encoded BLS public key and its proof of possession
+func getBLSPubKeyFromFlags() (blsPubKey []byte, blsProofOfPossession []byte, err error) {
+	blsPubKey, err = hex.DecodeString(viper.GetString(FlagBLSPubKey))
+	if err != nil {
+		return nil, nil, err
+	}
+	blsProofOfPossession, err = hex.DecodeString(viper.GetString(FlagBLSProofOfPossession))
+	if err != nil {
+		return nil, nil, err
+	}
+	return blsPubKey, blsProofOfPossession, nil
+}
+
+// getCommissionRatesFromFlags parses the commission rates and beneficiaries of a new node.
+// The rates are left empty, i.e. the defaults apply, when none of them is set.
+func getCommissionRatesFromFlags() (commission types.CommissionRates, beneficiaries []sdk.AccAddress, err error) {
+	rateStr := viper.GetString(FlagCommissionRate)
+	maxChangeRateStr := viper.GetString(FlagCommissionMaxChangeRate)
+	if rateStr != "" || maxChangeRateStr != "" {
+		commission = types.DefaultCommissionRates()
+		if rateStr != "" {
+			if commission.Rate, err = sdk.NewDecFromStr(rateStr); err != nil {
+				return commission, nil, err
+			}
+		}
+		if maxChangeRateStr != "" {
+			if commission.MaxChangeRate, err = sdk.NewDecFromStr(maxChangeRateStr); err != nil {
+				return commission, nil, err
+			}
+		}
+	}
+	beneficiaries, err = getBeneficiariesFromFlags()
+	return commission, beneficiaries, err
+}
+
+// getCommissionUpdateFromFlags parses the new commission rate and beneficiaries of a node, nil when not set
+func getCommissionUpdateFromFlags() (commissionRate *sdk.Dec, beneficiaries []sdk.AccAddress, err error) {
+	if rateStr := viper.GetString(FlagCommissionRate); rateStr != "" {
+		rate, err := sdk.NewDecFromStr(rateStr)
+		if err != nil {
+			return nil, nil, err
+		}
+		commissionRate = &rate
+	}
+	beneficiaries, err = getBeneficiariesFromFlags()
+	return commissionRate, beneficiaries, err
+}
+
+// getBeneficiariesFromFlags parses the comma separated bech32 addresses of the beneficiaries
+func getBeneficiariesFromFlags() (beneficiaries []sdk.AccAddress, err error) {
+	beneficiariesStr := strings.TrimSpace(viper.GetString(FlagBeneficiaries))
+	if beneficiariesStr == "" {
+		return nil, nil
+	}
+	for _, addrStr := range strings.Split(beneficiariesStr, ",") {
+		addr, err := sdk.AccAddressFromBech32(strings.TrimSpace(addrStr))
+		if err != nil {
+			return nil, err
+		}
+		beneficiaries = append(beneficiaries, addr)
+	}
+	return beneficiaries, nil
+}
+
+// RedelegateNodeStakeCmd will move bonded tokens of the owner from one of its nodes to another one, or to a new
+// resource node when --pubkey is set
+func RedelegateNodeStakeCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "redelegate-node-stake [src_network_address] [dst_network_address] [amount] [owner_address]",
+		Args:  cobra.ExactArgs(4),
+		Short: "move bonded tokens from one node of the owner to another one",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInputAndFrom(inBuf, args[3]).WithCodec(cdc)
+
+			srcNetworkAddr, err := stratos.SdsAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			dstNetworkAddr, err := stratos.SdsAddressFromBech32(args[1])
+			if err != nil {
+				return err
+			}
+			amount, err := sdk.ParseCoin(args[2])
+			if err != nil {
+				return err
+			}
+
+			var dstPubKey crypto.PubKey
+			if pkStr := viper.GetString(FlagPubKey); pkStr != "" {
+				dstPubKey, err = stratos.GetPubKeyFromBech32(stratos.Bech32PubKeyTypeSdsP2PPub, pkStr)
+				if err != nil {
+					return err
+				}
+			}
+
+			msg := types.NewMsgRedelegateNodeStake(cliCtx.GetFromAddress(), srcNetworkAddr, dstNetworkAddr,
+				viper.GetBool(FlagIsIndexingNode), amount, dstPubKey)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	cmd.Flags().AddFlagSet(FsIsIndexingNode)
+	cmd.Flags().String(FlagPubKey, "", "The Bech32 encoded PubKey of the destination resource node, only needed when it is not registered yet")
+	return cmd
+}
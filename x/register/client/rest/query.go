@@ -21,6 +21,8 @@ func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router) {
 	r.HandleFunc("/register/staking/address/{nodeAddress}", nodeStakingByNodeAddressFn(cliCtx, keeper.QueryNodeStakeByNodeAddr)).Methods("GET")
 	r.HandleFunc("/register/staking/owner/{ownerAddress}", nodeStakingByOwnerFn(cliCtx, keeper.QueryNodeStakeByOwner)).Methods("GET")
 	r.HandleFunc("/register/params", registerParamsHandlerFn(cliCtx, keeper.QueryRegisterParams)).Methods("GET")
+	r.HandleFunc("/register/delegations", delegationsHandlerFn(cliCtx, keeper.QueryDelegations)).Methods("GET")
+	r.HandleFunc("/register/redelegations", redelegationsHandlerFn(cliCtx, keeper.QueryRedelegations)).Methods("GET")
 }
 
 // GET request handler to query params of Register module
@@ -207,3 +209,88 @@ func nodeStakingByOwnerFn(cliCtx context.CLIContext, queryPath string) http.Hand
 		rest.PostProcessResponse(w, cliCtx, res)
 	}
 }
+
+// GET request handler to query delegations by node and/or delegator
+func delegationsHandlerFn(cliCtx context.CLIContext, queryPath string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		var (
+			networkAddr   stratos.SdsAddress
+			delegatorAddr sdk.AccAddress
+			err           error
+		)
+
+		if v := r.URL.Query().Get(RestNetworkAddr); len(v) != 0 {
+			networkAddr, err = stratos.SdsAddressFromBech32(v)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		if v := r.URL.Query().Get(RestDelegator); len(v) != 0 {
+			delegatorAddr, err = sdk.AccAddressFromBech32(v)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		params := types.NewQueryDelegationsParams(networkAddr, delegatorAddr)
+		bz, err := cliCtx.Codec.MarshalJSON(params)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		route := fmt.Sprintf("custom/%s/%s", types.QuerierRoute, queryPath)
+		res, height, err := cliCtx.QueryWithData(route, bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+// GET request handler to query the node stake redelegations, optionally of a single owner
+func redelegationsHandlerFn(cliCtx context.CLIContext, queryPath string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		var ownerAddr sdk.AccAddress
+		if v := r.URL.Query().Get(RestOwner); len(v) != 0 {
+			var err error
+			ownerAddr, err = sdk.AccAddressFromBech32(v)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		bz, err := cliCtx.Codec.MarshalJSON(types.NewQueryRedelegationsParams(ownerAddr))
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		route := fmt.Sprintf("custom/%s/%s", types.QuerierRoute, queryPath)
+		res, height, err := cliCtx.QueryWithData(route, bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
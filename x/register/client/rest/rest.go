@@ -13,6 +13,7 @@ const (
 	RestNumLimit    = "limit"
 	RestMoniker     = "moniker"
 	RestOwner       = "owner"
+	RestDelegator   = "delegator"
 	RestQueryType   = "query_type"
 )
 
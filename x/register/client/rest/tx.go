@@ -11,6 +11,7 @@ import (
 	"github.com/gorilla/mux"
 	stratos "github.com/stratosnet/stratos-chain/types"
 	"github.com/stratosnet/stratos-chain/x/register/types"
+	"github.com/tendermint/tendermint/crypto"
 )
 
 func registerTxRoutes(cliCtx context.CLIContext, r *mux.Router) {
@@ -51,16 +52,36 @@ func registerTxRoutes(cliCtx context.CLIContext, r *mux.Router) {
 		"/register/indexingNodeRegVote",
 		postIndexingNodeRegVoteFn(cliCtx),
 	).Methods("POST")
+
+	r.HandleFunc(
+		"/register/unsuspendNode",
+		postUnsuspendNodeHandlerFn(cliCtx),
+	).Methods("POST")
+
+	r.HandleFunc(
+		"/register/delegate",
+		postDelegateHandlerFn(cliCtx),
+	).Methods("POST")
+	r.HandleFunc(
+		"/register/undelegate",
+		postUndelegateHandlerFn(cliCtx),
+	).Methods("POST")
+	r.HandleFunc(
+		"/register/redelegateNodeStake",
+		postRedelegateNodeStakeHandlerFn(cliCtx),
+	).Methods("POST")
 }
 
 type (
 	CreateResourceNodeRequest struct {
-		BaseReq     rest.BaseReq      `json:"base_req" yaml:"base_req"`
-		NetworkAddr string            `json:"network_address" yaml:"network_address"`
-		PubKey      string            `json:"pubkey" yaml:"pubkey"` // in bech32
-		Amount      sdk.Coin          `json:"amount" yaml:"amount"`
-		Description types.Description `json:"description" yaml:"description"`
-		NodeType    int               `json:"node_type" yaml:"node_type"`
+		BaseReq       rest.BaseReq          `json:"base_req" yaml:"base_req"`
+		NetworkAddr   string                `json:"network_address" yaml:"network_address"`
+		PubKey        string                `json:"pubkey" yaml:"pubkey"` // in bech32
+		Amount        sdk.Coin              `json:"amount" yaml:"amount"`
+		Description   types.Description     `json:"description" yaml:"description"`
+		NodeType      int                   `json:"node_type" yaml:"node_type"`
+		Commission    types.CommissionRates `json:"commission" yaml:"commission"`
+		Beneficiaries []sdk.AccAddress      `json:"beneficiaries" yaml:"beneficiaries"`
 	}
 
 	RemoveResourceNodeRequest struct {
@@ -73,6 +94,10 @@ type (
 		Description    types.Description `json:"description" yaml:"description"`
 		NodeType       int               `json:"node_type" yaml:"node_type"`
 		NetworkAddress string            `json:"network_address" yaml:"network_address"`
+		CommissionRate *sdk.Dec          `json:"commission_rate" yaml:"commission_rate"`
+		Beneficiaries  []sdk.AccAddress  `json:"beneficiaries" yaml:"beneficiaries"`
+		// removes the current beneficiaries
+		ClearBeneficiaries bool `json:"clear_beneficiaries" yaml:"clear_beneficiaries"`
 	}
 
 	UpdateResourceNodeStakeRequest struct {
@@ -83,11 +108,15 @@ type (
 	}
 
 	CreateIndexingNodeRequest struct {
-		BaseReq     rest.BaseReq      `json:"base_req" yaml:"base_req"`
-		NetworkAddr string            `json:"network_address" yaml:"network_address"`
-		PubKey      string            `json:"pubkey" yaml:"pubkey"` // in bech32
-		Amount      sdk.Coin          `json:"amount" yaml:"amount"`
-		Description types.Description `json:"description" yaml:"description"`
+		BaseReq       rest.BaseReq          `json:"base_req" yaml:"base_req"`
+		NetworkAddr   string                `json:"network_address" yaml:"network_address"`
+		PubKey        string                `json:"pubkey" yaml:"pubkey"` // in bech32
+		Amount        sdk.Coin              `json:"amount" yaml:"amount"`
+		Description   types.Description     `json:"description" yaml:"description"`
+		BLSPubKey     []byte                `json:"bls_pub_key" yaml:"bls_pub_key"`
+		BLSPoP        []byte                `json:"bls_proof_of_possession" yaml:"bls_proof_of_possession"`
+		Commission    types.CommissionRates `json:"commission" yaml:"commission"`
+		Beneficiaries []sdk.AccAddress      `json:"beneficiaries" yaml:"beneficiaries"`
 	}
 
 	RemoveIndexingNodeRequest struct {
@@ -99,6 +128,12 @@ type (
 		BaseReq        rest.BaseReq      `json:"base_req" yaml:"base_req"`
 		Description    types.Description `json:"description" yaml:"description"`
 		NetworkAddress string            `json:"network_address" yaml:"network_address"`
+		BLSPubKey      []byte            `json:"bls_pub_key" yaml:"bls_pub_key"`
+		BLSPoP         []byte            `json:"bls_proof_of_possession" yaml:"bls_proof_of_possession"`
+		CommissionRate *sdk.Dec          `json:"commission_rate" yaml:"commission_rate"`
+		Beneficiaries  []sdk.AccAddress  `json:"beneficiaries" yaml:"beneficiaries"`
+		// removes the current beneficiaries
+		ClearBeneficiaries bool `json:"clear_beneficiaries" yaml:"clear_beneficiaries"`
 	}
 
 	UpdateIndexingNodeStakeRequest struct {
@@ -115,6 +150,28 @@ type (
 		Opinion                 bool         `json:"opinion" yaml:"opinion"`
 		VoterNetworkAddress     string       `json:"voter_network_address" yaml:"voter_network_address"`
 	}
+
+	UnsuspendNodeRequest struct {
+		BaseReq        rest.BaseReq `json:"base_req" yaml:"base_req"`
+		NetworkAddress string       `json:"network_address" yaml:"network_address"` // in bech32
+		IsIndexingNode bool         `json:"is_indexing_node" yaml:"is_indexing_node"`
+	}
+
+	DelegationRequest struct {
+		BaseReq        rest.BaseReq `json:"base_req" yaml:"base_req"`
+		NetworkAddress string       `json:"network_address" yaml:"network_address"` // in bech32
+		IsIndexingNode bool         `json:"is_indexing_node" yaml:"is_indexing_node"`
+		Amount         sdk.Coin     `json:"amount" yaml:"amount"`
+	}
+
+	RedelegateNodeStakeRequest struct {
+		BaseReq           rest.BaseReq `json:"base_req" yaml:"base_req"`
+		SrcNetworkAddress string       `json:"src_network_address" yaml:"src_network_address"` // in bech32
+		DstNetworkAddress string       `json:"dst_network_address" yaml:"dst_network_address"` // in bech32
+		IsIndexingNode    bool         `json:"is_indexing_node" yaml:"is_indexing_node"`
+		Amount            sdk.Coin     `json:"amount" yaml:"amount"`
+		DstPubKey         string       `json:"dst_pubkey" yaml:"dst_pubkey"` // in bech32, only for a new resource node
+	}
 )
 
 func postCreateResourceNodeHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
@@ -152,7 +209,7 @@ func postCreateResourceNodeHandlerFn(cliCtx context.CLIContext) http.HandlerFunc
 			return
 		}
 		msg := types.NewMsgCreateResourceNode(networkAddr, pubKey, req.Amount, ownerAddr, req.Description,
-			types.NodeType(nodeTypeRef))
+			types.NodeType(nodeTypeRef), req.Commission, req.Beneficiaries)
 		if err := msg.ValidateBasic(); err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
@@ -191,7 +248,8 @@ func postCreateIndexingNodeHandlerFn(cliCtx context.CLIContext) http.HandlerFunc
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
 		}
-		msg := types.NewMsgCreateIndexingNode(networkAddr, pubKey, req.Amount, ownerAddr, req.Description)
+		msg := types.NewMsgCreateIndexingNode(networkAddr, pubKey, req.Amount, ownerAddr, req.Description, req.BLSPubKey, req.BLSPoP,
+			req.Commission, req.Beneficiaries)
 		if err := msg.ValidateBasic(); err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
@@ -302,7 +360,7 @@ func postUpdateResourceNodeHandlerFn(cliCtx context.CLIContext) http.HandlerFunc
 			return
 		}
 		msg := types.NewMsgUpdateResourceNode(req.Description,
-			types.NodeType(nodeTypeRef), networkAddr, ownerAddr)
+			types.NodeType(nodeTypeRef), networkAddr, ownerAddr, req.CommissionRate, req.Beneficiaries, req.ClearBeneficiaries)
 		if err := msg.ValidateBasic(); err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
@@ -377,7 +435,8 @@ func postUpdateIndexingNodeHandlerFn(cliCtx context.CLIContext) http.HandlerFunc
 			return
 		}
 
-		msg := types.NewMsgUpdateIndexingNode(req.Description, networkAddr, ownerAddr)
+		msg := types.NewMsgUpdateIndexingNode(req.Description, networkAddr, ownerAddr, req.BLSPubKey, req.BLSPoP,
+			req.CommissionRate, req.Beneficiaries, req.ClearBeneficiaries)
 		if err := msg.ValidateBasic(); err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
@@ -473,3 +532,137 @@ func postIndexingNodeRegVoteFn(cliCtx context.CLIContext) http.HandlerFunc {
 		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
 	}
 }
+
+func postUnsuspendNodeHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req UnsuspendNodeRequest
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		nodeAddr, err := stratos.SdsAddressFromBech32(req.NetworkAddress)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		ownerAddr, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgUnsuspendNode(nodeAddr, ownerAddr, req.IsIndexingNode)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+func postDelegateHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return postDelegationHandlerFn(cliCtx, func(networkAddr stratos.SdsAddress, delegatorAddr sdk.AccAddress, req DelegationRequest) sdk.Msg {
+		return types.NewMsgDelegate(networkAddr, delegatorAddr, req.IsIndexingNode, req.Amount)
+	})
+}
+
+func postUndelegateHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return postDelegationHandlerFn(cliCtx, func(networkAddr stratos.SdsAddress, delegatorAddr sdk.AccAddress, req DelegationRequest) sdk.Msg {
+		return types.NewMsgUndelegate(networkAddr, delegatorAddr, req.IsIndexingNode, req.Amount)
+	})
+}
+
+func postDelegationHandlerFn(cliCtx context.CLIContext,
+	newMsg func(networkAddr stratos.SdsAddress, delegatorAddr sdk.AccAddress, req DelegationRequest) sdk.Msg) http.HandlerFunc {
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req DelegationRequest
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		nodeAddr, err := stratos.SdsAddressFromBech32(req.NetworkAddress)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		delegatorAddr, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := newMsg(nodeAddr, delegatorAddr, req)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+func postRedelegateNodeStakeHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req RedelegateNodeStakeRequest
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		srcNetworkAddr, err := stratos.SdsAddressFromBech32(req.SrcNetworkAddress)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		dstNetworkAddr, err := stratos.SdsAddressFromBech32(req.DstNetworkAddress)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		var dstPubKey crypto.PubKey
+		if req.DstPubKey != "" {
+			dstPubKey, err = stratos.GetPubKeyFromBech32(stratos.Bech32PubKeyTypeSdsP2PPub, req.DstPubKey)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		ownerAddr, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgRedelegateNodeStake(ownerAddr, srcNetworkAddr, dstNetworkAddr, req.IsIndexingNode, req.Amount, dstPubKey)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
@@ -22,6 +22,12 @@ func InitGenesis(ctx sdk.Context, keeper Keeper, data types.GenesisState) {
 		}
 		keeper.SetResourceNode(ctx, resourceNode)
 	}
+	if !data.ResourceNodeBondedToken.IsNil() {
+		resNodeBondedToken = data.ResourceNodeBondedToken
+	}
+	if !data.ResourceNodeNotBondedToken.IsNil() {
+		resNodeNotBondedToken = data.ResourceNodeNotBondedToken
+	}
 	keeper.SetResourceNodeBondedToken(ctx, sdk.NewCoin(keeper.BondDenom(ctx), resNodeBondedToken))
 	keeper.SetResourceNodeNotBondedToken(ctx, sdk.NewCoin(keeper.BondDenom(ctx), resNodeNotBondedToken))
 
@@ -36,24 +42,75 @@ func InitGenesis(ctx sdk.Context, keeper Keeper, data types.GenesisState) {
 		}
 		keeper.SetIndexingNode(ctx, indexingNode)
 	}
+	if !data.IndexingNodeBondedToken.IsNil() {
+		idxNodeBondedToken = data.IndexingNodeBondedToken
+	}
+	if !data.IndexingNodeNotBondedToken.IsNil() {
+		idxNodeNotBondedToken = data.IndexingNodeNotBondedToken
+	}
 	keeper.SetIndexingNodeBondedToken(ctx, sdk.NewCoin(keeper.BondDenom(ctx), idxNodeBondedToken))
 	keeper.SetIndexingNodeNotBondedToken(ctx, sdk.NewCoin(keeper.BondDenom(ctx), idxNodeNotBondedToken))
 
 	totalUnissuedPrepay := data.TotalUnissuedPrepay
 	initialUOzonePrice := sdk.ZeroDec()
 	initialUOzonePrice = initialUOzonePrice.Add(data.InitialUozPrice)
+	if !data.InitialGenesisStakeTotal.IsNil() {
+		initialStakeTotal = data.InitialGenesisStakeTotal
+	}
 	keeper.SetInitialGenesisStakeTotal(ctx, initialStakeTotal)
 	keeper.SetInitialUOzonePrice(ctx, initialUOzonePrice)
 	initOzoneLimit := initialStakeTotal.Add(totalUnissuedPrepay).ToDec().Quo(initialUOzonePrice).TruncateInt()
+	if !data.RemainingOzoneLimit.IsNil() {
+		initOzoneLimit = data.RemainingOzoneLimit
+	}
 	keeper.SetRemainingOzoneLimit(ctx, initOzoneLimit)
 	keeper.SetTotalUnissuedPrepay(ctx, sdk.Coin{
 		Denom:  data.Params.BondDenom,
 		Amount: totalUnissuedPrepay,
 	})
+	// the prepays are not part of the genesis, so the imported total unissued prepay is all preset
+	keeper.SetGenesisUnissuedPrepay(ctx, totalUnissuedPrepay)
 
 	for _, slashing := range data.SlashingInfo {
 		keeper.SetSlashing(ctx, slashing.WalletAddress, slashing.Value)
 	}
+
+	for _, ozoneBalance := range data.OzoneBalances {
+		keeper.SetOzoneBalance(ctx, ozoneBalance.WalletAddress, ozoneBalance.Balance)
+	}
+
+	for _, votePool := range data.VotePools {
+		keeper.SetIndexingNodeRegistrationVotePool(ctx, votePool)
+	}
+
+	for _, ubd := range data.UnbondingNodes {
+		keeper.SetUnbondingNode(ctx, ubd)
+		for _, entry := range ubd.Entries {
+			keeper.InsertUnbondingNodeQueue(ctx, ubd, entry.CompletionTime)
+		}
+	}
+
+	for _, suspendedTime := range data.NodeSuspendedTimes {
+		keeper.SetNodeSuspendedTime(ctx, suspendedTime.NetworkAddr, suspendedTime.SuspendedTime)
+		if !suspendedTime.SuspendedUntil.IsZero() {
+			keeper.SetNodeSuspendedUntil(ctx, suspendedTime.NetworkAddr, suspendedTime.SuspendedUntil)
+		}
+	}
+
+	for _, delegation := range data.Delegations {
+		keeper.SetDelegation(ctx, delegation)
+	}
+
+	for _, red := range data.Redelegations {
+		keeper.SetRedelegation(ctx, red)
+		for _, entry := range red.Entries {
+			keeper.InsertRedelegationQueue(ctx, red, entry.CompletionTime)
+		}
+	}
+
+	for _, snapshot := range data.UozSnapshots {
+		keeper.SetUozSnapshot(ctx, snapshot)
+	}
 }
 
 // ExportGenesis writes the current store values
@@ -65,7 +122,7 @@ func ExportGenesis(ctx sdk.Context, keeper Keeper) (data types.GenesisState) {
 	resourceNodes := keeper.GetAllResourceNodes(ctx)
 	indexingNodes := keeper.GetAllIndexingNodes(ctx)
 	totalUnissuedPrepay := keeper.GetTotalUnissuedPrepay(ctx).Amount
-	initialUOzonePrice := keeper.CurrUozPrice(ctx)
+	initialUOzonePrice := keeper.GetInitialUOzonePrice(ctx)
 
 	var slashingInfo []types.Slashing
 	keeper.IteratorSlashingInfo(ctx, func(walletAddress sdk.AccAddress, val sdk.Int) (stop bool) {
@@ -76,6 +133,12 @@ func ExportGenesis(ctx sdk.Context, keeper Keeper) (data types.GenesisState) {
 		return false
 	})
 
+	var ozoneBalances []types.OzoneBalance
+	keeper.IteratorOzoneBalances(ctx, func(walletAddress sdk.AccAddress, balance sdk.Int) (stop bool) {
+		ozoneBalances = append(ozoneBalances, types.NewOzoneBalance(walletAddress, balance))
+		return false
+	})
+
 	return types.GenesisState{
 		Params:              params,
 		ResourceNodes:       resourceNodes,
@@ -83,5 +146,19 @@ func ExportGenesis(ctx sdk.Context, keeper Keeper) (data types.GenesisState) {
 		InitialUozPrice:     initialUOzonePrice,
 		TotalUnissuedPrepay: totalUnissuedPrepay,
 		SlashingInfo:        slashingInfo,
+		VotePools:           keeper.GetAllIndexingNodeRegistrationVotePools(ctx),
+		UnbondingNodes:      keeper.GetAllUnbondingNodes(ctx),
+		NodeSuspendedTimes:  keeper.GetAllNodeSuspendedTimes(ctx),
+		OzoneBalances:       ozoneBalances,
+		Delegations:         keeper.GetAllDelegations(ctx),
+		Redelegations:       keeper.GetAllRedelegations(ctx),
+		UozSnapshots:        keeper.GetAllUozSnapshots(ctx),
+
+		ResourceNodeBondedToken:    keeper.GetResourceNodeBondedToken(ctx).Amount,
+		ResourceNodeNotBondedToken: keeper.GetResourceNodeNotBondedToken(ctx).Amount,
+		IndexingNodeBondedToken:    keeper.GetIndexingNodeBondedToken(ctx).Amount,
+		IndexingNodeNotBondedToken: keeper.GetIndexingNodeNotBondedToken(ctx).Amount,
+		InitialGenesisStakeTotal:   keeper.GetInitialGenesisStakeTotal(ctx),
+		RemainingOzoneLimit:        keeper.GetRemainingOzoneLimit(ctx),
 	}
 }
@@ -37,6 +37,14 @@ func NewHandler(k keeper.Keeper) sdk.Handler {
 			return handleMsgUpdateIndexingNodeStake(ctx, msg, k)
 		case types.MsgIndexingNodeRegistrationVote:
 			return handleMsgIndexingNodeRegistrationVote(ctx, msg, k)
+		case types.MsgUnsuspendNode:
+			return handleMsgUnsuspendNode(ctx, msg, k)
+		case types.MsgDelegate:
+			return handleMsgDelegate(ctx, msg, k)
+		case types.MsgUndelegate:
+			return handleMsgUndelegate(ctx, msg, k)
+		case types.MsgRedelegateNodeStake:
+			return handleMsgRedelegateNodeStake(ctx, msg, k)
 
 		// this line is used by starport scaffolding # 1
 		default:
@@ -56,7 +64,8 @@ func handleMsgCreateResourceNode(ctx sdk.Context, msg types.MsgCreateResourceNod
 		return nil, ErrBadDenom
 	}
 
-	ozoneLimitChange, err := k.RegisterResourceNode(ctx, msg.NetworkAddr, msg.PubKey, msg.OwnerAddress, msg.Description, msg.NodeType, msg.Value)
+	ozoneLimitChange, err := k.RegisterResourceNode(ctx, msg.NetworkAddr, msg.PubKey, msg.OwnerAddress, msg.Description, msg.NodeType, msg.Value,
+		msg.Commission, msg.Beneficiaries)
 	if err != nil {
 		return nil, err
 	}
@@ -89,7 +98,8 @@ func handleMsgCreateIndexingNode(ctx sdk.Context, msg types.MsgCreateIndexingNod
 		return nil, ErrBadDenom
 	}
 
-	ozoneLimitChange, err := k.RegisterIndexingNode(ctx, msg.NetworkAddr, msg.PubKey, msg.OwnerAddress, msg.Description, msg.Value)
+	ozoneLimitChange, err := k.RegisterIndexingNode(ctx, msg.NetworkAddr, msg.PubKey, msg.OwnerAddress, msg.Description, msg.Value, msg.BLSPubKey,
+		msg.Commission, msg.Beneficiaries)
 	if err != nil {
 		return nil, err
 	}
@@ -119,7 +129,7 @@ func handleMsgRemoveResourceNode(ctx sdk.Context, msg types.MsgRemoveResourceNod
 		return nil, types.ErrUnbondingNode
 	}
 
-	ozoneLimitChange, completionTime, err := k.UnbondResourceNode(ctx, resourceNode, resourceNode.Tokens)
+	ozoneLimitChange, completionTime, err := k.RemoveResourceNode(ctx, resourceNode)
 	if err != nil {
 		return nil, err
 	}
@@ -154,7 +164,7 @@ func handleMsgRemoveIndexingNode(ctx sdk.Context, msg types.MsgRemoveIndexingNod
 		return nil, types.ErrUnbondingNode
 	}
 
-	ozoneLimitChange, completionTime, err := k.UnbondIndexingNode(ctx, indexingNode, indexingNode.Tokens)
+	ozoneLimitChange, completionTime, err := k.RemoveIndexingNode(ctx, indexingNode)
 	if err != nil {
 		return nil, err
 	}
@@ -219,7 +229,8 @@ func handleMsgIndexingNodeRegistrationVote(ctx sdk.Context, msg types.MsgIndexin
 }
 
 func handleMsgUpdateResourceNode(ctx sdk.Context, msg types.MsgUpdateResourceNode, k keeper.Keeper) (*sdk.Result, error) {
-	err := k.UpdateResourceNode(ctx, msg.Description, msg.NodeType, msg.NetworkAddress, msg.OwnerAddress)
+	err := k.UpdateResourceNode(ctx, msg.Description, msg.NodeType, msg.NetworkAddress, msg.OwnerAddress,
+		msg.CommissionRate, msg.Beneficiaries, msg.ClearBeneficiaries)
 	if err != nil {
 		return nil, err
 	}
@@ -264,7 +275,8 @@ func handleMsgUpdateResourceNodeStake(ctx sdk.Context, msg types.MsgUpdateResour
 }
 
 func handleMsgUpdateIndexingNode(ctx sdk.Context, msg types.MsgUpdateIndexingNode, k keeper.Keeper) (*sdk.Result, error) {
-	err := k.UpdateIndexingNode(ctx, msg.Description, msg.NetworkAddress, msg.OwnerAddress)
+	err := k.UpdateIndexingNode(ctx, msg.Description, msg.NetworkAddress, msg.OwnerAddress, msg.BLSPubKey,
+		msg.CommissionRate, msg.Beneficiaries, msg.ClearBeneficiaries)
 	if err != nil {
 		return nil, err
 	}
@@ -306,3 +318,100 @@ func handleMsgUpdateIndexingNodeStake(ctx sdk.Context, msg types.MsgUpdateIndexi
 	})
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
 }
+
+func handleMsgUnsuspendNode(ctx sdk.Context, msg types.MsgUnsuspendNode, k keeper.Keeper) (*sdk.Result, error) {
+	if err := k.UnsuspendNode(ctx, msg.NetworkAddress, msg.OwnerAddress, msg.IsIndexingNode); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeUnsuspendNode,
+			sdk.NewAttribute(types.AttributeKeyNetworkAddress, msg.NetworkAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyIsIndexingNode, strconv.FormatBool(msg.IsIndexingNode)),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.OwnerAddress.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgDelegate(ctx sdk.Context, msg types.MsgDelegate, k keeper.Keeper) (*sdk.Result, error) {
+	ozoneLimitChange, err := k.Delegate(ctx, msg.DelegatorAddress, msg.NetworkAddress, msg.IsIndexingNode, msg.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeDelegate,
+			sdk.NewAttribute(types.AttributeKeyDelegator, msg.DelegatorAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyNetworkAddress, msg.NetworkAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyIsIndexingNode, strconv.FormatBool(msg.IsIndexingNode)),
+			sdk.NewAttribute(types.AttributeKeyAmount, msg.Amount.Amount.String()),
+			sdk.NewAttribute(types.AttributeKeyOZoneLimitChanges, ozoneLimitChange.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.DelegatorAddress.String()),
+		),
+	})
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgUndelegate(ctx sdk.Context, msg types.MsgUndelegate, k keeper.Keeper) (*sdk.Result, error) {
+	ozoneLimitChange, completionTime, err := k.Undelegate(ctx, msg.DelegatorAddress, msg.NetworkAddress, msg.IsIndexingNode, msg.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	completionTimeBz := types.ModuleCdc.MustMarshalBinaryLengthPrefixed(completionTime)
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeUndelegate,
+			sdk.NewAttribute(types.AttributeKeyDelegator, msg.DelegatorAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyNetworkAddress, msg.NetworkAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyIsIndexingNode, strconv.FormatBool(msg.IsIndexingNode)),
+			sdk.NewAttribute(types.AttributeKeyAmount, msg.Amount.Amount.String()),
+			sdk.NewAttribute(types.AttributeKeyOZoneLimitChanges, ozoneLimitChange.Neg().String()),
+			sdk.NewAttribute(types.AttributeKeyUnbondingMatureTime, completionTime.Format(time.RFC3339)),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.DelegatorAddress.String()),
+		),
+	})
+	return &sdk.Result{Data: completionTimeBz, Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgRedelegateNodeStake(ctx sdk.Context, msg types.MsgRedelegateNodeStake, k keeper.Keeper) (*sdk.Result, error) {
+	completionTime, err := k.RedelegateNodeStake(ctx, msg.OwnerAddress, msg.SrcNetworkAddress, msg.DstNetworkAddress,
+		msg.IsIndexingNode, msg.Amount, msg.DstPubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	completionTimeBz := types.ModuleCdc.MustMarshalBinaryLengthPrefixed(completionTime)
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeRedelegateNodeStake,
+			sdk.NewAttribute(types.AttributeKeySrcNetworkAddress, msg.SrcNetworkAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyDstNetworkAddress, msg.DstNetworkAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyIsIndexingNode, strconv.FormatBool(msg.IsIndexingNode)),
+			sdk.NewAttribute(types.AttributeKeyAmount, msg.Amount.Amount.String()),
+			sdk.NewAttribute(types.AttributeKeyCompletionTime, completionTime.Format(time.RFC3339)),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.OwnerAddress.String()),
+		),
+	})
+	return &sdk.Result{Data: completionTimeBz, Events: ctx.EventManager().Events()}, nil
+}
@@ -0,0 +1,191 @@
+package keeper
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	stratos "github.com/stratosnet/stratos-chain/types"
+	"github.com/stratosnet/stratos-chain/x/register/types"
+)
+
+// GetDelegation returns the delegation of the delegator to the node
+func (k Keeper) GetDelegation(ctx sdk.Context, networkAddr stratos.SdsAddress, delegatorAddr sdk.AccAddress) (delegation types.Delegation, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetDelegationKey(networkAddr, delegatorAddr))
+	if bz == nil {
+		return delegation, false
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &delegation)
+	return delegation, true
+}
+
+// SetDelegation stores the delegation, or removes it once nothing is delegated anymore
+func (k Keeper) SetDelegation(ctx sdk.Context, delegation types.Delegation) {
+	store := ctx.KVStore(k.storeKey)
+	key := types.GetDelegationKey(delegation.NetworkAddr, delegation.DelegatorAddress)
+	if !delegation.Amount.IsPositive() {
+		store.Delete(key)
+		return
+	}
+	store.Set(key, k.cdc.MustMarshalBinaryLengthPrefixed(delegation))
+}
+
+// IterateDelegations iterates through all the delegations
+func (k Keeper) IterateDelegations(ctx sdk.Context, handler func(delegation types.Delegation) (stop bool)) {
+	k.iterateDelegations(ctx, types.DelegationKey, handler)
+}
+
+// IterateDelegationsByNode iterates through all the delegations to the node
+func (k Keeper) IterateDelegationsByNode(ctx sdk.Context, networkAddr stratos.SdsAddress, handler func(delegation types.Delegation) (stop bool)) {
+	k.iterateDelegations(ctx, types.GetDelegationsByNodeKey(networkAddr), handler)
+}
+
+func (k Keeper) iterateDelegations(ctx sdk.Context, prefix []byte, handler func(delegation types.Delegation) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, prefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var delegation types.Delegation
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &delegation)
+		if handler(delegation) {
+			break
+		}
+	}
+}
+
+// GetAllDelegations returns all the delegations, used during genesis dump
+func (k Keeper) GetAllDelegations(ctx sdk.Context) (delegations types.Delegations) {
+	k.IterateDelegations(ctx, func(delegation types.Delegation) (stop bool) {
+		delegations = append(delegations, delegation)
+		return false
+	})
+	return delegations
+}
+
+// GetNodeDelegations returns all the delegations to the node
+func (k Keeper) GetNodeDelegations(ctx sdk.Context, networkAddr stratos.SdsAddress) (delegations types.Delegations) {
+	k.IterateDelegationsByNode(ctx, networkAddr, func(delegation types.Delegation) (stop bool) {
+		delegations = append(delegations, delegation)
+		return false
+	})
+	return delegations
+}
+
+// GetDelegatorDelegations returns all the delegations of the delegator
+func (k Keeper) GetDelegatorDelegations(ctx sdk.Context, delegatorAddr sdk.AccAddress) (delegations types.Delegations) {
+	k.IterateDelegations(ctx, func(delegation types.Delegation) (stop bool) {
+		if delegation.DelegatorAddress.Equals(delegatorAddr) {
+			delegations = append(delegations, delegation)
+		}
+		return false
+	})
+	return delegations
+}
+
+// GetOwnerBondedTokens returns the part of the node tokens the owner can unbond or move,
+// i.e. without the delegations and the stake already in the unbonding queue
+func (k Keeper) GetOwnerBondedTokens(ctx sdk.Context, networkAddr stratos.SdsAddress, tokens sdk.Int) sdk.Int {
+	return tokens.Sub(k.GetNodeDelegations(ctx, networkAddr).TotalAmount()).Sub(k.GetUnbondingNodeBalance(ctx, networkAddr))
+}
+
+// Delegate adds the tokens of the delegator to the stake of a bonded node
+func (k Keeper) Delegate(ctx sdk.Context, delegatorAddr sdk.AccAddress, networkAddr stratos.SdsAddress, isIndexingNode bool,
+	amount sdk.Coin) (ozoneLimitChange sdk.Int, err error) {
+
+	if amount.Denom != k.BondDenom(ctx) {
+		return sdk.ZeroInt(), types.ErrBadDenom
+	}
+
+	if isIndexingNode {
+		node, found := k.GetIndexingNode(ctx, networkAddr)
+		if !found {
+			return sdk.ZeroInt(), types.ErrNoIndexingNodeFound
+		}
+		if node.GetOwnerAddr().Equals(delegatorAddr) {
+			return sdk.ZeroInt(), types.ErrSelfDelegation
+		}
+		if node.GetStatus() != sdk.Bonded {
+			return sdk.ZeroInt(), types.ErrNodeNotBonded
+		}
+		ozoneLimitChange, err = k.addIndexingNodeStake(ctx, node, amount, delegatorAddr)
+	} else {
+		node, found := k.GetResourceNode(ctx, networkAddr)
+		if !found {
+			return sdk.ZeroInt(), types.ErrNoResourceNodeFound
+		}
+		if node.GetOwnerAddr().Equals(delegatorAddr) {
+			return sdk.ZeroInt(), types.ErrSelfDelegation
+		}
+		if node.GetStatus() != sdk.Bonded {
+			return sdk.ZeroInt(), types.ErrNodeNotBonded
+		}
+		ozoneLimitChange, err = k.addResourceNodeStake(ctx, node, amount, delegatorAddr)
+	}
+	if err != nil {
+		return sdk.ZeroInt(), err
+	}
+
+	delegation, found := k.GetDelegation(ctx, networkAddr, delegatorAddr)
+	if !found {
+		delegation = types.NewDelegation(delegatorAddr, networkAddr, isIndexingNode, sdk.ZeroInt())
+	}
+	delegation.Amount = delegation.Amount.Add(amount.Amount)
+	k.SetDelegation(ctx, delegation)
+	return ozoneLimitChange, nil
+}
+
+// Undelegate moves the delegated tokens to the unbonding queue of the node. They are paid back to the delegator once mature.
+func (k Keeper) Undelegate(ctx sdk.Context, delegatorAddr sdk.AccAddress, networkAddr stratos.SdsAddress, isIndexingNode bool,
+	amount sdk.Coin) (ozoneLimitChange sdk.Int, unbondingMatureTime time.Time, err error) {
+
+	blockTime := ctx.BlockHeader().Time
+	if amount.Denom != k.BondDenom(ctx) {
+		return sdk.ZeroInt(), blockTime, types.ErrBadDenom
+	}
+
+	delegation, found := k.GetDelegation(ctx, networkAddr, delegatorAddr)
+	if !found || delegation.IsIndexingNode != isIndexingNode {
+		return sdk.ZeroInt(), blockTime, types.ErrNoDelegationFound
+	}
+	if delegation.Amount.LT(amount.Amount) {
+		return sdk.ZeroInt(), blockTime, types.ErrInsufficientDelegation
+	}
+	if k.HasMaxUnbondingNodeEntries(ctx, networkAddr) {
+		return sdk.ZeroInt(), blockTime, types.ErrMaxUnbondingNodeEntries
+	}
+
+	if isIndexingNode {
+		node, found := k.GetIndexingNode(ctx, networkAddr)
+		if !found {
+			return sdk.ZeroInt(), blockTime, types.ErrNoIndexingNodeFound
+		}
+		if node.GetStatus() == sdk.Unbonding {
+			return sdk.ZeroInt(), blockTime, types.ErrUnbondingNode
+		}
+		ozoneLimitChange, unbondingMatureTime, err = k.unbondIndexingNode(ctx, node, amount.Amount, delegatorAddr)
+	} else {
+		node, found := k.GetResourceNode(ctx, networkAddr)
+		if !found {
+			return sdk.ZeroInt(), blockTime, types.ErrNoResourceNodeFound
+		}
+		if node.GetStatus() == sdk.Unbonding {
+			return sdk.ZeroInt(), blockTime, types.ErrUnbondingNode
+		}
+		ozoneLimitChange, unbondingMatureTime, err = k.unbondResourceNode(ctx, node, amount.Amount, delegatorAddr)
+	}
+	if err != nil {
+		return sdk.ZeroInt(), blockTime, err
+	}
+
+	delegation.Amount = delegation.Amount.Sub(amount.Amount)
+	k.SetDelegation(ctx, delegation)
+	return ozoneLimitChange, unbondingMatureTime, nil
+}
+
+// slashDelegations reduces the delegations to the node by the slashed fraction
+func (k Keeper) slashDelegations(ctx sdk.Context, networkAddr stratos.SdsAddress, fraction sdk.Dec) {
+	for _, delegation := range k.GetNodeDelegations(ctx, networkAddr) {
+		delegation.Amount = delegation.Amount.Sub(delegation.Amount.ToDec().Mul(fraction).TruncateInt())
+		k.SetDelegation(ctx, delegation)
+	}
+}
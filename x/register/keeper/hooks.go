@@ -43,3 +43,10 @@ func (k Keeper) AfterNodeBeginUnbonding(ctx sdk.Context, networkAddr stratos.Sds
 		k.hooks.AfterNodeBeginUnbonding(ctx, networkAddr, isIndexingNode)
 	}
 }
+
+// AfterNodeUnsuspended - call hook if registered
+func (k Keeper) AfterNodeUnsuspended(ctx sdk.Context, networkAddr stratos.SdsAddress, isIndexingNode bool) {
+	if k.hooks != nil {
+		k.hooks.AfterNodeUnsuspended(ctx, networkAddr, isIndexingNode)
+	}
+}
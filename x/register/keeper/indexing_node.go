@@ -1,6 +1,7 @@
 package keeper
 
 import (
+	"bytes"
 	"strings"
 	"time"
 
@@ -96,10 +97,34 @@ func (k Keeper) GetAllValidIndexingNodes(ctx sdk.Context) (indexingNodes []types
 	return indexingNodes
 }
 
+// GetIndexingNodeByBLSPubKey returns the indexing node which registered the given BLS public key
+func (k Keeper) GetIndexingNodeByBLSPubKey(ctx sdk.Context, blsPubKey []byte) (indexingNode types.IndexingNode, found bool) {
+	if len(blsPubKey) == 0 {
+		return indexingNode, false
+	}
+	for _, node := range k.GetAllIndexingNodes(ctx) {
+		if bytes.Equal(node.BLSPubKey, blsPubKey) {
+			return node, true
+		}
+	}
+	return indexingNode, false
+}
+
 func (k Keeper) RegisterIndexingNode(ctx sdk.Context, networkAddr stratos.SdsAddress, pubKey crypto.PubKey, ownerAddr sdk.AccAddress,
-	description types.Description, stake sdk.Coin) (ozoneLimitChange sdk.Int, err error) {
+	description types.Description, stake sdk.Coin, blsPubKey []byte, commission types.CommissionRates,
+	beneficiaries []sdk.AccAddress) (ozoneLimitChange sdk.Int, err error) {
+
+	if _, found := k.GetIndexingNodeByBLSPubKey(ctx, blsPubKey); found {
+		return sdk.ZeroInt(), types.ErrBLSPubKeyExists
+	}
 
 	indexingNode := types.NewIndexingNode(networkAddr, pubKey, ownerAddr, description, ctx.BlockHeader().Time)
+	indexingNode.BLSPubKey = blsPubKey
+	// empty rates keep the default commission, i.e. the owner keeps all the rewards
+	if !commission.IsEmpty() {
+		indexingNode.Commission = types.NewCommission(commission, ctx.BlockHeader().Time)
+	}
+	indexingNode.Beneficiaries = beneficiaries
 
 	ozoneLimitChange, err = k.AddIndexingNodeStake(ctx, indexingNode, stake)
 	if err != nil {
@@ -119,16 +144,22 @@ func (k Keeper) RegisterIndexingNode(ctx sdk.Context, networkAddr stratos.SdsAdd
 
 // AddIndexingNodeStake Update the tokens of an existing indexing node
 func (k Keeper) AddIndexingNodeStake(ctx sdk.Context, indexingNode types.IndexingNode, tokenToAdd sdk.Coin,
+) (ozoneLimitChange sdk.Int, err error) {
+	return k.addIndexingNodeStake(ctx, indexingNode, tokenToAdd, indexingNode.GetOwnerAddr())
+}
+
+// addIndexingNodeStake adds the tokens paid by the payer, either the owner or a delegator, to the stake of the indexing node
+func (k Keeper) addIndexingNodeStake(ctx sdk.Context, indexingNode types.IndexingNode, tokenToAdd sdk.Coin, payer sdk.AccAddress,
 ) (ozoneLimitChange sdk.Int, err error) {
 
 	coins := sdk.NewCoins(tokenToAdd)
 
-	// sub coins from owner's wallet
-	hasCoin := k.bankKeeper.HasCoins(ctx, indexingNode.OwnerAddress, coins)
+	// sub coins from payer's wallet
+	hasCoin := k.bankKeeper.HasCoins(ctx, payer, coins)
 	if !hasCoin {
 		return sdk.ZeroInt(), types.ErrInsufficientBalance
 	}
-	_, err = k.bankKeeper.SubtractCoins(ctx, indexingNode.GetOwnerAddr(), coins)
+	_, err = k.bankKeeper.SubtractCoins(ctx, payer, coins)
 	if err != nil {
 		return sdk.ZeroInt(), err
 	}
@@ -172,8 +203,14 @@ func (k Keeper) RemoveTokenFromPoolWhileUnbondingIndexingNode(ctx sdk.Context, i
 
 // SubtractIndexingNodeStake Update the tokens of an existing indexing node
 func (k Keeper) SubtractIndexingNodeStake(ctx sdk.Context, indexingNode types.IndexingNode, tokenToSub sdk.Coin) error {
-	ownerAcc := k.accountKeeper.GetAccount(ctx, indexingNode.OwnerAddress)
-	if ownerAcc == nil {
+	return k.subtractIndexingNodeStake(ctx, indexingNode, tokenToSub, indexingNode.GetOwnerAddr())
+}
+
+// subtractIndexingNodeStake removes the tokens from the stake of the indexing node and pays them to the recipient,
+// either the owner or a delegator
+func (k Keeper) subtractIndexingNodeStake(ctx sdk.Context, indexingNode types.IndexingNode, tokenToSub sdk.Coin, recipient sdk.AccAddress) error {
+	recipientAcc := k.accountKeeper.GetAccount(ctx, recipient)
+	if recipientAcc == nil {
 		return types.ErrNoOwnerAccountFound
 	}
 
@@ -192,9 +229,9 @@ func (k Keeper) SubtractIndexingNodeStake(ctx sdk.Context, indexingNode types.In
 	k.SetIndexingNodeNotBondedToken(ctx, notBondedTokenInPool)
 
 	// deduct slashing amount first
-	coins = k.DeductSlashing(ctx, indexingNode.OwnerAddress, coins)
-	// add tokens to owner acc
-	_, err := k.bankKeeper.AddCoins(ctx, indexingNode.OwnerAddress, coins)
+	coins = k.DeductSlashing(ctx, recipient, coins)
+	// add tokens to recipient acc
+	_, err := k.bankKeeper.AddCoins(ctx, recipient, coins)
 	if err != nil {
 		return err
 	}
@@ -335,8 +372,80 @@ func (k Keeper) SetIndexingNodeRegistrationVotePool(ctx sdk.Context, votePool ty
 	store.Set(types.GetIndexingNodeRegistrationVotesKey(nodeAddr), bz)
 }
 
+func (k Keeper) DeleteIndexingNodeRegistrationVotePool(ctx sdk.Context, nodeAddr stratos.SdsAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetIndexingNodeRegistrationVotesKey(nodeAddr))
+}
+
+// GetAllIndexingNodeRegistrationVotePools get the set of all registration vote pools, used during genesis dump
+func (k Keeper) GetAllIndexingNodeRegistrationVotePools(ctx sdk.Context) (votePools []types.IndexingNodeRegistrationVotePool) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.IndexingNodeRegistrationVotesKey)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var votePool types.IndexingNodeRegistrationVotePool
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &votePool)
+		votePools = append(votePools, votePool)
+	}
+	return votePools
+}
+
+/*
+	RemoveExpiredRegistrationVotePools is called in EndBlocker.
+
+	The vote pools whose expire time has passed are deleted. If the candidate indexing node
+	has not been approved before the expiration, its stake is refunded from the not bonded pool
+	to its owner (after deducting the slashing) and the node is removed.
+*/
+func (k Keeper) RemoveExpiredRegistrationVotePools(ctx sdk.Context) {
+	for _, votePool := range k.GetAllIndexingNodeRegistrationVotePools(ctx) {
+		if !votePool.ExpireTime.Before(ctx.BlockHeader().Time) {
+			continue
+		}
+		k.DeleteIndexingNodeRegistrationVotePool(ctx, votePool.NodeAddress)
+
+		refund := sdk.ZeroInt()
+		node, found := k.GetIndexingNode(ctx, votePool.NodeAddress)
+		if found && node.GetStatus() == sdk.Unbonded && node.GetTokens().IsPositive() {
+			// refund with a cached context so that a failed refund leaves the node untouched
+			cacheCtx, write := ctx.CacheContext()
+			err := k.refundIndexingNodeStake(cacheCtx, node)
+			if err != nil {
+				k.Logger(ctx).Error("failed to refund the stake of expired indexing node registration",
+					"node", votePool.NodeAddress.String(), "err", err.Error())
+			} else {
+				write()
+				refund = node.GetTokens()
+			}
+		}
+
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				types.EventTypeExpireIndexingNodeRegVote,
+				sdk.NewAttribute(types.AttributeKeyNetworkAddress, votePool.NodeAddress.String()),
+				sdk.NewAttribute(sdk.AttributeKeyAmount, refund.String()),
+			),
+		)
+	}
+}
+
+// refundIndexingNodeStake returns all the stake of an unbonded indexing node to its owner and removes the node
+func (k Keeper) refundIndexingNodeStake(ctx sdk.Context, indexingNode types.IndexingNode) error {
+	stake := indexingNode.GetTokens()
+	// the ozone limit was increased by the stake when the node registered
+	k.decreaseOzoneLimitBySubtractStake(ctx, stake)
+	err := k.SubtractIndexingNodeStake(ctx, indexingNode, sdk.NewCoin(k.BondDenom(ctx), stake))
+	if err != nil {
+		return err
+	}
+	k.AfterNodeRemoved(ctx, indexingNode.GetNetworkAddr(), true)
+	return nil
+}
+
 func (k Keeper) UpdateIndexingNode(ctx sdk.Context, description types.Description,
-	networkAddr stratos.SdsAddress, ownerAddr sdk.AccAddress) error {
+	networkAddr stratos.SdsAddress, ownerAddr sdk.AccAddress, blsPubKey []byte, commissionRate *sdk.Dec,
+	beneficiaries []sdk.AccAddress, clearBeneficiaries bool) error {
 
 	node, found := k.GetIndexingNode(ctx, networkAddr)
 	if !found {
@@ -349,6 +458,30 @@ func (k Keeper) UpdateIndexingNode(ctx sdk.Context, description types.Descriptio
 
 	node.Description = description
 
+	// an empty BLS public key keeps the current one
+	if len(blsPubKey) > 0 {
+		existing, found := k.GetIndexingNodeByBLSPubKey(ctx, blsPubKey)
+		if found && !existing.GetNetworkAddr().Equals(networkAddr) {
+			return types.ErrBLSPubKeyExists
+		}
+		node.BLSPubKey = blsPubKey
+	}
+
+	// a nil commission rate keeps the current one
+	if commissionRate != nil {
+		commission, err := node.Commission.UpdateRate(*commissionRate, ctx.BlockHeader().Time)
+		if err != nil {
+			return err
+		}
+		node.Commission = commission
+	}
+	// empty beneficiaries keep the current ones, unless they are cleared
+	if clearBeneficiaries {
+		node.Beneficiaries = nil
+	} else if len(beneficiaries) > 0 {
+		node.Beneficiaries = beneficiaries
+	}
+
 	k.SetIndexingNode(ctx, node)
 
 	return nil
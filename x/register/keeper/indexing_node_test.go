@@ -7,6 +7,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/stratosnet/stratos-chain/crypto/bls"
 	stratos "github.com/stratosnet/stratos-chain/types"
 	"github.com/stratosnet/stratos-chain/x/register/types"
 	"github.com/stretchr/testify/require"
@@ -39,6 +40,8 @@ var (
 	spNodePubKeyNew = ed25519.GenPrivKey().PubKey()
 	spNodeAddrNew   = stratos.SdsAddress(spNodePubKeyNew.Address())
 	spNodeStakeNew  = sdk.NewInt(100000000)
+
+	spNodeBLSPubKeyNew, _ = bls.GenPubKeyAndProof()
 )
 
 func TestExpiredVote(t *testing.T) {
@@ -58,22 +61,23 @@ func TestExpiredVote(t *testing.T) {
 	genesisSpNode2.Status = sdk.Bonded
 	genesisSpNode3.Status = sdk.Bonded
 	genesisSpNode4.Status = sdk.Bonded
+	genesisSpNode1.Suspend = false
+	genesisSpNode2.Suspend = false
+	genesisSpNode3.Suspend = false
+	genesisSpNode4.Suspend = false
 
 	k.SetIndexingNode(ctx, genesisSpNode1)
 	k.SetIndexingNode(ctx, genesisSpNode2)
 	k.SetIndexingNode(ctx, genesisSpNode3)
 	k.SetIndexingNode(ctx, genesisSpNode4)
-	k.SetLastIndexingNodeStake(ctx, spNodeAddr1, initialStake1)
-	k.SetLastIndexingNodeStake(ctx, spNodeAddr2, initialStake2)
-	k.SetLastIndexingNodeStake(ctx, spNodeAddr3, initialStake3)
-	k.SetLastIndexingNodeStake(ctx, spNodeAddr4, initialStake4)
 	k.SetIndexingNodeBondedToken(ctx, sdk.NewCoin(k.BondDenom(ctx), initialStake1.Add(initialStake2).Add(initialStake3).Add(initialStake4)))
 	k.SetInitialGenesisStakeTotal(ctx, initialStake1.Add(initialStake2).Add(initialStake3).Add(initialStake4))
 
 	//Register new SP node after genesis initialized
 	createAccount(t, ctx, accountKeeper, bankKeeper, spNodeOwnerNew, sdk.NewCoins(sdk.NewCoin("ustos", spNodeStakeNew)))
 	_, err := k.RegisterIndexingNode(ctx, spNodeAddrNew, spNodePubKeyNew, spNodeOwnerNew,
-		types.NewDescription("sds://newIndexingNode", "", "", "", ""), sdk.NewCoin("ustos", spNodeStakeNew))
+		types.NewDescription("sds://newIndexingNode", "", "", "", ""), sdk.NewCoin("ustos", spNodeStakeNew), spNodeBLSPubKeyNew,
+		types.CommissionRates{}, nil)
 	require.NoError(t, err)
 
 	//set expireTime of voting to 7 days before
@@ -106,22 +110,23 @@ func TestDuplicateVote(t *testing.T) {
 	genesisSpNode2.Status = sdk.Bonded
 	genesisSpNode3.Status = sdk.Bonded
 	genesisSpNode4.Status = sdk.Bonded
+	genesisSpNode1.Suspend = false
+	genesisSpNode2.Suspend = false
+	genesisSpNode3.Suspend = false
+	genesisSpNode4.Suspend = false
 
 	k.SetIndexingNode(ctx, genesisSpNode1)
 	k.SetIndexingNode(ctx, genesisSpNode2)
 	k.SetIndexingNode(ctx, genesisSpNode3)
 	k.SetIndexingNode(ctx, genesisSpNode4)
-	k.SetLastIndexingNodeStake(ctx, spNodeAddr1, initialStake1)
-	k.SetLastIndexingNodeStake(ctx, spNodeAddr2, initialStake2)
-	k.SetLastIndexingNodeStake(ctx, spNodeAddr3, initialStake3)
-	k.SetLastIndexingNodeStake(ctx, spNodeAddr4, initialStake4)
 	k.SetIndexingNodeBondedToken(ctx, sdk.NewCoin(k.BondDenom(ctx), initialStake1.Add(initialStake2).Add(initialStake3).Add(initialStake4)))
 	k.SetInitialGenesisStakeTotal(ctx, initialStake1.Add(initialStake2).Add(initialStake3).Add(initialStake4))
 
 	//Register new SP node after genesis initialized
 	createAccount(t, ctx, accountKeeper, bankKeeper, spNodeOwnerNew, sdk.NewCoins(sdk.NewCoin("ustos", spNodeStakeNew)))
 	_, err := k.RegisterIndexingNode(ctx, spNodeAddrNew, spNodePubKeyNew, spNodeOwnerNew,
-		types.NewDescription("sds://newIndexingNode", "", "", "", ""), sdk.NewCoin("ustos", spNodeStakeNew))
+		types.NewDescription("sds://newIndexingNode", "", "", "", ""), sdk.NewCoin("ustos", spNodeStakeNew), spNodeBLSPubKeyNew,
+		types.CommissionRates{}, nil)
 	require.NoError(t, err)
 
 	//After registration, the status of new SP node is UNBONDED
@@ -158,15 +163,15 @@ func TestSpRegistrationApproval(t *testing.T) {
 	genesisSpNode2.Status = sdk.Bonded
 	genesisSpNode3.Status = sdk.Bonded
 	genesisSpNode4.Status = sdk.Bonded
+	genesisSpNode1.Suspend = false
+	genesisSpNode2.Suspend = false
+	genesisSpNode3.Suspend = false
+	genesisSpNode4.Suspend = false
 
 	k.SetIndexingNode(ctx, genesisSpNode1)
 	k.SetIndexingNode(ctx, genesisSpNode2)
 	k.SetIndexingNode(ctx, genesisSpNode3)
 	k.SetIndexingNode(ctx, genesisSpNode4)
-	k.SetLastIndexingNodeStake(ctx, spNodeAddr1, initialStake1)
-	k.SetLastIndexingNodeStake(ctx, spNodeAddr2, initialStake2)
-	k.SetLastIndexingNodeStake(ctx, spNodeAddr3, initialStake3)
-	k.SetLastIndexingNodeStake(ctx, spNodeAddr4, initialStake4)
 	k.SetIndexingNodeBondedToken(ctx, sdk.NewCoin(k.BondDenom(ctx), initialStake1.Add(initialStake2).Add(initialStake3).Add(initialStake4)))
 	k.SetInitialGenesisStakeTotal(ctx, initialStake1.Add(initialStake2).Add(initialStake3).Add(initialStake4))
 
@@ -176,7 +181,8 @@ func TestSpRegistrationApproval(t *testing.T) {
 	//require.NoError(t, err)
 
 	_, err := k.RegisterIndexingNode(ctx, spNodeAddrNew, spNodePubKeyNew, spNodeOwnerNew,
-		types.NewDescription("sds://newIndexingNode", "", "", "", ""), sdk.NewCoin("ustos", spNodeStakeNew))
+		types.NewDescription("sds://newIndexingNode", "", "", "", ""), sdk.NewCoin("ustos", spNodeStakeNew), spNodeBLSPubKeyNew,
+		types.CommissionRates{}, nil)
 	require.NoError(t, err)
 
 	//After registration, the status of new SP node is UNBONDED
@@ -0,0 +1,116 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/stratosnet/stratos-chain/x/register/types"
+)
+
+// RegisterInvariants registers all register invariants
+func RegisterInvariants(ir sdk.InvariantRegistry, k Keeper) {
+	ir.RegisterRoute(types.ModuleName, "resource-node-pools",
+		ResourceNodePoolsInvariant(k))
+	ir.RegisterRoute(types.ModuleName, "indexing-node-pools",
+		IndexingNodePoolsInvariant(k))
+	ir.RegisterRoute(types.ModuleName, "nonnegative-ozone-limit",
+		NonNegativeOzoneLimitInvariant(k))
+}
+
+// AllInvariants runs all invariants of the register module.
+func AllInvariants(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		res, stop := ResourceNodePoolsInvariant(k)(ctx)
+		if stop {
+			return res, stop
+		}
+
+		res, stop = IndexingNodePoolsInvariant(k)(ctx)
+		if stop {
+			return res, stop
+		}
+
+		return NonNegativeOzoneLimitInvariant(k)(ctx)
+	}
+}
+
+// ResourceNodePoolsInvariant checks that the bonded & not bonded pools of resource nodes
+// reflect the tokens of the resource nodes, the unbonding balances being kept in the not bonded pool
+func ResourceNodePoolsInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var msg string
+		count := 0
+		bonded := sdk.ZeroInt()
+		notBonded := sdk.ZeroInt()
+		for _, resourceNode := range k.GetAllResourceNodes(ctx) {
+			var valid bool
+			bonded, notBonded, valid = addNodeTokens(bonded, notBonded, resourceNode.GetStatus(),
+				resourceNode.GetTokens(), k.GetUnbondingNodeBalance(ctx, resourceNode.GetNetworkAddr()))
+			if !valid {
+				count++
+				msg += fmt.Sprintf("\t%v has an invalid status: %v\n", resourceNode.GetNetworkAddr(), resourceNode.GetStatus())
+			}
+		}
+		return poolsInvariantResult("resource-node-pools", bonded, notBonded,
+			k.GetResourceNodeBondedToken(ctx).Amount, k.GetResourceNodeNotBondedToken(ctx).Amount, count, msg)
+	}
+}
+
+// IndexingNodePoolsInvariant checks that the bonded & not bonded pools of indexing nodes
+// reflect the tokens of the indexing nodes, the unbonding balances being kept in the not bonded pool
+func IndexingNodePoolsInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var msg string
+		count := 0
+		bonded := sdk.ZeroInt()
+		notBonded := sdk.ZeroInt()
+		for _, indexingNode := range k.GetAllIndexingNodes(ctx) {
+			var valid bool
+			bonded, notBonded, valid = addNodeTokens(bonded, notBonded, indexingNode.GetStatus(),
+				indexingNode.GetTokens(), k.GetUnbondingNodeBalance(ctx, indexingNode.GetNetworkAddr()))
+			if !valid {
+				count++
+				msg += fmt.Sprintf("\t%v has an invalid status: %v\n", indexingNode.GetNetworkAddr(), indexingNode.GetStatus())
+			}
+		}
+		return poolsInvariantResult("indexing-node-pools", bonded, notBonded,
+			k.GetIndexingNodeBondedToken(ctx).Amount, k.GetIndexingNodeNotBondedToken(ctx).Amount, count, msg)
+	}
+}
+
+// NonNegativeOzoneLimitInvariant checks that the remaining ozone limit is never negative
+func NonNegativeOzoneLimitInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		remainingOzoneLimit := k.GetRemainingOzoneLimit(ctx)
+		broken := remainingOzoneLimit.IsNegative()
+
+		return sdk.FormatInvariant(types.ModuleName, "nonnegative-ozone-limit", fmt.Sprintf(
+			"\tremaining ozone limit: %v\n", remainingOzoneLimit)), broken
+	}
+}
+
+// addNodeTokens adds the tokens of a node to the expected pool amounts, it reports a node with an invalid status as not valid.
+// The tokens of a node include its unbonding balance, which is moved to the not bonded pool when a bonded node starts unbonding.
+func addNodeTokens(bonded, notBonded sdk.Int, status sdk.BondStatus, tokens, unbondingBalance sdk.Int) (sdk.Int, sdk.Int, bool) {
+	switch status {
+	case sdk.Bonded:
+		return bonded.Add(tokens.Sub(unbondingBalance)), notBonded.Add(unbondingBalance), true
+	case sdk.Unbonding, sdk.Unbonded:
+		return bonded, notBonded.Add(tokens), true
+	default:
+		return bonded, notBonded, false
+	}
+}
+
+func poolsInvariantResult(route string, bonded, notBonded, poolBonded, poolNotBonded sdk.Int, invalidCount int, msg string) (string, bool) {
+	broken := invalidCount != 0 || !poolBonded.Equal(bonded) || !poolNotBonded.Equal(notBonded)
+
+	return sdk.FormatInvariant(types.ModuleName, route, fmt.Sprintf(
+		"\tPool's bonded tokens: %v\n"+
+			"\tsum of bonded tokens: %v\n"+
+			"\tPool's not bonded tokens: %v\n"+
+			"\tsum of not bonded tokens: %v\n"+
+			"%d nodes with an invalid status found\n%s",
+		poolBonded, bonded, poolNotBonded, notBonded, invalidCount, msg)), broken
+}
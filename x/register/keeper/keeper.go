@@ -94,6 +94,32 @@ func (k Keeper) GetTotalUnissuedPrepay(ctx sdk.Context) (totalUnissuedPrepay sdk
 	return
 }
 
+// SetGenesisUnissuedPrepay records the total unissued prepay preset by the genesis, which no prepay is behind
+func (k Keeper) SetGenesisUnissuedPrepay(ctx sdk.Context, amount sdk.Int) {
+	store := ctx.KVStore(k.storeKey)
+	b := k.cdc.MustMarshalBinaryLengthPrefixed(amount)
+	store.Set(types.GenesisUnissuedPrepayKey, b)
+}
+
+func (k Keeper) GetGenesisUnissuedPrepay(ctx sdk.Context) (amount sdk.Int) {
+	store := ctx.KVStore(k.storeKey)
+	b := store.Get(types.GenesisUnissuedPrepayKey)
+	if b == nil {
+		return sdk.ZeroInt()
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(b, &amount)
+	return
+}
+
+// MigrateGenesisUnissuedPrepay presets the genesis unissued prepay of a chain started before it was recorded
+// with the current total unissued prepay
+func (k Keeper) MigrateGenesisUnissuedPrepay(ctx sdk.Context) {
+	if ctx.KVStore(k.storeKey).Has(types.GenesisUnissuedPrepayKey) {
+		return
+	}
+	k.SetGenesisUnissuedPrepay(ctx, k.GetTotalUnissuedPrepay(ctx).Amount)
+}
+
 func (k Keeper) SetInitialGenesisStakeTotal(ctx sdk.Context, stake sdk.Int) {
 	store := ctx.KVStore(k.storeKey)
 	b := k.cdc.MustMarshalBinaryLengthPrefixed(stake)
@@ -267,6 +293,25 @@ func (k Keeper) HasMaxUnbondingNodeEntries(ctx sdk.Context, networkAddr stratos.
 	return len(ubd.Entries) >= int(k.MaxEntries(ctx))
 }
 
+// hasRoomForUnbondingNodeEntries checks if the unbonding node can take the new entries without exceeding the maximum
+func (k Keeper) hasRoomForUnbondingNodeEntries(ctx sdk.Context, networkAddr stratos.SdsAddress, newEntries int) bool {
+	entries := newEntries
+	if ubd, found := k.GetUnbondingNode(ctx, networkAddr); found {
+		entries += len(ubd.Entries)
+	}
+	return entries <= int(k.MaxEntries(ctx))
+}
+
+// countRemovalUnbondingEntries returns the number of unbonding entries removing the node creates,
+// one per delegation plus one for the stake of the owner not unbonding yet
+func countRemovalUnbondingEntries(delegations types.Delegations, ownerTokens sdk.Int) int {
+	newEntries := len(delegations)
+	if ownerTokens.IsPositive() {
+		newEntries++
+	}
+	return newEntries
+}
+
 // set the unbonding IndexingNode
 func (k Keeper) SetUnbondingNode(ctx sdk.Context, ubd types.UnbondingNode) {
 	store := ctx.KVStore(k.storeKey)
@@ -285,13 +330,13 @@ func (k Keeper) RemoveUnbondingNode(ctx sdk.Context, ubd types.UnbondingNode) {
 // SetUnbondingIndexingNodeEntry adds an entry to the unbonding IndexingNode at
 // the given addresses. It creates the unbonding IndexingNode if it does not exist
 func (k Keeper) SetUnbondingNodeEntry(ctx sdk.Context, networkAddr stratos.SdsAddress, isIndexingNode bool,
-	creationHeight int64, minTime time.Time, balance sdk.Int) types.UnbondingNode {
+	creationHeight int64, minTime time.Time, balance sdk.Int, delegator sdk.AccAddress) types.UnbondingNode {
 
 	ubd, found := k.GetUnbondingNode(ctx, networkAddr)
 	if found {
-		ubd.AddEntry(creationHeight, minTime, balance)
+		ubd.AddEntry(creationHeight, minTime, balance, delegator)
 	} else {
-		ubd = types.NewUnbondingNode(networkAddr, isIndexingNode, creationHeight, minTime, balance)
+		ubd = types.NewUnbondingNode(networkAddr, isIndexingNode, creationHeight, minTime, balance, delegator)
 	}
 	k.SetUnbondingNode(ctx, ubd)
 	return ubd
@@ -384,7 +429,7 @@ func (k Keeper) CompleteUnbondingWithAmount(ctx sdk.Context, networkAddr stratos
 			// track undelegation only when remaining or truncated shares are non-zero
 			if !entry.Balance.IsZero() {
 				amt := sdk.NewCoin(bondDenom, entry.Balance)
-				err := k.SubtractUBDNodeStake(ctx, ubd, amt)
+				err := k.SubtractUBDNodeStake(ctx, ubd, amt, entry.Delegator)
 				if err != nil {
 					return nil, false, err
 				}
@@ -411,25 +456,83 @@ func (k Keeper) CompleteUnbonding(ctx sdk.Context, networkAddr stratos.SdsAddres
 	return err
 }
 
-func (k Keeper) SubtractUBDNodeStake(ctx sdk.Context, ubd types.UnbondingNode, tokenToSub sdk.Coin) error {
+// SubtractUBDNodeStake removes the unbonded tokens from the node and pays them to the delegator,
+// or to the node owner when the delegator is empty
+func (k Keeper) SubtractUBDNodeStake(ctx sdk.Context, ubd types.UnbondingNode, tokenToSub sdk.Coin, delegator sdk.AccAddress) error {
 	// case of indexing node
 	if ubd.IsIndexingNode {
 		indexingNode, found := k.GetIndexingNode(ctx, ubd.NetworkAddr)
 		if !found {
 			return types.ErrNoIndexingNodeFound
 		}
-		return k.SubtractIndexingNodeStake(ctx, indexingNode, tokenToSub)
+		if delegator.Empty() {
+			delegator = indexingNode.GetOwnerAddr()
+		}
+		return k.subtractIndexingNodeStake(ctx, indexingNode, tokenToSub, delegator)
 	}
 	// case of resource node
 	resourceNode, found := k.GetResourceNode(ctx, ubd.NetworkAddr)
 	if !found {
 		return types.ErrNoIndexingNodeFound
 	}
-	return k.SubtractResourceNodeStake(ctx, resourceNode, tokenToSub)
+	if delegator.Empty() {
+		delegator = resourceNode.GetOwnerAddr()
+	}
+	return k.subtractResourceNodeStake(ctx, resourceNode, tokenToSub, delegator)
 }
 
 func (k Keeper) UnbondResourceNode(
 	ctx sdk.Context, resourceNode types.ResourceNode, amt sdk.Int,
+) (ozoneLimitChange sdk.Int, unbondingMatureTime time.Time, err error) {
+	// the owner can only unbond its own part of the stake which is not unbonding yet
+	if amt.GT(k.GetOwnerBondedTokens(ctx, resourceNode.GetNetworkAddr(), resourceNode.Tokens)) {
+		return sdk.ZeroInt(), time.Time{}, types.ErrInsufficientBalance
+	}
+	if k.HasMaxUnbondingNodeEntries(ctx, resourceNode.GetNetworkAddr()) {
+		return sdk.ZeroInt(), time.Time{}, types.ErrMaxUnbondingNodeEntries
+	}
+	return k.unbondResourceNode(ctx, resourceNode, amt, nil)
+}
+
+// RemoveResourceNode unbonds the whole stake of the resource node: the delegations are undelegated to their delegators,
+// then the stake of the owner not unbonding yet is unbonded, and the node begins unbonding.
+func (k Keeper) RemoveResourceNode(
+	ctx sdk.Context, resourceNode types.ResourceNode,
+) (ozoneLimitChange sdk.Int, unbondingMatureTime time.Time, err error) {
+	networkAddr := resourceNode.GetNetworkAddr()
+	delegations := k.GetNodeDelegations(ctx, networkAddr)
+	ownerTokens := k.GetOwnerBondedTokens(ctx, networkAddr, resourceNode.Tokens)
+	if !k.hasRoomForUnbondingNodeEntries(ctx, networkAddr, countRemovalUnbondingEntries(delegations, ownerTokens)) {
+		return sdk.ZeroInt(), time.Time{}, types.ErrMaxUnbondingNodeEntries
+	}
+
+	ozoneLimitChange = sdk.ZeroInt()
+	for _, delegation := range delegations {
+		change, matureTime, err := k.unbondResourceNode(ctx, resourceNode, delegation.Amount, delegation.DelegatorAddress)
+		if err != nil {
+			return sdk.ZeroInt(), time.Time{}, err
+		}
+		ozoneLimitChange, unbondingMatureTime = ozoneLimitChange.Add(change), matureTime
+		delegation.Amount = sdk.ZeroInt()
+		k.SetDelegation(ctx, delegation)
+	}
+
+	if ownerTokens.IsPositive() {
+		change, matureTime, err := k.unbondResourceNode(ctx, resourceNode, ownerTokens, nil)
+		if err != nil {
+			return sdk.ZeroInt(), time.Time{}, err
+		}
+		ozoneLimitChange, unbondingMatureTime = ozoneLimitChange.Add(change), matureTime
+	}
+
+	resourceNode.Status = sdk.Unbonding
+	k.SetResourceNode(ctx, resourceNode)
+	return ozoneLimitChange, unbondingMatureTime, nil
+}
+
+// unbondResourceNode moves the tokens of the owner or of a delegator to the unbonding queue of the resource node
+func (k Keeper) unbondResourceNode(
+	ctx sdk.Context, resourceNode types.ResourceNode, amt sdk.Int, delegator sdk.AccAddress,
 ) (ozoneLimitChange sdk.Int, unbondingMatureTime time.Time, err error) {
 	params := k.GetParams(ctx)
 	ctx.Logger().Info("Params of register module: " + params.String())
@@ -440,10 +543,6 @@ func (k Keeper) UnbondResourceNode(
 		return sdk.ZeroInt(), time.Time{}, types.ErrNoOwnerAccountFound
 	}
 
-	networkAddr := resourceNode.GetNetworkAddr()
-	if k.HasMaxUnbondingNodeEntries(ctx, networkAddr) {
-		return sdk.ZeroInt(), time.Time{}, types.ErrMaxUnbondingNodeEntries
-	}
 	unbondingMatureTime = calcUnbondingMatureTime(ctx, resourceNode.Status, resourceNode.CreationTime, k.UnbondingThreasholdTime(ctx), k.UnbondingCompletionTime(ctx))
 
 	bondDenom := k.GetParams(ctx).BondDenom
@@ -465,7 +564,7 @@ func (k Keeper) UnbondResourceNode(
 	ctx.Logger().Info(fmt.Sprintf("Calculating mature time: creationTime[%s], threasholdTime[%s], completionTime[%s], matureTime[%s]",
 		resourceNode.CreationTime, k.UnbondingThreasholdTime(ctx), k.UnbondingCompletionTime(ctx), unbondingMatureTime,
 	))
-	unbondingNode := k.SetUnbondingNodeEntry(ctx, resourceNode.GetNetworkAddr(), false, ctx.BlockHeight(), unbondingMatureTime, amt)
+	unbondingNode := k.SetUnbondingNodeEntry(ctx, resourceNode.GetNetworkAddr(), false, ctx.BlockHeight(), unbondingMatureTime, amt, delegator)
 	// Add to unbonding node queue
 	k.InsertUnbondingNodeQueue(ctx, unbondingNode, unbondingMatureTime)
 	ctx.Logger().Info("Unbonding resource node " + unbondingNode.String() + "\n after mature time" + unbondingMatureTime.String())
@@ -476,17 +575,62 @@ func (k Keeper) UnbondResourceNode(
 func (k Keeper) UnbondIndexingNode(
 	ctx sdk.Context, indexingNode types.IndexingNode, amt sdk.Int,
 ) (ozoneLimitChange sdk.Int, unbondingMatureTime time.Time, err error) {
-
-	ownerAcc := k.accountKeeper.GetAccount(ctx, indexingNode.OwnerAddress)
-	if ownerAcc == nil {
-		return sdk.ZeroInt(), time.Time{}, types.ErrNoOwnerAccountFound
+	// the owner can only unbond its own part of the stake which is not unbonding yet
+	if amt.GT(k.GetOwnerBondedTokens(ctx, indexingNode.GetNetworkAddr(), indexingNode.Tokens)) {
+		return sdk.ZeroInt(), time.Time{}, types.ErrInsufficientBalance
+	}
+	if k.HasMaxUnbondingNodeEntries(ctx, indexingNode.GetNetworkAddr()) {
+		return sdk.ZeroInt(), time.Time{}, types.ErrMaxUnbondingNodeEntries
 	}
+	return k.unbondIndexingNode(ctx, indexingNode, amt, nil)
+}
 
+// RemoveIndexingNode unbonds the whole stake of the indexing node: the delegations are undelegated to their delegators,
+// then the stake of the owner not unbonding yet is unbonded, and the node begins unbonding.
+func (k Keeper) RemoveIndexingNode(
+	ctx sdk.Context, indexingNode types.IndexingNode,
+) (ozoneLimitChange sdk.Int, unbondingMatureTime time.Time, err error) {
 	networkAddr := indexingNode.GetNetworkAddr()
-	if k.HasMaxUnbondingNodeEntries(ctx, networkAddr) {
+	delegations := k.GetNodeDelegations(ctx, networkAddr)
+	ownerTokens := k.GetOwnerBondedTokens(ctx, networkAddr, indexingNode.Tokens)
+	if !k.hasRoomForUnbondingNodeEntries(ctx, networkAddr, countRemovalUnbondingEntries(delegations, ownerTokens)) {
 		return sdk.ZeroInt(), time.Time{}, types.ErrMaxUnbondingNodeEntries
 	}
 
+	ozoneLimitChange = sdk.ZeroInt()
+	for _, delegation := range delegations {
+		change, matureTime, err := k.unbondIndexingNode(ctx, indexingNode, delegation.Amount, delegation.DelegatorAddress)
+		if err != nil {
+			return sdk.ZeroInt(), time.Time{}, err
+		}
+		ozoneLimitChange, unbondingMatureTime = ozoneLimitChange.Add(change), matureTime
+		delegation.Amount = sdk.ZeroInt()
+		k.SetDelegation(ctx, delegation)
+	}
+
+	if ownerTokens.IsPositive() {
+		change, matureTime, err := k.unbondIndexingNode(ctx, indexingNode, ownerTokens, nil)
+		if err != nil {
+			return sdk.ZeroInt(), time.Time{}, err
+		}
+		ozoneLimitChange, unbondingMatureTime = ozoneLimitChange.Add(change), matureTime
+	}
+
+	indexingNode.Status = sdk.Unbonding
+	k.SetIndexingNode(ctx, indexingNode)
+	return ozoneLimitChange, unbondingMatureTime, nil
+}
+
+// unbondIndexingNode moves the tokens of the owner or of a delegator to the unbonding queue of the indexing node
+func (k Keeper) unbondIndexingNode(
+	ctx sdk.Context, indexingNode types.IndexingNode, amt sdk.Int, delegator sdk.AccAddress,
+) (ozoneLimitChange sdk.Int, unbondingMatureTime time.Time, err error) {
+
+	ownerAcc := k.accountKeeper.GetAccount(ctx, indexingNode.OwnerAddress)
+	if ownerAcc == nil {
+		return sdk.ZeroInt(), time.Time{}, types.ErrNoOwnerAccountFound
+	}
+
 	unbondingMatureTime = calcUnbondingMatureTime(ctx, indexingNode.Status, indexingNode.CreationTime, k.UnbondingThreasholdTime(ctx), k.UnbondingCompletionTime(ctx))
 
 	bondDenom := k.GetParams(ctx).BondDenom
@@ -504,7 +648,7 @@ func (k Keeper) UnbondIndexingNode(
 	}
 
 	// Set the unbonding mature time and completion height appropriately
-	unbondingNode := k.SetUnbondingNodeEntry(ctx, indexingNode.GetNetworkAddr(), true, ctx.BlockHeight(), unbondingMatureTime, amt)
+	unbondingNode := k.SetUnbondingNodeEntry(ctx, indexingNode.GetNetworkAddr(), true, ctx.BlockHeight(), unbondingMatureTime, amt, delegator)
 	// Add to unbonding node queue
 	k.InsertUnbondingNodeQueue(ctx, unbondingNode, unbondingMatureTime)
 	ctx.Logger().Info("Unbonding indexing node " + unbondingNode.String() + "\n after mature time" + unbondingMatureTime.String())
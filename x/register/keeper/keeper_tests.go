@@ -50,6 +50,9 @@ func CreateTestInput(t *testing.T, isCheckTx bool) (sdk.Context, auth.AccountKee
 
 	keeper := NewKeeper(cdc, keyRegister, pk.Subspace(types.DefaultParamSpace), accountKeeper, bankKeeper)
 	keeper.SetParams(ctx, types.DefaultParams())
+	keeper.SetInitialUOzonePrice(ctx, types.DefaultUozPrice)
+	keeper.SetRemainingOzoneLimit(ctx, sdk.ZeroInt())
+	keeper.SetTotalUnissuedPrepay(ctx, sdk.NewCoin(types.DefaultBondDenom, sdk.ZeroInt()))
 
 	return ctx, accountKeeper, bankKeeper, keeper, pk
 }
@@ -40,6 +40,22 @@ func (k Keeper) BlockRegisteredNodesUpdates(ctx sdk.Context) []abci.ValidatorUpd
 
 	}
 
+	// Remove all mature redelegation entries from the redelegation queue.
+	for _, pair := range k.DequeueAllMatureRedelegationQueue(ctx, ctx.BlockHeader().Time) {
+		balance, err := k.CompleteRedelegation(ctx, pair.SrcNetworkAddr, pair.DstNetworkAddr)
+		if err != nil {
+			continue
+		}
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				types.EventTypeCompleteRedelegation,
+				sdk.NewAttribute(sdk.AttributeKeyAmount, balance.String()),
+				sdk.NewAttribute(types.AttributeKeySrcNetworkAddress, pair.SrcNetworkAddr.String()),
+				sdk.NewAttribute(types.AttributeKeyDstNetworkAddress, pair.DstNetworkAddr.String()),
+			),
+		)
+	}
+
 	// UpdateNode won't create UBD node
 	return []abci.ValidatorUpdate{}
 }
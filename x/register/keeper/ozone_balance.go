@@ -0,0 +1,62 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/stratosnet/stratos-chain/x/register/types"
+)
+
+// GetOzoneBalance returns the remaining uoz of the wallet, purchased by prepay and not consumed yet
+func (k Keeper) GetOzoneBalance(ctx sdk.Context, walletAddress sdk.AccAddress) (balance sdk.Int) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetOzoneBalanceKey(walletAddress))
+	if bz == nil {
+		return sdk.ZeroInt()
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &balance)
+	return
+}
+
+// SetOzoneBalance sets the remaining uoz of the wallet, the record is removed once the balance is used up
+func (k Keeper) SetOzoneBalance(ctx sdk.Context, walletAddress sdk.AccAddress, balance sdk.Int) {
+	store := ctx.KVStore(k.storeKey)
+	if !balance.IsPositive() {
+		store.Delete(types.GetOzoneBalanceKey(walletAddress))
+		return
+	}
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(balance)
+	store.Set(types.GetOzoneBalanceKey(walletAddress), bz)
+}
+
+// AddOzoneBalance credits the wallet with the uoz it purchased
+func (k Keeper) AddOzoneBalance(ctx sdk.Context, walletAddress sdk.AccAddress, amount sdk.Int) sdk.Int {
+	balance := k.GetOzoneBalance(ctx, walletAddress).Add(amount)
+	k.SetOzoneBalance(ctx, walletAddress, balance)
+	return balance
+}
+
+// DebitOzoneBalance debits the uoz consumed by the wallet. The debit is capped at the remaining balance,
+// the amount actually debited is returned.
+func (k Keeper) DebitOzoneBalance(ctx sdk.Context, walletAddress sdk.AccAddress, amount sdk.Int) (debited sdk.Int) {
+	balance := k.GetOzoneBalance(ctx, walletAddress)
+	debited = sdk.MinInt(balance, amount)
+	if !debited.IsPositive() {
+		return sdk.ZeroInt()
+	}
+	k.SetOzoneBalance(ctx, walletAddress, balance.Sub(debited))
+	return debited
+}
+
+func (k Keeper) IteratorOzoneBalances(ctx sdk.Context, handler func(walletAddress sdk.AccAddress, balance sdk.Int) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, types.OzoneBalancePrefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		walletAddress := sdk.AccAddress(iter.Key()[len(types.OzoneBalancePrefix):])
+		var balance sdk.Int
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &balance)
+		if handler(walletAddress, balance) {
+			break
+		}
+	}
+}
@@ -17,6 +17,17 @@ func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
 	k.paramSpace.SetParamSet(ctx, &params)
 }
 
+// MigrateParams sets the params missing from the param space of a chain started before they were added
+// to their default values, the other params are left unchanged
+func (k Keeper) MigrateParams(ctx sdk.Context) {
+	defaultParams := types.DefaultParams()
+	for _, pair := range defaultParams.ParamSetPairs() {
+		if !k.paramSpace.Has(ctx, pair.Key) {
+			k.paramSpace.Set(ctx, pair.Key, pair.Value)
+		}
+	}
+}
+
 // BondDenom - Bondable coin denomination
 func (k Keeper) BondDenom(ctx sdk.Context) (res string) {
 	k.paramSpace.Get(ctx, types.KeyBondDenom, &res)
@@ -41,3 +52,15 @@ func (k Keeper) UnbondingCompletionTime(ctx sdk.Context) (res time.Duration) {
 	k.paramSpace.Get(ctx, types.KeyUnbondingCompletionTime, &res)
 	return
 }
+
+// MinSuspendPeriod
+func (k Keeper) MinSuspendPeriod(ctx sdk.Context) (res time.Duration) {
+	k.paramSpace.Get(ctx, types.KeyMinSuspendPeriod, &res)
+	return
+}
+
+// UozHistoryEntries
+func (k Keeper) UozHistoryEntries(ctx sdk.Context) (res uint32) {
+	k.paramSpace.Get(ctx, types.KeyUozHistoryEntries, &res)
+	return
+}
@@ -23,6 +23,8 @@ const (
 	QueryNodeStakeByNodeAddr       = "node_stakes"
 	QueryNodeStakeByOwner          = "node_stakes_by_owner"
 	QueryRegisterParams            = "register_params"
+	QueryDelegations               = "delegations"
+	QueryRedelegations             = "redelegations"
 	QueryDefaultLimit              = 100
 )
 
@@ -42,6 +44,10 @@ func NewQuerier(k Keeper) sdk.Querier {
 			return getStakingInfoByOwnerAddr(ctx, req, k)
 		case QueryRegisterParams:
 			return getRegisterParams(ctx, req, k)
+		case QueryDelegations:
+			return getDelegations(ctx, req, k)
+		case QueryRedelegations:
+			return getRedelegations(ctx, req, k)
 		default:
 			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "unknown register query endpoint "+req.String()+string(req.Data))
 		}
@@ -53,6 +59,63 @@ func getRegisterParams(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte
 	return types.ModuleCdc.MustMarshalJSON(params), nil
 }
 
+// getDelegations returns the delegations to a node and/or of a delegator
+func getDelegations(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, error) {
+	var params types.QueryDelegationsParams
+	err := keeper.cdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	var delegations types.Delegations
+	switch {
+	case !params.NetworkAddr.Empty():
+		for _, delegation := range keeper.GetNodeDelegations(ctx, params.NetworkAddr) {
+			if params.DelegatorAddr.Empty() || delegation.DelegatorAddress.Equals(params.DelegatorAddr) {
+				delegations = append(delegations, delegation)
+			}
+		}
+	case !params.DelegatorAddr.Empty():
+		delegations = keeper.GetDelegatorDelegations(ctx, params.DelegatorAddr)
+	default:
+		delegations = keeper.GetAllDelegations(ctx)
+	}
+	if delegations == nil {
+		delegations = types.Delegations{}
+	}
+
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, delegations)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
+
+// getRedelegations returns the redelegations of an owner, or all the redelegations when no owner is given
+func getRedelegations(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, error) {
+	var params types.QueryRedelegationsParams
+	err := keeper.cdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	var reds types.Redelegations
+	if params.OwnerAddr.Empty() {
+		reds = keeper.GetAllRedelegations(ctx)
+	} else {
+		reds = keeper.GetOwnerRedelegations(ctx, params.OwnerAddr)
+	}
+	if reds == nil {
+		reds = types.Redelegations{}
+	}
+
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, reds)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
+
 func getResourceNodeByNetworkAddr(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, error) {
 	var params types.QueryNodesParams
 	err := keeper.cdc.UnmarshalJSON(req.Data, &params)
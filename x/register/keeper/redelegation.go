@@ -0,0 +1,307 @@
+package keeper
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	stratos "github.com/stratosnet/stratos-chain/types"
+	"github.com/stratosnet/stratos-chain/x/register/types"
+	"github.com/tendermint/tendermint/crypto"
+)
+
+// GetRedelegation returns the redelegation from the source node to the destination node
+func (k Keeper) GetRedelegation(ctx sdk.Context, srcNetworkAddr, dstNetworkAddr stratos.SdsAddress) (red types.Redelegation, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetRedelegationKey(srcNetworkAddr, dstNetworkAddr))
+	if bz == nil {
+		return red, false
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &red)
+	return red, true
+}
+
+// SetRedelegation stores the redelegation and its index by destination node, or removes them once all the entries completed
+func (k Keeper) SetRedelegation(ctx sdk.Context, red types.Redelegation) {
+	if len(red.Entries) == 0 {
+		k.RemoveRedelegation(ctx, red)
+		return
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetRedelegationKey(red.SrcNetworkAddr, red.DstNetworkAddr), k.cdc.MustMarshalBinaryLengthPrefixed(red))
+	store.Set(types.GetRedelegationByDstIndexKey(red.SrcNetworkAddr, red.DstNetworkAddr), []byte{})
+}
+
+// RemoveRedelegation removes the redelegation and its index by destination node
+func (k Keeper) RemoveRedelegation(ctx sdk.Context, red types.Redelegation) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetRedelegationKey(red.SrcNetworkAddr, red.DstNetworkAddr))
+	store.Delete(types.GetRedelegationByDstIndexKey(red.SrcNetworkAddr, red.DstNetworkAddr))
+}
+
+// IterateRedelegations iterates through all the redelegations
+func (k Keeper) IterateRedelegations(ctx sdk.Context, handler func(red types.Redelegation) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, types.RedelegationKey)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var red types.Redelegation
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &red)
+		if handler(red) {
+			break
+		}
+	}
+}
+
+// GetAllRedelegations returns all the redelegations, used during genesis dump
+func (k Keeper) GetAllRedelegations(ctx sdk.Context) (reds types.Redelegations) {
+	k.IterateRedelegations(ctx, func(red types.Redelegation) (stop bool) {
+		reds = append(reds, red)
+		return false
+	})
+	return reds
+}
+
+// GetOwnerRedelegations returns all the redelegations of the owner
+func (k Keeper) GetOwnerRedelegations(ctx sdk.Context, ownerAddr sdk.AccAddress) (reds types.Redelegations) {
+	k.IterateRedelegations(ctx, func(red types.Redelegation) (stop bool) {
+		if red.OwnerAddress.Equals(ownerAddr) {
+			reds = append(reds, red)
+		}
+		return false
+	})
+	return reds
+}
+
+// HasReceivingRedelegation checks if the node received stake from a redelegation which is not completed yet
+func (k Keeper) HasReceivingRedelegation(ctx sdk.Context, dstNetworkAddr stratos.SdsAddress) bool {
+	store := ctx.KVStore(k.storeKey)
+	prefix := types.GetRedelegationsByDstIndexKey(dstNetworkAddr)
+	iter := sdk.KVStorePrefixIterator(store, prefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		srcNetworkAddr := stratos.SdsAddress(iter.Key()[len(prefix):])
+		red, found := k.GetRedelegation(ctx, srcNetworkAddr, dstNetworkAddr)
+		if !found {
+			continue
+		}
+		for _, entry := range red.Entries {
+			if !entry.IsMature(ctx.BlockHeader().Time) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// GetRedelegationQueueTimeSlice gets the redelegations completing at the timestamp
+func (k Keeper) GetRedelegationQueueTimeSlice(ctx sdk.Context, timestamp time.Time) (pairs []types.RedelegationPair) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetRedelegationTimeKey(timestamp))
+	if bz == nil {
+		return []types.RedelegationPair{}
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &pairs)
+	return pairs
+}
+
+// SetRedelegationQueueTimeSlice sets the redelegations completing at the timestamp
+func (k Keeper) SetRedelegationQueueTimeSlice(ctx sdk.Context, timestamp time.Time, pairs []types.RedelegationPair) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(pairs)
+	store.Set(types.GetRedelegationTimeKey(timestamp), bz)
+}
+
+// InsertRedelegationQueue inserts the redelegation to the appropriate timeslice in the redelegation queue
+func (k Keeper) InsertRedelegationQueue(ctx sdk.Context, red types.Redelegation, completionTime time.Time) {
+	timeSlice := k.GetRedelegationQueueTimeSlice(ctx, completionTime)
+	pair := types.RedelegationPair{SrcNetworkAddr: red.SrcNetworkAddr, DstNetworkAddr: red.DstNetworkAddr}
+	k.SetRedelegationQueueTimeSlice(ctx, completionTime, append(timeSlice, pair))
+}
+
+// DequeueAllMatureRedelegationQueue returns all the redelegations with timeslices inclusively previous to currTime,
+// and deletes the timeslices from the queue
+func (k Keeper) DequeueAllMatureRedelegationQueue(ctx sdk.Context, currTime time.Time) (matureRedelegations []types.RedelegationPair) {
+	store := ctx.KVStore(k.storeKey)
+	iter := store.Iterator(types.RedelegationQueueKey, sdk.InclusiveEndBytes(types.GetRedelegationTimeKey(currTime)))
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		var timeSlice []types.RedelegationPair
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &timeSlice)
+		matureRedelegations = append(matureRedelegations, timeSlice...)
+		store.Delete(iter.Key())
+	}
+	return matureRedelegations
+}
+
+// CompleteRedelegation removes the mature entries of the redelegation and returns the balance they moved
+func (k Keeper) CompleteRedelegation(ctx sdk.Context, srcNetworkAddr, dstNetworkAddr stratos.SdsAddress) (sdk.Int, error) {
+	red, found := k.GetRedelegation(ctx, srcNetworkAddr, dstNetworkAddr)
+	if !found {
+		return sdk.ZeroInt(), types.ErrNoRedelegationFound
+	}
+
+	balance := sdk.ZeroInt()
+	ctxTime := ctx.BlockHeader().Time
+	for i := 0; i < len(red.Entries); i++ {
+		entry := red.Entries[i]
+		if entry.IsMature(ctxTime) {
+			red.RemoveEntry(int64(i))
+			i--
+			balance = balance.Add(entry.Balance)
+		}
+	}
+	k.SetRedelegation(ctx, red)
+	return balance, nil
+}
+
+// RedelegateNodeStake moves bonded tokens of the owner from one of its nodes to another one, or to a new resource node
+// registered with the public key of the destination. The tokens stay bonded, so the pools and the ozone limit are unchanged.
+func (k Keeper) RedelegateNodeStake(ctx sdk.Context, ownerAddr sdk.AccAddress, srcNetworkAddr, dstNetworkAddr stratos.SdsAddress,
+	isIndexingNode bool, amount sdk.Coin, dstPubKey crypto.PubKey) (completionTime time.Time, err error) {
+
+	blockTime := ctx.BlockHeader().Time
+	if amount.Denom != k.BondDenom(ctx) {
+		return blockTime, types.ErrBadDenom
+	}
+	if srcNetworkAddr.Equals(dstNetworkAddr) {
+		return blockTime, types.ErrSelfRedelegation
+	}
+	// redelegation entries are not slashed, so the stake can not move away before the slashing debt is cleared
+	if k.GetSlashing(ctx, ownerAddr).IsPositive() {
+		return blockTime, types.ErrSlashingNotCleared
+	}
+	// the stake received from a redelegation can not move again before the redelegation completes
+	if k.HasReceivingRedelegation(ctx, srcNetworkAddr) {
+		return blockTime, types.ErrTransitiveRedelegation
+	}
+	red, found := k.GetRedelegation(ctx, srcNetworkAddr, dstNetworkAddr)
+	if found && len(red.Entries) >= int(k.MaxEntries(ctx)) {
+		return blockTime, types.ErrMaxRedelegationEntries
+	}
+
+	if isIndexingNode {
+		err = k.redelegateIndexingNodeStake(ctx, ownerAddr, srcNetworkAddr, dstNetworkAddr, amount.Amount)
+	} else {
+		err = k.redelegateResourceNodeStake(ctx, ownerAddr, srcNetworkAddr, dstNetworkAddr, amount.Amount, dstPubKey)
+	}
+	if err != nil {
+		return blockTime, err
+	}
+
+	completionTime = blockTime.Add(k.UnbondingCompletionTime(ctx))
+	if found {
+		red.AddEntry(ctx.BlockHeight(), completionTime, amount.Amount)
+	} else {
+		red = types.NewRedelegation(ownerAddr, srcNetworkAddr, dstNetworkAddr, isIndexingNode, ctx.BlockHeight(), completionTime, amount.Amount)
+	}
+	k.SetRedelegation(ctx, red)
+	k.InsertRedelegationQueue(ctx, red, completionTime)
+	ctx.Logger().Info(fmt.Sprintf("Redelegating %s from %s to %s until %s", amount, srcNetworkAddr, dstNetworkAddr, completionTime))
+	return completionTime, nil
+}
+
+func (k Keeper) redelegateResourceNodeStake(ctx sdk.Context, ownerAddr sdk.AccAddress, srcNetworkAddr, dstNetworkAddr stratos.SdsAddress,
+	amt sdk.Int, dstPubKey crypto.PubKey) error {
+
+	srcNode, found := k.GetResourceNode(ctx, srcNetworkAddr)
+	if !found {
+		return types.ErrNoResourceNodeFound
+	}
+	if !srcNode.GetOwnerAddr().Equals(ownerAddr) {
+		return types.ErrInvalidOwnerAddr
+	}
+	if srcNode.GetStatus() != sdk.Bonded {
+		return types.ErrNodeNotBonded
+	}
+	if srcNode.IsSuspended() {
+		return types.ErrNodeSuspended
+	}
+	if amt.GT(k.GetOwnerBondedTokens(ctx, srcNetworkAddr, srcNode.GetTokens())) {
+		return types.ErrInsufficientBalance
+	}
+
+	dstNode, dstFound := k.GetResourceNode(ctx, dstNetworkAddr)
+	if dstFound {
+		if !dstNode.GetOwnerAddr().Equals(ownerAddr) {
+			return types.ErrInvalidOwnerAddr
+		}
+		if dstNode.GetStatus() != sdk.Bonded {
+			return types.ErrNodeNotBonded
+		}
+	} else {
+		if dstPubKey == nil {
+			return types.ErrNoResourceNodeFound
+		}
+		// the new node takes over the settings of the source node, and is bonded like the redelegated stake
+		dstNode = types.NewResourceNode(dstNetworkAddr, dstPubKey, ownerAddr, srcNode.Description, srcNode.NodeType, ctx.BlockHeader().Time)
+		dstNode.Status = sdk.Bonded
+		dstNode.Commission = srcNode.Commission
+		dstNode.Beneficiaries = srcNode.Beneficiaries
+	}
+
+	srcNode = srcNode.SubToken(amt)
+	dstNode = dstNode.AddToken(amt)
+	k.SetResourceNode(ctx, srcNode)
+	k.SetResourceNode(ctx, dstNode)
+	if !dstFound {
+		k.AfterNodeCreated(ctx, dstNetworkAddr, false)
+	}
+
+	// the source node is removed once all its stake moved
+	if srcNode.GetTokens().IsZero() {
+		if err := k.removeResourceNode(ctx, srcNetworkAddr); err != nil {
+			return err
+		}
+		k.AfterNodeRemoved(ctx, srcNetworkAddr, false)
+	}
+	return nil
+}
+
+func (k Keeper) redelegateIndexingNodeStake(ctx sdk.Context, ownerAddr sdk.AccAddress, srcNetworkAddr, dstNetworkAddr stratos.SdsAddress,
+	amt sdk.Int) error {
+
+	srcNode, found := k.GetIndexingNode(ctx, srcNetworkAddr)
+	if !found {
+		return types.ErrNoIndexingNodeFound
+	}
+	if !srcNode.GetOwnerAddr().Equals(ownerAddr) {
+		return types.ErrInvalidOwnerAddr
+	}
+	if srcNode.GetStatus() != sdk.Bonded {
+		return types.ErrNodeNotBonded
+	}
+	if srcNode.IsSuspended() {
+		return types.ErrNodeSuspended
+	}
+	if amt.GT(k.GetOwnerBondedTokens(ctx, srcNetworkAddr, srcNode.GetTokens())) {
+		return types.ErrInsufficientBalance
+	}
+
+	// a new indexing node has to be approved by the registration vote, so the destination must already be bonded
+	dstNode, found := k.GetIndexingNode(ctx, dstNetworkAddr)
+	if !found {
+		return types.ErrNewIndexingNodeRedelegation
+	}
+	if !dstNode.GetOwnerAddr().Equals(ownerAddr) {
+		return types.ErrInvalidOwnerAddr
+	}
+	if dstNode.GetStatus() != sdk.Bonded {
+		return types.ErrNodeNotBonded
+	}
+
+	srcNode = srcNode.SubToken(amt)
+	dstNode = dstNode.AddToken(amt)
+	k.SetIndexingNode(ctx, srcNode)
+	k.SetIndexingNode(ctx, dstNode)
+
+	// the source node is removed once all its stake moved
+	if srcNode.GetTokens().IsZero() {
+		if err := k.removeIndexingNode(ctx, srcNetworkAddr); err != nil {
+			return err
+		}
+		k.AfterNodeRemoved(ctx, srcNetworkAddr, true)
+	}
+	return nil
+}
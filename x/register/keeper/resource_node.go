@@ -87,16 +87,22 @@ func (k Keeper) getResourceNodeIterator(ctx sdk.Context) sdk.Iterator {
 
 // AddResourceNodeStake Update the tokens of an existing resource node
 func (k Keeper) AddResourceNodeStake(ctx sdk.Context, resourceNode types.ResourceNode, tokenToAdd sdk.Coin,
+) (ozoneLimitChange sdk.Int, err error) {
+	return k.addResourceNodeStake(ctx, resourceNode, tokenToAdd, resourceNode.GetOwnerAddr())
+}
+
+// addResourceNodeStake adds the tokens paid by the payer, either the owner or a delegator, to the stake of the resource node
+func (k Keeper) addResourceNodeStake(ctx sdk.Context, resourceNode types.ResourceNode, tokenToAdd sdk.Coin, payer sdk.AccAddress,
 ) (ozoneLimitChange sdk.Int, err error) {
 
 	coins := sdk.NewCoins(tokenToAdd)
 
-	// sub coins from owner's wallet
-	hasCoin := k.bankKeeper.HasCoins(ctx, resourceNode.GetOwnerAddr(), coins)
+	// sub coins from payer's wallet
+	hasCoin := k.bankKeeper.HasCoins(ctx, payer, coins)
 	if !hasCoin {
 		return sdk.ZeroInt(), types.ErrInsufficientBalance
 	}
-	_, err = k.bankKeeper.SubtractCoins(ctx, resourceNode.GetOwnerAddr(), coins)
+	_, err = k.bankKeeper.SubtractCoins(ctx, payer, coins)
 	if err != nil {
 		return sdk.ZeroInt(), err
 	}
@@ -159,8 +165,14 @@ func (k Keeper) RemoveTokenFromPoolWhileUnbondingResourceNode(ctx sdk.Context, r
 
 // SubtractResourceNodeStake Update the tokens of an existing resource node
 func (k Keeper) SubtractResourceNodeStake(ctx sdk.Context, resourceNode types.ResourceNode, tokenToSub sdk.Coin) error {
-	ownerAcc := k.accountKeeper.GetAccount(ctx, resourceNode.OwnerAddress)
-	if ownerAcc == nil {
+	return k.subtractResourceNodeStake(ctx, resourceNode, tokenToSub, resourceNode.GetOwnerAddr())
+}
+
+// subtractResourceNodeStake removes the tokens from the stake of the resource node and pays them to the recipient,
+// either the owner or a delegator
+func (k Keeper) subtractResourceNodeStake(ctx sdk.Context, resourceNode types.ResourceNode, tokenToSub sdk.Coin, recipient sdk.AccAddress) error {
+	recipientAcc := k.accountKeeper.GetAccount(ctx, recipient)
+	if recipientAcc == nil {
 		return types.ErrNoOwnerAccountFound
 	}
 
@@ -179,9 +191,9 @@ func (k Keeper) SubtractResourceNodeStake(ctx sdk.Context, resourceNode types.Re
 	k.SetResourceNodeNotBondedToken(ctx, notBondedTokenInPool)
 
 	// deduct slashing amount first
-	coins = k.DeductSlashing(ctx, resourceNode.OwnerAddress, coins)
-	// add tokens to owner acc
-	_, err := k.bankKeeper.AddCoins(ctx, resourceNode.OwnerAddress, coins)
+	coins = k.DeductSlashing(ctx, recipient, coins)
+	// add tokens to recipient acc
+	_, err := k.bankKeeper.AddCoins(ctx, recipient, coins)
 	if err != nil {
 		return err
 	}
@@ -219,15 +231,22 @@ func (k Keeper) removeResourceNode(ctx sdk.Context, addr stratos.SdsAddress) err
 }
 
 func (k Keeper) RegisterResourceNode(ctx sdk.Context, networkAddr stratos.SdsAddress, pubKey crypto.PubKey, ownerAddr sdk.AccAddress,
-	description types.Description, nodeType types.NodeType, stake sdk.Coin) (ozoneLimitChange sdk.Int, err error) {
+	description types.Description, nodeType types.NodeType, stake sdk.Coin, commission types.CommissionRates,
+	beneficiaries []sdk.AccAddress) (ozoneLimitChange sdk.Int, err error) {
 
 	resourceNode := types.NewResourceNode(networkAddr, pubKey, ownerAddr, description, nodeType, ctx.BlockHeader().Time)
+	// empty rates keep the default commission, i.e. the owner keeps all the rewards
+	if !commission.IsEmpty() {
+		resourceNode.Commission = types.NewCommission(commission, ctx.BlockHeader().Time)
+	}
+	resourceNode.Beneficiaries = beneficiaries
 	ozoneLimitChange, err = k.AddResourceNodeStake(ctx, resourceNode, stake)
 	return ozoneLimitChange, err
 }
 
 func (k Keeper) UpdateResourceNode(ctx sdk.Context, description types.Description, nodeType types.NodeType,
-	networkAddr stratos.SdsAddress, ownerAddr sdk.AccAddress) error {
+	networkAddr stratos.SdsAddress, ownerAddr sdk.AccAddress, commissionRate *sdk.Dec, beneficiaries []sdk.AccAddress,
+	clearBeneficiaries bool) error {
 
 	node, found := k.GetResourceNode(ctx, networkAddr)
 	if !found {
@@ -241,6 +260,21 @@ func (k Keeper) UpdateResourceNode(ctx sdk.Context, description types.Descriptio
 	node.Description = description
 	node.NodeType = nodeType
 
+	// a nil commission rate keeps the current one
+	if commissionRate != nil {
+		commission, err := node.Commission.UpdateRate(*commissionRate, ctx.BlockHeader().Time)
+		if err != nil {
+			return err
+		}
+		node.Commission = commission
+	}
+	// empty beneficiaries keep the current ones, unless they are cleared
+	if clearBeneficiaries {
+		node.Beneficiaries = nil
+	} else if len(beneficiaries) > 0 {
+		node.Beneficiaries = beneficiaries
+	}
+
 	k.SetResourceNode(ctx, node)
 
 	return nil
@@ -59,3 +59,52 @@ func (k Keeper) GetSlashing(ctx sdk.Context, walletAddress sdk.AccAddress) (res
 	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &res)
 	return
 }
+
+// SlashResourceNodeStake burns the fraction of the stake of the resource node, including the balance of its unbonding entries
+// and the delegations to it, and returns the amount slashed. The bonded & not bonded pools are reduced accordingly.
+func (k Keeper) SlashResourceNodeStake(ctx sdk.Context, resourceNode types.ResourceNode, fraction sdk.Dec) (slashed sdk.Int, err error) {
+	if !fraction.IsPositive() || !resourceNode.GetTokens().IsPositive() {
+		return sdk.ZeroInt(), nil
+	}
+	bondDenom := k.BondDenom(ctx)
+	notBondedSlashed := sdk.ZeroInt()
+
+	// slash the unbonding entries, their tokens are already in the not bonded pool
+	unbondingTotal := sdk.ZeroInt()
+	ubd, found := k.GetUnbondingNode(ctx, resourceNode.GetNetworkAddr())
+	if found {
+		for i, entry := range ubd.Entries {
+			unbondingTotal = unbondingTotal.Add(entry.Balance)
+			entrySlashed := entry.Balance.ToDec().Mul(fraction).TruncateInt()
+			ubd.Entries[i].Balance = entry.Balance.Sub(entrySlashed)
+			notBondedSlashed = notBondedSlashed.Add(entrySlashed)
+		}
+		k.SetUnbondingNode(ctx, ubd)
+	}
+
+	// slash the remaining stake of the node
+	stakeSlashed := resourceNode.GetTokens().Sub(unbondingTotal).ToDec().Mul(fraction).TruncateInt()
+	if resourceNode.GetStatus() == sdk.Bonded {
+		bondedTokenInPool := k.GetResourceNodeBondedToken(ctx)
+		if bondedTokenInPool.Amount.LT(stakeSlashed) {
+			return sdk.ZeroInt(), types.ErrInsufficientBalanceOfBondedPool
+		}
+		k.SetResourceNodeBondedToken(ctx, bondedTokenInPool.Sub(sdk.NewCoin(bondDenom, stakeSlashed)))
+		k.decreaseOzoneLimitBySubtractStake(ctx, stakeSlashed)
+	} else {
+		notBondedSlashed = notBondedSlashed.Add(stakeSlashed)
+	}
+
+	notBondedTokenInPool := k.GetResourceNodeNotBondedToken(ctx)
+	if notBondedTokenInPool.Amount.LT(notBondedSlashed) {
+		return sdk.ZeroInt(), types.ErrInsufficientBalanceOfNotBondedPool
+	}
+	k.SetResourceNodeNotBondedToken(ctx, notBondedTokenInPool.Sub(sdk.NewCoin(bondDenom, notBondedSlashed)))
+
+	slashed = stakeSlashed.Add(notBondedSlashed)
+	resourceNode = resourceNode.SubToken(slashed)
+	k.SetResourceNode(ctx, resourceNode)
+	// delegators bear their share of the slashed stake
+	k.slashDelegations(ctx, resourceNode.GetNetworkAddr(), fraction)
+	return slashed, nil
+}
@@ -0,0 +1,143 @@
+package keeper
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	stratos "github.com/stratosnet/stratos-chain/types"
+	"github.com/stratosnet/stratos-chain/x/register/exported"
+	"github.com/stratosnet/stratos-chain/x/register/types"
+)
+
+func (k Keeper) GetNodeSuspendedTime(ctx sdk.Context, networkAddr stratos.SdsAddress) (suspendedTime time.Time, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetNodeSuspendedTimeKey(networkAddr))
+	if bz == nil {
+		return suspendedTime, false
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &suspendedTime)
+	return suspendedTime, true
+}
+
+func (k Keeper) SetNodeSuspendedTime(ctx sdk.Context, networkAddr stratos.SdsAddress, suspendedTime time.Time) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(suspendedTime)
+	store.Set(types.GetNodeSuspendedTimeKey(networkAddr), bz)
+}
+
+func (k Keeper) DeleteNodeSuspendedTime(ctx sdk.Context, networkAddr stratos.SdsAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetNodeSuspendedTimeKey(networkAddr))
+}
+
+func (k Keeper) GetNodeSuspendedUntil(ctx sdk.Context, networkAddr stratos.SdsAddress) (suspendedUntil time.Time, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetNodeSuspendedUntilKey(networkAddr))
+	if bz == nil {
+		return suspendedUntil, false
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &suspendedUntil)
+	return suspendedUntil, true
+}
+
+// SetNodeSuspendedUntil records the time the suspension of a slashed node expires, the owner can not unsuspend it before
+func (k Keeper) SetNodeSuspendedUntil(ctx sdk.Context, networkAddr stratos.SdsAddress, suspendedUntil time.Time) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(suspendedUntil)
+	store.Set(types.GetNodeSuspendedUntilKey(networkAddr), bz)
+}
+
+func (k Keeper) DeleteNodeSuspendedUntil(ctx sdk.Context, networkAddr stratos.SdsAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetNodeSuspendedUntilKey(networkAddr))
+}
+
+// GetAllNodeSuspendedTimes get the set of all suspended times of the nodes, used during genesis dump
+func (k Keeper) GetAllNodeSuspendedTimes(ctx sdk.Context) (suspendedTimes []types.NodeSuspendedTime) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.NodeSuspendedTimeKey)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var suspendedTime time.Time
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &suspendedTime)
+		networkAddr := stratos.SdsAddress(iterator.Key()[len(types.NodeSuspendedTimeKey):])
+		suspendedUntil, _ := k.GetNodeSuspendedUntil(ctx, networkAddr)
+		suspendedTimes = append(suspendedTimes, types.NewNodeSuspendedTime(networkAddr, suspendedTime, suspendedUntil))
+	}
+	return suspendedTimes
+}
+
+// ClearNodeSuspension sets the node back to the unsuspended status and calls the AfterNodeUnsuspended hook
+func (k Keeper) ClearNodeSuspension(ctx sdk.Context, networkAddr stratos.SdsAddress, isIndexingNode bool) error {
+	if isIndexingNode {
+		node, found := k.GetIndexingNode(ctx, networkAddr)
+		if !found {
+			return types.ErrNoIndexingNodeFound
+		}
+		node.Suspend = false
+		k.SetIndexingNode(ctx, node)
+	} else {
+		node, found := k.GetResourceNode(ctx, networkAddr)
+		if !found {
+			return types.ErrNoResourceNodeFound
+		}
+		node.Suspend = false
+		k.SetResourceNode(ctx, node)
+	}
+	k.DeleteNodeSuspendedTime(ctx, networkAddr)
+	k.DeleteNodeSuspendedUntil(ctx, networkAddr)
+	k.AfterNodeUnsuspended(ctx, networkAddr, isIndexingNode)
+	return nil
+}
+
+/*
+	UnsuspendNode is called by the owner of a suspended node to bring it back to service.
+
+	The node can be unsuspended when:
+	1, it is bonded and currently suspended.
+	2, the min suspend period has passed since it was suspended,
+	   and the suspension set by the slashing of the node has expired.
+	3, the slashing debt of its owner has been cleared.
+*/
+func (k Keeper) UnsuspendNode(ctx sdk.Context, networkAddr stratos.SdsAddress, ownerAddr sdk.AccAddress, isIndexingNode bool) error {
+	var node exported.IndexingNodeI
+	if isIndexingNode {
+		indexingNode, found := k.GetIndexingNode(ctx, networkAddr)
+		if !found {
+			return types.ErrNoIndexingNodeFound
+		}
+		node = indexingNode
+	} else {
+		resourceNode, found := k.GetResourceNode(ctx, networkAddr)
+		if !found {
+			return types.ErrNoResourceNodeFound
+		}
+		node = resourceNode
+	}
+
+	if !node.GetOwnerAddr().Equals(ownerAddr) {
+		return types.ErrInvalidOwnerAddr
+	}
+	if !node.IsSuspended() {
+		return types.ErrNodeNotSuspended
+	}
+	if node.GetStatus() != sdk.Bonded {
+		return types.ErrNodeNotBonded
+	}
+	if suspendedTime, found := k.GetNodeSuspendedTime(ctx, networkAddr); found {
+		if ctx.BlockHeader().Time.Before(suspendedTime.Add(k.MinSuspendPeriod(ctx))) {
+			return types.ErrMinSuspendPeriod
+		}
+	}
+	if suspendedUntil, found := k.GetNodeSuspendedUntil(ctx, networkAddr); found {
+		if ctx.BlockHeader().Time.Before(suspendedUntil) {
+			return types.ErrSuspensionNotExpired
+		}
+	}
+	if k.GetSlashing(ctx, ownerAddr).IsPositive() {
+		return types.ErrSlashingNotCleared
+	}
+
+	return k.ClearNodeSuspension(ctx, networkAddr, isIndexingNode)
+}
@@ -0,0 +1,79 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/stratosnet/stratos-chain/x/register/types"
+)
+
+// GetUozSnapshot returns the uoz snapshot taken at the height
+func (k Keeper) GetUozSnapshot(ctx sdk.Context, height int64) (snapshot types.UozSnapshot, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetUozSnapshotKey(height))
+	if bz == nil {
+		return snapshot, false
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &snapshot)
+	return snapshot, true
+}
+
+func (k Keeper) SetUozSnapshot(ctx sdk.Context, snapshot types.UozSnapshot) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(snapshot)
+	store.Set(types.GetUozSnapshotKey(snapshot.Height), bz)
+}
+
+// GetUozSnapshots returns the uoz snapshots taken from fromHeight to toHeight, both included
+func (k Keeper) GetUozSnapshots(ctx sdk.Context, fromHeight, toHeight int64) (snapshots []types.UozSnapshot) {
+	store := ctx.KVStore(k.storeKey)
+	iter := store.Iterator(types.GetUozSnapshotKey(fromHeight), types.GetUozSnapshotKey(toHeight+1))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var snapshot types.UozSnapshot
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &snapshot)
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots
+}
+
+// GetAllUozSnapshots returns all the uoz snapshots of the history
+func (k Keeper) GetAllUozSnapshots(ctx sdk.Context) (snapshots []types.UozSnapshot) {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, types.UozSnapshotKey)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var snapshot types.UozSnapshot
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &snapshot)
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots
+}
+
+// TrackUozHistory is called in BeginBlocker. The uoz price & supply at the beginning of the block is recorded,
+// the snapshots older than UozHistoryEntries blocks are pruned so that the history stays a bounded ring.
+func (k Keeper) TrackUozHistory(ctx sdk.Context) {
+	entries := int64(k.UozHistoryEntries(ctx))
+
+	// prune the snapshots out of the history, more than one are pruned when the param is lowered
+	if pruneHeight := ctx.BlockHeight() - entries; pruneHeight >= 0 {
+		store := ctx.KVStore(k.storeKey)
+		var pruned [][]byte
+		iter := store.Iterator(types.UozSnapshotKey, types.GetUozSnapshotKey(pruneHeight+1))
+		for ; iter.Valid(); iter.Next() {
+			pruned = append(pruned, iter.Key())
+		}
+		iter.Close()
+		for _, key := range pruned {
+			store.Delete(key)
+		}
+	}
+
+	// the price is undefined until stake is bonded & uoz is issued
+	if entries == 0 || !k.GetInitialGenesisStakeTotal(ctx).IsPositive() || !k.GetRemainingOzoneLimit(ctx).IsPositive() {
+		return
+	}
+	remaining, total := k.UozSupply(ctx)
+	snapshot := types.NewUozSnapshot(ctx.BlockHeight(), ctx.BlockTime(), k.CurrUozPrice(ctx), remaining, total,
+		k.GetTotalUnissuedPrepay(ctx).Amount)
+	k.SetUozSnapshot(ctx, snapshot)
+}
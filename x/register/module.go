@@ -95,7 +95,9 @@ func (AppModule) Name() string {
 }
 
 // RegisterInvariants registers the register module invariants.
-func (am AppModule) RegisterInvariants(_ sdk.InvariantRegistry) {}
+func (am AppModule) RegisterInvariants(ir sdk.InvariantRegistry) {
+	keeper.RegisterInvariants(ir, am.keeper)
+}
 
 // Route returns the message routing key for the register module.
 func (AppModule) Route() string {
@@ -17,6 +17,12 @@ func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgUpdateIndexingNodeStake{}, "register/UpdateIndexingNodeStakeTx", nil)
 
 	cdc.RegisterConcrete(MsgIndexingNodeRegistrationVote{}, "register/MsgIndexingNodeRegistrationVote", nil)
+
+	cdc.RegisterConcrete(MsgUnsuspendNode{}, "register/UnsuspendNodeTx", nil)
+
+	cdc.RegisterConcrete(MsgDelegate{}, "register/DelegateTx", nil)
+	cdc.RegisterConcrete(MsgUndelegate{}, "register/UndelegateTx", nil)
+	cdc.RegisterConcrete(MsgRedelegateNodeStake{}, "register/RedelegateNodeStakeTx", nil)
 }
 
 // ModuleCdc defines the module codec
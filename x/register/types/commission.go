@@ -0,0 +1,123 @@
+package types
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	// MaxBeneficiaries is the maximum number of beneficiaries sharing the rewards of a node
+	MaxBeneficiaries = 10
+	// CommissionUpdatePeriod is the minimum period between two changes of the commission rate
+	CommissionUpdatePeriod = 24 * time.Hour
+)
+
+// CommissionRates defines the commission chosen by the owner of a node
+type CommissionRates struct {
+	Rate          sdk.Dec `json:"rate" yaml:"rate"`                       // share of the node rewards kept by the owner, the remainder goes to the beneficiaries
+	MaxChangeRate sdk.Dec `json:"max_change_rate" yaml:"max_change_rate"` // maximum change of the rate per day, fixed once the node is created
+}
+
+// NewCommissionRates creates a new CommissionRates object
+func NewCommissionRates(rate, maxChangeRate sdk.Dec) CommissionRates {
+	return CommissionRates{
+		Rate:          rate,
+		MaxChangeRate: maxChangeRate,
+	}
+}
+
+// DefaultCommissionRates lets the owner keep all the rewards of the node
+func DefaultCommissionRates() CommissionRates {
+	return NewCommissionRates(sdk.OneDec(), sdk.OneDec())
+}
+
+// IsEmpty returns true when the rates are not set, in which case the default rates apply
+func (cr CommissionRates) IsEmpty() bool {
+	return cr.Rate.IsNil() && cr.MaxChangeRate.IsNil()
+}
+
+// Validate performs a stateless validation of the commission rates
+func (cr CommissionRates) Validate() error {
+	for _, rate := range []sdk.Dec{cr.Rate, cr.MaxChangeRate} {
+		if rate.IsNil() || rate.IsNegative() || rate.GT(sdk.OneDec()) {
+			return ErrInvalidCommissionRate
+		}
+	}
+	return nil
+}
+
+func (cr CommissionRates) String() string {
+	return fmt.Sprintf(`Rate: %s, MaxChangeRate: %s`, cr.Rate, cr.MaxChangeRate)
+}
+
+// Commission defines the commission of a node and the last time its rate was changed
+type Commission struct {
+	CommissionRates `json:"commission_rates" yaml:"commission_rates"`
+	UpdateTime      time.Time `json:"update_time" yaml:"update_time"`
+}
+
+// NewCommission creates a new Commission object
+func NewCommission(rates CommissionRates, updateTime time.Time) Commission {
+	return Commission{
+		CommissionRates: rates,
+		UpdateTime:      updateTime,
+	}
+}
+
+// ValidateNewRate checks the rate can be changed at the block time, i.e. the last change was at least
+// one day ago and the rate does not change by more than the max change rate
+func (c Commission) ValidateNewRate(newRate sdk.Dec, blockTime time.Time) error {
+	if newRate.IsNil() || newRate.IsNegative() || newRate.GT(sdk.OneDec()) {
+		return ErrInvalidCommissionRate
+	}
+	if blockTime.Sub(c.UpdateTime) < CommissionUpdatePeriod {
+		return ErrCommissionUpdateTime
+	}
+	if !c.Rate.IsNil() && !c.MaxChangeRate.IsNil() && newRate.Sub(c.Rate).Abs().GT(c.MaxChangeRate) {
+		return ErrCommissionGTMaxChangeRate
+	}
+	return nil
+}
+
+// UpdateRate returns the commission with the new rate, once checked against the last change.
+// Nodes created without commission start from the default rates.
+func (c Commission) UpdateRate(newRate sdk.Dec, blockTime time.Time) (Commission, error) {
+	if c.IsEmpty() {
+		c = NewCommission(DefaultCommissionRates(), time.Time{})
+	}
+	if err := c.ValidateNewRate(newRate, blockTime); err != nil {
+		return c, err
+	}
+	c.Rate = newRate
+	c.UpdateTime = blockTime
+	return c, nil
+}
+
+// CommissionOf returns the part of the reward kept by the owner of the node. Nodes without commission rate keep all the reward.
+func (c Commission) CommissionOf(reward sdk.Int) sdk.Int {
+	if c.Rate.IsNil() {
+		return reward
+	}
+	return reward.ToDec().Mul(c.Rate).TruncateInt()
+}
+
+func (c Commission) String() string {
+	return fmt.Sprintf(`%s, UpdateTime: %s`, c.CommissionRates, c.UpdateTime)
+}
+
+// ValidateBeneficiaries checks the beneficiaries of a node are distinct and not too many
+func ValidateBeneficiaries(beneficiaries []sdk.AccAddress) error {
+	if len(beneficiaries) > MaxBeneficiaries {
+		return ErrInvalidBeneficiaries
+	}
+	seen := make(map[string]bool)
+	for _, beneficiary := range beneficiaries {
+		if beneficiary.Empty() || seen[beneficiary.String()] {
+			return ErrInvalidBeneficiaries
+		}
+		seen[beneficiary.String()] = true
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	stratos "github.com/stratosnet/stratos-chain/types"
+)
+
+// Delegation records the tokens a third party has delegated to a resource node or an indexing node
+type Delegation struct {
+	DelegatorAddress sdk.AccAddress     `json:"delegator_address" yaml:"delegator_address"`
+	NetworkAddr      stratos.SdsAddress `json:"network_address" yaml:"network_address"`
+	IsIndexingNode   bool               `json:"is_indexing_node" yaml:"is_indexing_node"`
+	Amount           sdk.Int            `json:"amount" yaml:"amount"` // ustos delegated to the node, still bonded
+}
+
+// NewDelegation creates a new Delegation object
+func NewDelegation(delegatorAddr sdk.AccAddress, networkAddr stratos.SdsAddress, isIndexingNode bool, amount sdk.Int) Delegation {
+	return Delegation{
+		DelegatorAddress: delegatorAddr,
+		NetworkAddr:      networkAddr,
+		IsIndexingNode:   isIndexingNode,
+		Amount:           amount,
+	}
+}
+
+// Validate performs a stateless validation of the delegation
+func (d Delegation) Validate() error {
+	if d.DelegatorAddress.Empty() {
+		return ErrEmptyDelegatorAddr
+	}
+	if d.NetworkAddr.Empty() {
+		return ErrInvalidNetworkAddr
+	}
+	if d.Amount.IsNil() || !d.Amount.IsPositive() {
+		return ErrValueNegative
+	}
+	return nil
+}
+
+// String returns a human readable string representation of a Delegation.
+func (d Delegation) String() string {
+	return fmt.Sprintf(`Delegation:
+  Delegator:        %s
+  NetworkAddr:      %s
+  IsIndexingNode:   %t
+  Amount:           %s`, d.DelegatorAddress, d.NetworkAddr, d.IsIndexingNode, d.Amount)
+}
+
+// Delegations is a collection of Delegation
+type Delegations []Delegation
+
+func (ds Delegations) String() (out string) {
+	for _, d := range ds {
+		out += d.String() + "\n"
+	}
+	return strings.TrimSpace(out)
+}
+
+// TotalAmount returns the sum of the delegated tokens
+func (ds Delegations) TotalAmount() sdk.Int {
+	total := sdk.ZeroInt()
+	for _, d := range ds {
+		total = total.Add(d.Amount)
+	}
+	return total
+}
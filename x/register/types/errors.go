@@ -49,4 +49,28 @@ var (
 	ErrTotalUnissuedPrepay                = sdkerrors.Register(ModuleName, 42, "total unissued prepay must be non-negative")
 	ErrInvalidNodeType                    = sdkerrors.Register(ModuleName, 43, "invalid node type")
 	ErrUnknownAccountAddress              = sdkerrors.Register(ModuleName, 44, "account address does not exist")
+	ErrInvalidBLSPubKey                   = sdkerrors.Register(ModuleName, 45, "invalid BLS public key")
+	ErrInvalidBLSProofOfPossession        = sdkerrors.Register(ModuleName, 46, "invalid BLS proof of possession")
+	ErrBLSPubKeyExists                    = sdkerrors.Register(ModuleName, 47, "BLS public key already registered by another indexing node")
+	ErrNodeNotSuspended                   = sdkerrors.Register(ModuleName, 48, "node is not suspended")
+	ErrMinSuspendPeriod                   = sdkerrors.Register(ModuleName, 49, "min suspend period is not over")
+	ErrSlashingNotCleared                 = sdkerrors.Register(ModuleName, 50, "slashing debt of the owner is not cleared")
+	ErrNodeNotBonded                      = sdkerrors.Register(ModuleName, 51, "node is not bonded")
+	ErrDuplicateVotePool                  = sdkerrors.Register(ModuleName, 52, "duplicate registration vote pool of indexing node")
+	ErrEmptyDelegatorAddr                 = sdkerrors.Register(ModuleName, 53, "missing delegator address")
+	ErrNoDelegationFound                  = sdkerrors.Register(ModuleName, 54, "delegation does not exist")
+	ErrInsufficientDelegation             = sdkerrors.Register(ModuleName, 55, "insufficient delegation")
+	ErrDelegatedStake                     = sdkerrors.Register(ModuleName, 56, "can not remove stake delegated by others")
+	ErrSelfDelegation                     = sdkerrors.Register(ModuleName, 57, "owner can not delegate to its own node")
+	ErrInvalidCommissionRate              = sdkerrors.Register(ModuleName, 58, "commission rate must be between 0 and 1")
+	ErrCommissionUpdateTime               = sdkerrors.Register(ModuleName, 59, "commission rate cannot be changed more than once a day")
+	ErrCommissionGTMaxChangeRate          = sdkerrors.Register(ModuleName, 60, "commission rate change cannot exceed the max change rate")
+	ErrInvalidBeneficiaries               = sdkerrors.Register(ModuleName, 61, "invalid beneficiaries")
+	ErrSelfRedelegation                   = sdkerrors.Register(ModuleName, 62, "cannot redelegate to the same node")
+	ErrTransitiveRedelegation             = sdkerrors.Register(ModuleName, 63, "redelegation to this node is in progress, the stake can not be redelegated again")
+	ErrMaxRedelegationEntries             = sdkerrors.Register(ModuleName, 64, "too many redelegation entries for the node pair")
+	ErrNewIndexingNodeRedelegation        = sdkerrors.Register(ModuleName, 65, "stake can not be redelegated to an unregistered indexing node")
+	ErrNoRedelegationFound                = sdkerrors.Register(ModuleName, 66, "redelegation does not exist")
+	ErrSuspensionNotExpired               = sdkerrors.Register(ModuleName, 67, "suspension of the node has not expired")
+	ErrNodeSuspended                      = sdkerrors.Register(ModuleName, 68, "node is suspended")
 )
@@ -16,6 +16,12 @@ const (
 	EventTypeUpdateIndexingNode           = "update_indexing_node"
 	EventTypeUpdateIndexingNodeStake      = "update_indexing_node_stake"
 	EventTypeIndexingNodeRegistrationVote = "indexing_node_reg_vote"
+	EventTypeUnsuspendNode                = "unsuspend_node"
+	EventTypeExpireIndexingNodeRegVote    = "expire_indexing_node_reg_vote"
+	EventTypeDelegate                     = "delegate"
+	EventTypeUndelegate                   = "undelegate"
+	EventTypeRedelegateNodeStake          = "redelegate_node_stake"
+	EventTypeCompleteRedelegation         = "complete_redelegation"
 
 	AttributeKeyResourceNode            = "resource_node"
 	AttributeKeyIndexingNode            = "indexing_node"
@@ -25,14 +31,19 @@ const (
 	AttributeKeyVoterNetworkAddress     = "voter_network_address"
 	AttributeKeyCandidateStatus         = "candidate_status"
 	AttributeKeyIsIndexingNode          = "is_indexing_node"
+	AttributeKeyDelegator               = "delegator"
+	AttributeKeySrcNetworkAddress       = "src_network_address"
+	AttributeKeyDstNetworkAddress       = "dst_network_address"
 
 	AttributeKeyUnbondingMatureTime = "unbonding_mature_time"
+	AttributeKeyCompletionTime      = "completion_time"
 
 	AttributeKeyOZoneLimitChanges = "ozone_limit_changes"
 	AttributeKeyInitialStake      = "initial_stake"
 	AttributeKeyStakeDelta        = "stake_delta"
 	AttributeKeyStakeToRemove     = "stake_to_remove"
 	AttributeKeyIncrStakeBool     = "incr_stake"
+	AttributeKeyAmount            = "amount"
 
 	AttributeValueCategory = ModuleName
 )
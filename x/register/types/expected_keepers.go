@@ -57,6 +57,7 @@ type RegisterHooks interface {
 
 	AfterNodeBonded(ctx sdk.Context, networkAddr stratos.SdsAddress, isIndexingNode bool)         // Must be called when a node is bonded
 	AfterNodeBeginUnbonding(ctx sdk.Context, networkAddr stratos.SdsAddress, isIndexingNode bool) // Must be called when a node begins unbonding
+	AfterNodeUnsuspended(ctx sdk.Context, networkAddr stratos.SdsAddress, isIndexingNode bool)    // Must be called when a node is unsuspended
 
 	//BeforeNodeCreated(ctx sdk.Context, networkAddr sdk.AccAddress, isIndexingNode bool)  // Must be called when a node is created
 	//BeforeNodeModified(ctx sdk.Context, networkAddr sdk.AccAddress, isIndexingNode bool) // Must be called when a node's shares are modified
@@ -2,6 +2,7 @@ package types
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -10,12 +11,27 @@ import (
 
 // GenesisState - all register state that must be provided at genesis
 type GenesisState struct {
-	Params              Params        `json:"params" yaml:"params"`
-	ResourceNodes       ResourceNodes `json:"resource_nodes" yaml:"resource_nodes"`
-	IndexingNodes       IndexingNodes `json:"indexing_nodes" yaml:"indexing_nodes"`
-	InitialUozPrice     sdk.Dec       `json:"initial_uoz_price" yaml:"initial_uoz_price"` //initial price of uoz
-	TotalUnissuedPrepay sdk.Int       `json:"total_unissued_prepay" yaml:"total_unissued_prepay"`
-	SlashingInfo        []Slashing    `json:"slashing_info" yaml:"slashing_info"`
+	Params              Params                             `json:"params" yaml:"params"`
+	ResourceNodes       ResourceNodes                      `json:"resource_nodes" yaml:"resource_nodes"`
+	IndexingNodes       IndexingNodes                      `json:"indexing_nodes" yaml:"indexing_nodes"`
+	InitialUozPrice     sdk.Dec                            `json:"initial_uoz_price" yaml:"initial_uoz_price"` //initial price of uoz
+	TotalUnissuedPrepay sdk.Int                            `json:"total_unissued_prepay" yaml:"total_unissued_prepay"`
+	SlashingInfo        []Slashing                         `json:"slashing_info" yaml:"slashing_info"`
+	VotePools           []IndexingNodeRegistrationVotePool `json:"indexing_node_reg_vote_pools" yaml:"indexing_node_reg_vote_pools"`
+	UnbondingNodes      []UnbondingNode                    `json:"unbonding_nodes" yaml:"unbonding_nodes"`
+	NodeSuspendedTimes  []NodeSuspendedTime                `json:"node_suspended_times" yaml:"node_suspended_times"`
+	OzoneBalances       []OzoneBalance                     `json:"ozone_balances" yaml:"ozone_balances"`
+	Delegations         Delegations                        `json:"delegations" yaml:"delegations"`
+	Redelegations       Redelegations                      `json:"redelegations" yaml:"redelegations"`
+	UozSnapshots        []UozSnapshot                      `json:"uoz_snapshots" yaml:"uoz_snapshots"`
+
+	// the following values are computed from the nodes when they are not provided
+	ResourceNodeBondedToken    sdk.Int `json:"resource_node_bonded_token" yaml:"resource_node_bonded_token"`
+	ResourceNodeNotBondedToken sdk.Int `json:"resource_node_not_bonded_token" yaml:"resource_node_not_bonded_token"`
+	IndexingNodeBondedToken    sdk.Int `json:"indexing_node_bonded_token" yaml:"indexing_node_bonded_token"`
+	IndexingNodeNotBondedToken sdk.Int `json:"indexing_node_not_bonded_token" yaml:"indexing_node_not_bonded_token"`
+	InitialGenesisStakeTotal   sdk.Int `json:"initial_genesis_stake_total" yaml:"initial_genesis_stake_total"`
+	RemainingOzoneLimit        sdk.Int `json:"remaining_ozone_limit" yaml:"remaining_ozone_limit"`
 }
 
 // NewGenesisState creates a new GenesisState object
@@ -25,6 +41,7 @@ func NewGenesisState(params Params,
 	initialUOzonePrice sdk.Dec,
 	totalUnissuedPrepay sdk.Int,
 	slashingInfo []Slashing,
+	votePools []IndexingNodeRegistrationVotePool,
 ) GenesisState {
 	return GenesisState{
 		Params:              params,
@@ -33,6 +50,7 @@ func NewGenesisState(params Params,
 		InitialUozPrice:     initialUOzonePrice,
 		TotalUnissuedPrepay: totalUnissuedPrepay,
 		SlashingInfo:        slashingInfo,
+		VotePools:           votePools,
 	}
 }
 
@@ -43,6 +61,7 @@ func DefaultGenesisState() GenesisState {
 		InitialUozPrice:     DefaultUozPrice,
 		TotalUnissuedPrepay: DefaultTotalUnissuedPrepay,
 		SlashingInfo:        make([]Slashing, 0),
+		VotePools:           make([]IndexingNodeRegistrationVotePool, 0),
 	}
 }
 
@@ -76,9 +95,116 @@ func ValidateGenesis(data GenesisState) error {
 	if data.TotalUnissuedPrepay.LT(sdk.ZeroInt()) {
 		return ErrInitialUOzonePrice
 	}
+
+	votePoolAddrs := make(map[string]bool)
+	for _, votePool := range data.VotePools {
+		if votePool.NodeAddress.Empty() {
+			return ErrEmptyIndexingNodeAddr
+		}
+		if votePoolAddrs[votePool.NodeAddress.String()] {
+			return ErrDuplicateVotePool
+		}
+		votePoolAddrs[votePool.NodeAddress.String()] = true
+	}
+
+	for _, ozoneBalance := range data.OzoneBalances {
+		if ozoneBalance.WalletAddress.Empty() {
+			return ErrInvalidOwnerAddr
+		}
+		if ozoneBalance.Balance.IsNil() || ozoneBalance.Balance.IsNegative() {
+			return ErrValueNegative
+		}
+	}
+
+	delegationKeys := make(map[string]bool)
+	for _, delegation := range data.Delegations {
+		if err := delegation.Validate(); err != nil {
+			return err
+		}
+		key := string(GetDelegationKey(delegation.NetworkAddr, delegation.DelegatorAddress))
+		if delegationKeys[key] {
+			return ErrInvalid
+		}
+		delegationKeys[key] = true
+	}
+
+	redelegationKeys := make(map[string]bool)
+	for _, red := range data.Redelegations {
+		if err := red.Validate(); err != nil {
+			return err
+		}
+		key := string(GetRedelegationKey(red.SrcNetworkAddr, red.DstNetworkAddr))
+		if redelegationKeys[key] {
+			return ErrInvalid
+		}
+		redelegationKeys[key] = true
+	}
+
+	for _, ubd := range data.UnbondingNodes {
+		if ubd.NetworkAddr.Empty() {
+			return ErrInvalidNetworkAddr
+		}
+		for _, entry := range ubd.Entries {
+			if entry.Balance.IsNegative() {
+				return ErrValueNegative
+			}
+		}
+	}
+
+	snapshotHeights := make(map[int64]bool)
+	for _, snapshot := range data.UozSnapshots {
+		if snapshot.Height < 0 || snapshotHeights[snapshot.Height] {
+			return ErrInvalid
+		}
+		snapshotHeights[snapshot.Height] = true
+		if snapshot.UozPrice.IsNil() || !snapshot.UozPrice.IsPositive() {
+			return ErrInvalid
+		}
+		for _, value := range []sdk.Int{snapshot.RemainingUoz, snapshot.TotalUoz, snapshot.TotalUnissuedPrepay} {
+			if value.IsNil() || value.IsNegative() {
+				return ErrValueNegative
+			}
+		}
+	}
+
+	for _, value := range []sdk.Int{data.ResourceNodeBondedToken, data.ResourceNodeNotBondedToken, data.IndexingNodeBondedToken,
+		data.IndexingNodeNotBondedToken, data.InitialGenesisStakeTotal, data.RemainingOzoneLimit} {
+		if !value.IsNil() && value.IsNegative() {
+			return ErrValueNegative
+		}
+	}
 	return nil
 }
 
+// NodeSuspendedTime records the time a suspended node has been suspended,
+// and the time its suspension expires when it has been suspended for a fixed duration
+type NodeSuspendedTime struct {
+	NetworkAddr    stratos.SdsAddress `json:"network_address" yaml:"network_address"`
+	SuspendedTime  time.Time          `json:"suspended_time" yaml:"suspended_time"`
+	SuspendedUntil time.Time          `json:"suspended_until" yaml:"suspended_until"`
+}
+
+func NewNodeSuspendedTime(networkAddr stratos.SdsAddress, suspendedTime, suspendedUntil time.Time) NodeSuspendedTime {
+	return NodeSuspendedTime{
+		NetworkAddr:    networkAddr,
+		SuspendedTime:  suspendedTime,
+		SuspendedUntil: suspendedUntil,
+	}
+}
+
+// OzoneBalance records the remaining uoz of a wallet
+type OzoneBalance struct {
+	WalletAddress sdk.AccAddress `json:"wallet_address" yaml:"wallet_address"`
+	Balance       sdk.Int        `json:"balance" yaml:"balance"`
+}
+
+func NewOzoneBalance(walletAddress sdk.AccAddress, balance sdk.Int) OzoneBalance {
+	return OzoneBalance{
+		WalletAddress: walletAddress,
+		Balance:       balance,
+	}
+}
+
 type GenesisIndexingNode struct {
 	NetworkAddr  string         `json:"network_address" yaml:"network_address"` // network address of the indexing node
 	PubKey       string         `json:"pubkey" yaml:"pubkey"`                   // the consensus public key of the indexing node; bech encoded in JSON
@@ -118,6 +244,7 @@ func (v GenesisIndexingNode) ToIndexingNode() IndexingNode {
 		Tokens:       tokens,
 		OwnerAddress: ownerAddress,
 		Description:  v.Description,
+		Commission:   NewCommission(DefaultCommissionRates(), time.Time{}),
 	}
 }
 
@@ -38,3 +38,8 @@ func (h MultiRegisterHooks) AfterNodeBeginUnbonding(ctx sdk.Context, networkAddr
 		h[i].AfterNodeBeginUnbonding(ctx, networkAddr, isIndexingNode)
 	}
 }
+func (h MultiRegisterHooks) AfterNodeUnsuspended(ctx sdk.Context, networkAddr stratos.SdsAddress, isIndexingNode bool) {
+	for i := range h {
+		h[i].AfterNodeUnsuspended(ctx, networkAddr, isIndexingNode)
+	}
+}
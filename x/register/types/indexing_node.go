@@ -2,6 +2,7 @@ package types
 
 import (
 	"bytes"
+	"encoding/hex"
 	"fmt"
 	"sort"
 	"strings"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stratosnet/stratos-chain/crypto/bls"
 	stratos "github.com/stratosnet/stratos-chain/types"
 	"github.com/tendermint/tendermint/crypto"
 )
@@ -55,14 +57,17 @@ func (v IndexingNodes) Validate() error {
 }
 
 type IndexingNode struct {
-	NetworkAddr  stratos.SdsAddress `json:"network_address" yaml:"network_address"` // network address
-	PubKey       crypto.PubKey      `json:"pubkey" yaml:"pubkey"`                   // the consensus public key of the indexing node; bech encoded in JSON
-	Suspend      bool               `json:"suspend" yaml:"suspend"`                 // has the indexing node been suspended from bonded status?
-	Status       sdk.BondStatus     `json:"status" yaml:"status"`                   // indexing node status (bonded/unbonding/unbonded)
-	Tokens       sdk.Int            `json:"tokens" yaml:"tokens"`                   // delegated tokens
-	OwnerAddress sdk.AccAddress     `json:"owner_address" yaml:"owner_address"`     // owner address of the indexing node
-	Description  Description        `json:"description" yaml:"description"`         // description terms for the indexing node
-	CreationTime time.Time          `json:"creation_time" yaml:"creation_time"`
+	NetworkAddr   stratos.SdsAddress `json:"network_address" yaml:"network_address"` // network address
+	PubKey        crypto.PubKey      `json:"pubkey" yaml:"pubkey"`                   // the consensus public key of the indexing node; bech encoded in JSON
+	Suspend       bool               `json:"suspend" yaml:"suspend"`                 // has the indexing node been suspended from bonded status?
+	Status        sdk.BondStatus     `json:"status" yaml:"status"`                   // indexing node status (bonded/unbonding/unbonded)
+	Tokens        sdk.Int            `json:"tokens" yaml:"tokens"`                   // delegated tokens
+	OwnerAddress  sdk.AccAddress     `json:"owner_address" yaml:"owner_address"`     // owner address of the indexing node
+	Description   Description        `json:"description" yaml:"description"`         // description terms for the indexing node
+	CreationTime  time.Time          `json:"creation_time" yaml:"creation_time"`
+	BLSPubKey     []byte             `json:"bls_pub_key" yaml:"bls_pub_key"`     // BLS public key used to sign volume reports
+	Commission    Commission         `json:"commission" yaml:"commission"`       // commission kept by the owner on the rewards of the node
+	Beneficiaries []sdk.AccAddress   `json:"beneficiaries" yaml:"beneficiaries"` // addresses sharing the rewards of the node left after the commission
 }
 
 // NewIndexingNode - initialize a new indexing node
@@ -76,6 +81,7 @@ func NewIndexingNode(networkAddr stratos.SdsAddress, pubKey crypto.PubKey, owner
 		OwnerAddress: ownerAddr,
 		Description:  description,
 		CreationTime: creationTime,
+		Commission:   NewCommission(DefaultCommissionRates(), creationTime),
 	}
 }
 
@@ -94,7 +100,11 @@ func (v IndexingNode) String() string {
 		Owner Address: 		%s
   		Description:		%s
 		CreationTime:		%s
-	}`, v.NetworkAddr, pubKey, v.Suspend, v.Status, v.Tokens, v.OwnerAddress, v.Description, v.CreationTime)
+		BLS Pubkey:			%s
+		Commission:			%s
+		Beneficiaries:		%v
+	}`, v.NetworkAddr, pubKey, v.Suspend, v.Status, v.Tokens, v.OwnerAddress, v.Description, v.CreationTime,
+		hex.EncodeToString(v.BLSPubKey), v.Commission, v.Beneficiaries)
 }
 
 // AddToken adds tokens to a indexing node
@@ -134,7 +144,15 @@ func (v IndexingNode) Validate() error {
 	if v.Description.Moniker == "" {
 		return ErrEmptyMoniker
 	}
-	return nil
+	if len(v.BLSPubKey) > 0 && len(v.BLSPubKey) != bls.PubKeySize {
+		return ErrInvalidBLSPubKey
+	}
+	if !v.Commission.IsEmpty() {
+		if err := v.Commission.Validate(); err != nil {
+			return err
+		}
+	}
+	return ValidateBeneficiaries(v.Beneficiaries)
 }
 
 // IsBonded checks if the node status equals Bonded
@@ -162,6 +180,11 @@ func (v IndexingNode) GetNetworkAddr() stratos.SdsAddress {
 func (v IndexingNode) GetTokens() sdk.Int           { return v.Tokens }
 func (v IndexingNode) GetOwnerAddr() sdk.AccAddress { return v.OwnerAddress }
 func (v IndexingNode) GetCreationTime() time.Time   { return v.CreationTime }
+func (v IndexingNode) GetBLSPubKey() []byte         { return v.BLSPubKey }
+func (v IndexingNode) GetCommission() Commission    { return v.Commission }
+func (v IndexingNode) GetBeneficiaries() []sdk.AccAddress {
+	return v.Beneficiaries
+}
 
 // MustMarshalIndexingNode returns the indexingNode bytes. Panics if fails
 func MustMarshalIndexingNode(cdc *codec.Codec, indexingNode IndexingNode) []byte {
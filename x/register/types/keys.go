@@ -31,6 +31,8 @@ var (
 	UpperBoundOfTotalOzoneKey     = []byte{0x05}
 	TotalUnissuedPrepayKey        = []byte{0x06}
 	SlashingPrefix                = []byte{0x07}
+	OzoneBalancePrefix            = []byte{0x08} // prefix for the remaining uoz of each wallet
+	GenesisUnissuedPrepayKey      = []byte{0x09} // key of the total unissued prepay preset by the genesis
 
 	InitialGenesisStakeTotalKey = []byte{0x13} // key of initial genesis deposit by all resource nodes and meta nodes at t=0
 	InitialUOzonePriceKey       = []byte{0x14} // key of initial uoz price at t=0
@@ -42,6 +44,17 @@ var (
 	UBDNodeKey = []byte{0x31} // prefix for each key to an unbonding node
 
 	UBDNodeQueueKey = []byte{0x41} // prefix for the timestamps in unbonding node queue
+
+	NodeSuspendedTimeKey  = []byte{0x51} // prefix for the time a node has been suspended
+	NodeSuspendedUntilKey = []byte{0x52} // prefix for the time the suspension of a slashed node expires
+
+	UozSnapshotKey = []byte{0x61} // prefix for the uoz price & supply snapshot of each block
+
+	DelegationKey = []byte{0x71} // prefix for each key to a delegation
+
+	RedelegationKey           = []byte{0x81} // prefix for each key to a redelegation
+	RedelegationByDstIndexKey = []byte{0x82} // prefix for each key to the index of a redelegation by destination node
+	RedelegationQueueKey      = []byte{0x83} // prefix for the timestamps in redelegation queue
 )
 
 // GetResourceNodeKey gets the key for the resourceNode with address
@@ -76,3 +89,65 @@ func GetSlashingKey(walletAddress sdk.AccAddress) []byte {
 	key := append(SlashingPrefix, walletAddress...)
 	return key
 }
+
+// GetNodeSuspendedTimeKey gets the key for the time the node has been suspended
+// VALUE: time.Time
+func GetNodeSuspendedTimeKey(nodeAddr stratos.SdsAddress) []byte {
+	return append(NodeSuspendedTimeKey, nodeAddr.Bytes()...)
+}
+
+// GetNodeSuspendedUntilKey gets the key for the time the suspension of the node expires
+// VALUE: time.Time
+func GetNodeSuspendedUntilKey(nodeAddr stratos.SdsAddress) []byte {
+	return append(NodeSuspendedUntilKey, nodeAddr.Bytes()...)
+}
+
+// GetOzoneBalanceKey gets the key for the remaining uoz of a wallet
+// VALUE: sdk.Int
+func GetOzoneBalanceKey(walletAddress sdk.AccAddress) []byte {
+	return append(OzoneBalancePrefix, walletAddress...)
+}
+
+// GetUozSnapshotKey gets the key for the uoz snapshot taken at the height
+// VALUE: UozSnapshot
+func GetUozSnapshotKey(height int64) []byte {
+	return append(UozSnapshotKey, sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+// GetDelegationsByNodeKey gets the prefix for all the delegations to a node
+func GetDelegationsByNodeKey(nodeAddr stratos.SdsAddress) []byte {
+	return append(DelegationKey, nodeAddr.Bytes()...)
+}
+
+// GetDelegationKey gets the key for the delegation of a delegator to a node
+// VALUE: Delegation
+func GetDelegationKey(nodeAddr stratos.SdsAddress, delegatorAddr sdk.AccAddress) []byte {
+	return append(GetDelegationsByNodeKey(nodeAddr), delegatorAddr.Bytes()...)
+}
+
+// GetRedelegationsFromSrcKey gets the prefix for all the redelegations from a source node
+func GetRedelegationsFromSrcKey(srcNetworkAddr stratos.SdsAddress) []byte {
+	return append(RedelegationKey, srcNetworkAddr.Bytes()...)
+}
+
+// GetRedelegationKey gets the key for the redelegation from the source node to the destination node
+// VALUE: register/Redelegation
+func GetRedelegationKey(srcNetworkAddr, dstNetworkAddr stratos.SdsAddress) []byte {
+	return append(GetRedelegationsFromSrcKey(srcNetworkAddr), dstNetworkAddr.Bytes()...)
+}
+
+// GetRedelegationsByDstIndexKey gets the prefix for the index of all the redelegations to a destination node
+func GetRedelegationsByDstIndexKey(dstNetworkAddr stratos.SdsAddress) []byte {
+	return append(RedelegationByDstIndexKey, dstNetworkAddr.Bytes()...)
+}
+
+// GetRedelegationByDstIndexKey gets the key for the index of the redelegation by destination node
+func GetRedelegationByDstIndexKey(srcNetworkAddr, dstNetworkAddr stratos.SdsAddress) []byte {
+	return append(GetRedelegationsByDstIndexKey(dstNetworkAddr), srcNetworkAddr.Bytes()...)
+}
+
+// GetRedelegationTimeKey gets the prefix for all the redelegations maturing at the timestamp
+func GetRedelegationTimeKey(timestamp time.Time) []byte {
+	bz := sdk.FormatTimeBytes(timestamp)
+	return append(RedelegationQueueKey, bz...)
+}
@@ -2,6 +2,7 @@ package types
 
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stratosnet/stratos-chain/crypto/bls"
 	stratos "github.com/stratosnet/stratos-chain/types"
 	"github.com/tendermint/tendermint/crypto"
 )
@@ -17,28 +18,37 @@ var (
 	_ sdk.Msg = &MsgUpdateIndexingNode{}
 	_ sdk.Msg = &MsgUpdateIndexingNodeStake{}
 	_ sdk.Msg = &MsgIndexingNodeRegistrationVote{}
+	_ sdk.Msg = &MsgUnsuspendNode{}
+	_ sdk.Msg = &MsgDelegate{}
+	_ sdk.Msg = &MsgUndelegate{}
+	_ sdk.Msg = &MsgRedelegateNodeStake{}
 )
 
 type MsgCreateResourceNode struct {
-	NetworkAddr  stratos.SdsAddress `json:"network_address" yaml:"network_address"`
-	PubKey       crypto.PubKey      `json:"pubkey" yaml:"pubkey"`
-	Value        sdk.Coin           `json:"value" yaml:"value"`
-	OwnerAddress sdk.AccAddress     `json:"owner_address" yaml:"owner_address"`
-	Description  Description        `json:"description" yaml:"description"`
-	NodeType     NodeType           `json:"node_type" yaml:"node_type"`
+	NetworkAddr   stratos.SdsAddress `json:"network_address" yaml:"network_address"`
+	PubKey        crypto.PubKey      `json:"pubkey" yaml:"pubkey"`
+	Value         sdk.Coin           `json:"value" yaml:"value"`
+	OwnerAddress  sdk.AccAddress     `json:"owner_address" yaml:"owner_address"`
+	Description   Description        `json:"description" yaml:"description"`
+	NodeType      NodeType           `json:"node_type" yaml:"node_type"`
+	Commission    CommissionRates    `json:"commission" yaml:"commission"`       // empty to let the owner keep all the rewards
+	Beneficiaries []sdk.AccAddress   `json:"beneficiaries" yaml:"beneficiaries"` // addresses sharing the rewards left after the commission
 }
 
 // NewMsgCreateResourceNode NewMsg<Action> creates a new Msg<Action> instance
 func NewMsgCreateResourceNode(networkAddr stratos.SdsAddress, pubKey crypto.PubKey, value sdk.Coin,
-	ownerAddr sdk.AccAddress, description Description, nodeType NodeType,
+	ownerAddr sdk.AccAddress, description Description, nodeType NodeType, commission CommissionRates,
+	beneficiaries []sdk.AccAddress,
 ) MsgCreateResourceNode {
 	return MsgCreateResourceNode{
-		NetworkAddr:  networkAddr,
-		PubKey:       pubKey,
-		Value:        value,
-		OwnerAddress: ownerAddr,
-		Description:  description,
-		NodeType:     nodeType,
+		NetworkAddr:   networkAddr,
+		PubKey:        pubKey,
+		Value:         value,
+		OwnerAddress:  ownerAddr,
+		Description:   description,
+		NodeType:      nodeType,
+		Commission:    commission,
+		Beneficiaries: beneficiaries,
 	}
 }
 
@@ -74,7 +84,7 @@ func (msg MsgCreateResourceNode) ValidateBasic() error {
 	if msg.NodeType > 7 || msg.NodeType < 1 {
 		return ErrInvalidNodeType
 	}
-	return nil
+	return validateCommission(msg.Commission, msg.Beneficiaries)
 }
 
 func (msg MsgCreateResourceNode) GetSignBytes() []byte {
@@ -89,22 +99,31 @@ func (msg MsgCreateResourceNode) GetSigners() []sdk.AccAddress {
 }
 
 type MsgCreateIndexingNode struct {
-	NetworkAddr  stratos.SdsAddress `json:"network_addr" yaml:"network_addr"`
-	PubKey       crypto.PubKey      `json:"pubkey" yaml:"pubkey"`
-	Value        sdk.Coin           `json:"value" yaml:"value"`
-	OwnerAddress sdk.AccAddress     `json:"owner_address" yaml:"owner_address"`
-	Description  Description        `json:"description" yaml:"description"`
+	NetworkAddr          stratos.SdsAddress `json:"network_addr" yaml:"network_addr"`
+	PubKey               crypto.PubKey      `json:"pubkey" yaml:"pubkey"`
+	Value                sdk.Coin           `json:"value" yaml:"value"`
+	OwnerAddress         sdk.AccAddress     `json:"owner_address" yaml:"owner_address"`
+	Description          Description        `json:"description" yaml:"description"`
+	BLSPubKey            []byte             `json:"bls_pub_key" yaml:"bls_pub_key"`                         // BLS public key used to sign volume reports
+	BLSProofOfPossession []byte             `json:"bls_proof_of_possession" yaml:"bls_proof_of_possession"` // signature of BLSPubKey by its private key
+	Commission           CommissionRates    `json:"commission" yaml:"commission"`                           // empty to let the owner keep all the rewards
+	Beneficiaries        []sdk.AccAddress   `json:"beneficiaries" yaml:"beneficiaries"`                     // addresses sharing the rewards left after the commission
 }
 
 // NewMsgCreateIndexingNode NewMsg<Action> creates a new Msg<Action> instance
 func NewMsgCreateIndexingNode(networkAddr stratos.SdsAddress, pubKey crypto.PubKey, value sdk.Coin, ownerAddr sdk.AccAddress, description Description,
+	blsPubKey []byte, blsProofOfPossession []byte, commission CommissionRates, beneficiaries []sdk.AccAddress,
 ) MsgCreateIndexingNode {
 	return MsgCreateIndexingNode{
-		NetworkAddr:  networkAddr,
-		PubKey:       pubKey,
-		Value:        value,
-		OwnerAddress: ownerAddr,
-		Description:  description,
+		NetworkAddr:          networkAddr,
+		PubKey:               pubKey,
+		Value:                value,
+		OwnerAddress:         ownerAddr,
+		Description:          description,
+		BLSPubKey:            blsPubKey,
+		BLSProofOfPossession: blsProofOfPossession,
+		Commission:           commission,
+		Beneficiaries:        beneficiaries,
 	}
 }
 
@@ -136,7 +155,20 @@ func (msg MsgCreateIndexingNode) ValidateBasic() error {
 	if msg.Description.Moniker == "" {
 		return ErrEmptyMoniker
 	}
-	return nil
+	if err := validateCommission(msg.Commission, msg.Beneficiaries); err != nil {
+		return err
+	}
+	return validateBLSPubKey(msg.BLSPubKey, msg.BLSProofOfPossession)
+}
+
+// validateCommission checks the commission rates, when set, and the beneficiaries of a new node
+func validateCommission(commission CommissionRates, beneficiaries []sdk.AccAddress) error {
+	if !commission.IsEmpty() {
+		if err := commission.Validate(); err != nil {
+			return err
+		}
+	}
+	return ValidateBeneficiaries(beneficiaries)
 }
 
 func (msg MsgCreateIndexingNode) GetSignBytes() []byte {
@@ -240,16 +272,24 @@ type MsgUpdateResourceNode struct {
 	NodeType       NodeType           `json:"node_type" yaml:"node_type"`
 	NetworkAddress stratos.SdsAddress `json:"network_address" yaml:"network_address"`
 	OwnerAddress   sdk.AccAddress     `json:"owner_address" yaml:"owner_address"`
+	CommissionRate *sdk.Dec           `json:"commission_rate" yaml:"commission_rate"` // new commission rate, nil to keep the current one
+	Beneficiaries  []sdk.AccAddress   `json:"beneficiaries" yaml:"beneficiaries"`     // new beneficiaries, empty to keep the current ones
+	// removes the current beneficiaries, the owner then keeps all the rewards
+	ClearBeneficiaries bool `json:"clear_beneficiaries" yaml:"clear_beneficiaries"`
 }
 
 func NewMsgUpdateResourceNode(description Description, nodeType NodeType,
-	networkAddress stratos.SdsAddress, ownerAddress sdk.AccAddress) MsgUpdateResourceNode {
+	networkAddress stratos.SdsAddress, ownerAddress sdk.AccAddress, commissionRate *sdk.Dec,
+	beneficiaries []sdk.AccAddress, clearBeneficiaries bool) MsgUpdateResourceNode {
 
 	return MsgUpdateResourceNode{
-		Description:    description,
-		NodeType:       nodeType,
-		NetworkAddress: networkAddress,
-		OwnerAddress:   ownerAddress,
+		Description:        description,
+		NodeType:           nodeType,
+		NetworkAddress:     networkAddress,
+		OwnerAddress:       ownerAddress,
+		CommissionRate:     commissionRate,
+		Beneficiaries:      beneficiaries,
+		ClearBeneficiaries: clearBeneficiaries,
 	}
 }
 
@@ -285,7 +325,19 @@ func (msg MsgUpdateResourceNode) ValidateBasic() error {
 	if msg.NodeType > 7 || msg.NodeType < 1 {
 		return ErrInvalidNodeType
 	}
-	return nil
+	return validateCommissionUpdate(msg.CommissionRate, msg.Beneficiaries, msg.ClearBeneficiaries)
+}
+
+// validateCommissionUpdate checks the new commission rate and beneficiaries of a node, when set.
+// The beneficiaries can not be set and cleared at once.
+func validateCommissionUpdate(commissionRate *sdk.Dec, beneficiaries []sdk.AccAddress, clearBeneficiaries bool) error {
+	if commissionRate != nil && (commissionRate.IsNil() || commissionRate.IsNegative() || commissionRate.GT(sdk.OneDec())) {
+		return ErrInvalidCommissionRate
+	}
+	if clearBeneficiaries && len(beneficiaries) > 0 {
+		return ErrInvalidBeneficiaries
+	}
+	return ValidateBeneficiaries(beneficiaries)
 }
 
 // MsgUpdateResourceNodeStake struct for only updating resource node's stake
@@ -339,18 +391,31 @@ func (msg MsgUpdateResourceNodeStake) ValidateBasic() error {
 
 // MsgUpdateIndexingNode struct for updating indexing node
 type MsgUpdateIndexingNode struct {
-	Description    Description        `json:"description" yaml:"description"`
-	NetworkAddress stratos.SdsAddress `json:"network_address" yaml:"network_address"`
-	OwnerAddress   sdk.AccAddress     `json:"owner_address" yaml:"owner_address"`
+	Description          Description        `json:"description" yaml:"description"`
+	NetworkAddress       stratos.SdsAddress `json:"network_address" yaml:"network_address"`
+	OwnerAddress         sdk.AccAddress     `json:"owner_address" yaml:"owner_address"`
+	BLSPubKey            []byte             `json:"bls_pub_key" yaml:"bls_pub_key"`                         // new BLS public key, empty to keep the current one
+	BLSProofOfPossession []byte             `json:"bls_proof_of_possession" yaml:"bls_proof_of_possession"` // signature of BLSPubKey by its private key
+	CommissionRate       *sdk.Dec           `json:"commission_rate" yaml:"commission_rate"`                 // new commission rate, nil to keep the current one
+	Beneficiaries        []sdk.AccAddress   `json:"beneficiaries" yaml:"beneficiaries"`                     // new beneficiaries, empty to keep the current ones
+	// removes the current beneficiaries, the owner then keeps all the rewards
+	ClearBeneficiaries bool `json:"clear_beneficiaries" yaml:"clear_beneficiaries"`
 }
 
 func NewMsgUpdateIndexingNode(description Description, networkAddress stratos.SdsAddress, ownerAddress sdk.AccAddress,
+	blsPubKey []byte, blsProofOfPossession []byte, commissionRate *sdk.Dec, beneficiaries []sdk.AccAddress,
+	clearBeneficiaries bool,
 ) MsgUpdateIndexingNode {
 
 	return MsgUpdateIndexingNode{
-		Description:    description,
-		NetworkAddress: networkAddress,
-		OwnerAddress:   ownerAddress,
+		Description:          description,
+		NetworkAddress:       networkAddress,
+		OwnerAddress:         ownerAddress,
+		BLSPubKey:            blsPubKey,
+		BLSProofOfPossession: blsProofOfPossession,
+		CommissionRate:       commissionRate,
+		Beneficiaries:        beneficiaries,
+		ClearBeneficiaries:   clearBeneficiaries,
 	}
 }
 
@@ -382,6 +447,24 @@ func (msg MsgUpdateIndexingNode) ValidateBasic() error {
 	if msg.Description.Moniker == "" {
 		return ErrEmptyMoniker
 	}
+	if err := validateCommissionUpdate(msg.CommissionRate, msg.Beneficiaries, msg.ClearBeneficiaries); err != nil {
+		return err
+	}
+	if len(msg.BLSPubKey) == 0 && len(msg.BLSProofOfPossession) == 0 {
+		return nil
+	}
+	return validateBLSPubKey(msg.BLSPubKey, msg.BLSProofOfPossession)
+}
+
+// validateBLSPubKey checks the BLS public key together with its proof of possession
+func validateBLSPubKey(blsPubKey []byte, blsProofOfPossession []byte) error {
+	if len(blsPubKey) != bls.PubKeySize {
+		return ErrInvalidBLSPubKey
+	}
+	verified, err := bls.VerifyPossession(blsPubKey, blsProofOfPossession)
+	if err != nil || !verified {
+		return ErrInvalidBLSProofOfPossession
+	}
 	return nil
 }
 
@@ -491,3 +574,211 @@ func (m MsgIndexingNodeRegistrationVote) GetSigners() []sdk.AccAddress {
 	addrs = append(addrs, m.VoterOwnerAddress)
 	return addrs
 }
+
+// MsgUnsuspendNode - struct for unsuspending a resource node or an indexing node
+type MsgUnsuspendNode struct {
+	NetworkAddress stratos.SdsAddress `json:"network_address" yaml:"network_address"`
+	OwnerAddress   sdk.AccAddress     `json:"owner_address" yaml:"owner_address"`
+	IsIndexingNode bool               `json:"is_indexing_node" yaml:"is_indexing_node"`
+}
+
+// NewMsgUnsuspendNode creates a new MsgUnsuspendNode instance.
+func NewMsgUnsuspendNode(networkAddr stratos.SdsAddress, ownerAddr sdk.AccAddress, isIndexingNode bool) MsgUnsuspendNode {
+	return MsgUnsuspendNode{
+		NetworkAddress: networkAddr,
+		OwnerAddress:   ownerAddr,
+		IsIndexingNode: isIndexingNode,
+	}
+}
+
+// Route implements the sdk.Msg interface.
+func (msg MsgUnsuspendNode) Route() string { return RouterKey }
+
+// Type implements the sdk.Msg interface.
+func (msg MsgUnsuspendNode) Type() string { return "unsuspend_node" }
+
+// GetSigners implements the sdk.Msg interface.
+func (msg MsgUnsuspendNode) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.OwnerAddress}
+}
+
+// GetSignBytes implements the sdk.Msg interface.
+func (msg MsgUnsuspendNode) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic implements the sdk.Msg interface.
+func (msg MsgUnsuspendNode) ValidateBasic() error {
+	if msg.NetworkAddress.Empty() {
+		if msg.IsIndexingNode {
+			return ErrEmptyIndexingNodeAddr
+		}
+		return ErrEmptyResourceNodeAddr
+	}
+	if msg.OwnerAddress.Empty() {
+		return ErrEmptyOwnerAddr
+	}
+	return nil
+}
+
+// MsgDelegate - struct for delegating tokens to a resource node or an indexing node
+type MsgDelegate struct {
+	NetworkAddress   stratos.SdsAddress `json:"network_address" yaml:"network_address"`
+	DelegatorAddress sdk.AccAddress     `json:"delegator_address" yaml:"delegator_address"`
+	IsIndexingNode   bool               `json:"is_indexing_node" yaml:"is_indexing_node"`
+	Amount           sdk.Coin           `json:"amount" yaml:"amount"`
+}
+
+// NewMsgDelegate creates a new MsgDelegate instance.
+func NewMsgDelegate(networkAddr stratos.SdsAddress, delegatorAddr sdk.AccAddress, isIndexingNode bool, amount sdk.Coin) MsgDelegate {
+	return MsgDelegate{
+		NetworkAddress:   networkAddr,
+		DelegatorAddress: delegatorAddr,
+		IsIndexingNode:   isIndexingNode,
+		Amount:           amount,
+	}
+}
+
+// Route implements the sdk.Msg interface.
+func (msg MsgDelegate) Route() string { return RouterKey }
+
+// Type implements the sdk.Msg interface.
+func (msg MsgDelegate) Type() string { return "delegate" }
+
+// GetSigners implements the sdk.Msg interface.
+func (msg MsgDelegate) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.DelegatorAddress}
+}
+
+// GetSignBytes implements the sdk.Msg interface.
+func (msg MsgDelegate) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic implements the sdk.Msg interface.
+func (msg MsgDelegate) ValidateBasic() error {
+	return validateDelegationMsg(msg.NetworkAddress, msg.DelegatorAddress, msg.IsIndexingNode, msg.Amount)
+}
+
+// MsgUndelegate - struct for withdrawing tokens delegated to a resource node or an indexing node
+type MsgUndelegate struct {
+	NetworkAddress   stratos.SdsAddress `json:"network_address" yaml:"network_address"`
+	DelegatorAddress sdk.AccAddress     `json:"delegator_address" yaml:"delegator_address"`
+	IsIndexingNode   bool               `json:"is_indexing_node" yaml:"is_indexing_node"`
+	Amount           sdk.Coin           `json:"amount" yaml:"amount"`
+}
+
+// NewMsgUndelegate creates a new MsgUndelegate instance.
+func NewMsgUndelegate(networkAddr stratos.SdsAddress, delegatorAddr sdk.AccAddress, isIndexingNode bool, amount sdk.Coin) MsgUndelegate {
+	return MsgUndelegate{
+		NetworkAddress:   networkAddr,
+		DelegatorAddress: delegatorAddr,
+		IsIndexingNode:   isIndexingNode,
+		Amount:           amount,
+	}
+}
+
+// Route implements the sdk.Msg interface.
+func (msg MsgUndelegate) Route() string { return RouterKey }
+
+// Type implements the sdk.Msg interface.
+func (msg MsgUndelegate) Type() string { return "undelegate" }
+
+// GetSigners implements the sdk.Msg interface.
+func (msg MsgUndelegate) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.DelegatorAddress}
+}
+
+// GetSignBytes implements the sdk.Msg interface.
+func (msg MsgUndelegate) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic implements the sdk.Msg interface.
+func (msg MsgUndelegate) ValidateBasic() error {
+	return validateDelegationMsg(msg.NetworkAddress, msg.DelegatorAddress, msg.IsIndexingNode, msg.Amount)
+}
+
+func validateDelegationMsg(networkAddr stratos.SdsAddress, delegatorAddr sdk.AccAddress, isIndexingNode bool, amount sdk.Coin) error {
+	if networkAddr.Empty() {
+		if isIndexingNode {
+			return ErrEmptyIndexingNodeAddr
+		}
+		return ErrEmptyResourceNodeAddr
+	}
+	if delegatorAddr.Empty() {
+		return ErrEmptyDelegatorAddr
+	}
+	if !amount.IsValid() || !amount.IsPositive() {
+		return ErrValueNegative
+	}
+	return nil
+}
+
+// MsgRedelegateNodeStake - struct for moving bonded tokens of an owner from one of its nodes to another
+type MsgRedelegateNodeStake struct {
+	OwnerAddress      sdk.AccAddress     `json:"owner_address" yaml:"owner_address"`
+	SrcNetworkAddress stratos.SdsAddress `json:"src_network_address" yaml:"src_network_address"`
+	DstNetworkAddress stratos.SdsAddress `json:"dst_network_address" yaml:"dst_network_address"`
+	IsIndexingNode    bool               `json:"is_indexing_node" yaml:"is_indexing_node"`
+	Amount            sdk.Coin           `json:"amount" yaml:"amount"`
+	DstPubKey         crypto.PubKey      `json:"dst_pubkey" yaml:"dst_pubkey"` // public key of the destination resource node, only to register a new network address
+}
+
+// NewMsgRedelegateNodeStake creates a new MsgRedelegateNodeStake instance.
+func NewMsgRedelegateNodeStake(ownerAddr sdk.AccAddress, srcNetworkAddr, dstNetworkAddr stratos.SdsAddress, isIndexingNode bool,
+	amount sdk.Coin, dstPubKey crypto.PubKey) MsgRedelegateNodeStake {
+	return MsgRedelegateNodeStake{
+		OwnerAddress:      ownerAddr,
+		SrcNetworkAddress: srcNetworkAddr,
+		DstNetworkAddress: dstNetworkAddr,
+		IsIndexingNode:    isIndexingNode,
+		Amount:            amount,
+		DstPubKey:         dstPubKey,
+	}
+}
+
+// Route implements the sdk.Msg interface.
+func (msg MsgRedelegateNodeStake) Route() string { return RouterKey }
+
+// Type implements the sdk.Msg interface.
+func (msg MsgRedelegateNodeStake) Type() string { return "redelegate_node_stake" }
+
+// GetSigners implements the sdk.Msg interface.
+func (msg MsgRedelegateNodeStake) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.OwnerAddress}
+}
+
+// GetSignBytes implements the sdk.Msg interface.
+func (msg MsgRedelegateNodeStake) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic implements the sdk.Msg interface.
+func (msg MsgRedelegateNodeStake) ValidateBasic() error {
+	if msg.OwnerAddress.Empty() {
+		return ErrEmptyOwnerAddr
+	}
+	if msg.SrcNetworkAddress.Empty() || msg.DstNetworkAddress.Empty() {
+		return ErrInvalidNetworkAddr
+	}
+	if msg.SrcNetworkAddress.Equals(msg.DstNetworkAddress) {
+		return ErrSelfRedelegation
+	}
+	if msg.DstPubKey != nil {
+		if msg.IsIndexingNode {
+			return ErrNewIndexingNodeRedelegation
+		}
+		if !msg.DstNetworkAddress.Equals(stratos.SdsAddress(msg.DstPubKey.Address())) {
+			return ErrInvalidNetworkAddr
+		}
+	}
+	if !msg.Amount.IsValid() || !msg.Amount.IsPositive() {
+		return ErrValueNegative
+	}
+	return nil
+}
@@ -18,6 +18,8 @@ const (
 	DefaultUnbondingThreasholdTime time.Duration = 180 * 24 * time.Hour // threashold for unbonding - by default 180 days
 	DefaultUnbondingCompletionTime time.Duration = 14 * 24 * time.Hour  // lead time to complete unbonding - by default 14 days
 	DefaultMaxEntries                            = uint16(16)
+	DefaultMinSuspendPeriod        time.Duration = 24 * time.Hour // min period before a suspended node can be unsuspended - by default 1 day
+	DefaultUozHistoryEntries                     = uint32(10000)  // number of blocks the uoz snapshots are kept for
 )
 
 // Parameter store keys
@@ -26,6 +28,8 @@ var (
 	KeyUnbondingThreasholdTime = []byte("UnbondingThreasholdTime")
 	KeyUnbondingCompletionTime = []byte("UnbondingCompletionTime")
 	KeyMaxEntries              = []byte("KeyMaxEntries")
+	KeyMinSuspendPeriod        = []byte("MinSuspendPeriod")
+	KeyUozHistoryEntries       = []byte("UozHistoryEntries")
 
 	DefaultUozPrice            = sdk.NewDecWithPrec(1000000, 9) // 0.001 ustos -> 1 uoz
 	DefaultTotalUnissuedPrepay = sdk.NewInt(0)
@@ -44,15 +48,20 @@ type Params struct {
 	UnbondingThreasholdTime time.Duration `json:"unbonding_threashold_time" yaml:"unbonding_threashold_time"` // threashold for unbonding - by default 180 days
 	UnbondingCompletionTime time.Duration `json:"unbonding_completion_time" yaml:"unbonding_completion_time"` // lead time to complete unbonding - by default 14 days
 	MaxEntries              uint16        `json:"max_entries" yaml:"max_entries"`                             // max entries for either unbonding delegation or redelegation (per pair/trio)
+	MinSuspendPeriod        time.Duration `json:"min_suspend_period" yaml:"min_suspend_period"`               // min period before a suspended node can be unsuspended - by default 1 day
+	UozHistoryEntries       uint32        `json:"uoz_history_entries" yaml:"uoz_history_entries"`             // number of blocks the uoz snapshots are kept for, 0 disables the history
 }
 
 // NewParams creates a new Params object
-func NewParams(bondDenom string, threashold, completion time.Duration, maxEntries uint16) Params {
+func NewParams(bondDenom string, threashold, completion time.Duration, maxEntries uint16, minSuspendPeriod time.Duration,
+	uozHistoryEntries uint32) Params {
 	return Params{
 		BondDenom:               bondDenom,
 		UnbondingThreasholdTime: threashold,
 		UnbondingCompletionTime: completion,
 		MaxEntries:              maxEntries,
+		MinSuspendPeriod:        minSuspendPeriod,
+		UozHistoryEntries:       uozHistoryEntries,
 	}
 }
 
@@ -63,8 +72,10 @@ func (p Params) String() string {
 	  Unbonding Threashold Time:  	%s
 	  Unbonding Completion Time:  	%s
 	  Max Entries:        			%d
+	  Min Suspend Period:  			%s
+	  Uoz History Entries:  		%d
 `,
-		p.BondDenom, p.UnbondingThreasholdTime, p.UnbondingCompletionTime, p.MaxEntries,
+		p.BondDenom, p.UnbondingThreasholdTime, p.UnbondingCompletionTime, p.MaxEntries, p.MinSuspendPeriod, p.UozHistoryEntries,
 	)
 }
 
@@ -75,6 +86,8 @@ func (p *Params) ParamSetPairs() params.ParamSetPairs {
 		params.NewParamSetPair(KeyUnbondingThreasholdTime, &p.UnbondingThreasholdTime, validateUnbondingThreasholdTime),
 		params.NewParamSetPair(KeyUnbondingCompletionTime, &p.UnbondingCompletionTime, validateUnbondingCompletionTime),
 		params.NewParamSetPair(KeyMaxEntries, &p.MaxEntries, validateMaxEntries),
+		params.NewParamSetPair(KeyMinSuspendPeriod, &p.MinSuspendPeriod, validateMinSuspendPeriod),
+		params.NewParamSetPair(KeyUozHistoryEntries, &p.UozHistoryEntries, validateUozHistoryEntries),
 	}
 }
 
@@ -91,12 +104,19 @@ func (p Params) Validate() error {
 	if err := validateMaxEntries(p.MaxEntries); err != nil {
 		return err
 	}
+	if err := validateMinSuspendPeriod(p.MinSuspendPeriod); err != nil {
+		return err
+	}
+	if err := validateUozHistoryEntries(p.UozHistoryEntries); err != nil {
+		return err
+	}
 	return nil
 }
 
 // DefaultParams defines the parameters for this module
 func DefaultParams() Params {
-	return NewParams(DefaultBondDenom, DefaultUnbondingThreasholdTime, DefaultUnbondingCompletionTime, DefaultMaxEntries)
+	return NewParams(DefaultBondDenom, DefaultUnbondingThreasholdTime, DefaultUnbondingCompletionTime, DefaultMaxEntries,
+		DefaultMinSuspendPeriod, DefaultUozHistoryEntries)
 }
 
 func validateBondDenom(i interface{}) error {
@@ -153,3 +173,25 @@ func validateMaxEntries(i interface{}) error {
 
 	return nil
 }
+
+func validateMinSuspendPeriod(i interface{}) error {
+	v, ok := i.(time.Duration)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v < 0 {
+		return fmt.Errorf("min suspend period must not be negative: %d", v)
+	}
+
+	return nil
+}
+
+func validateUozHistoryEntries(i interface{}) error {
+	_, ok := i.(uint32)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	return nil
+}
@@ -35,6 +35,32 @@ func NewQueryNodesParams(page, limit int, networkAddr stratos.SdsAddress, monike
 	}
 }
 
+// QueryDelegationsParams Params for query 'custom/register/delegations'
+type QueryDelegationsParams struct {
+	NetworkAddr   stratos.SdsAddress
+	DelegatorAddr sdk.AccAddress
+}
+
+// NewQueryDelegationsParams creates a new instance of QueryDelegationsParams
+func NewQueryDelegationsParams(networkAddr stratos.SdsAddress, delegatorAddr sdk.AccAddress) QueryDelegationsParams {
+	return QueryDelegationsParams{
+		NetworkAddr:   networkAddr,
+		DelegatorAddr: delegatorAddr,
+	}
+}
+
+// QueryRedelegationsParams Params for query 'custom/register/redelegations'
+type QueryRedelegationsParams struct {
+	OwnerAddr sdk.AccAddress
+}
+
+// NewQueryRedelegationsParams creates a new instance of QueryRedelegationsParams
+func NewQueryRedelegationsParams(ownerAddr sdk.AccAddress) QueryRedelegationsParams {
+	return QueryRedelegationsParams{
+		OwnerAddr: ownerAddr,
+	}
+}
+
 type QueryNodeStakingParams struct {
 	AccAddr   stratos.SdsAddress
 	QueryType int64 //0:All(Default) 1: indexingNode; 2: ResourceNode
@@ -0,0 +1,114 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	stratos "github.com/stratosnet/stratos-chain/types"
+)
+
+// Redelegation records the stake an owner moved from one of its nodes to another, in a time-ordered list of entries.
+// The entries are kept until the unbonding completion time is over, so the moved stake can not hop again meanwhile.
+type Redelegation struct {
+	OwnerAddress   sdk.AccAddress      `json:"owner_address" yaml:"owner_address"`
+	SrcNetworkAddr stratos.SdsAddress  `json:"src_network_addr" yaml:"src_network_addr"`
+	DstNetworkAddr stratos.SdsAddress  `json:"dst_network_addr" yaml:"dst_network_addr"`
+	IsIndexingNode bool                `json:"is_indexing_node" yaml:"is_indexing_node"`
+	Entries        []RedelegationEntry `json:"entries" yaml:"entries"` // redelegation entries
+}
+
+// RedelegationEntry - entry to a Redelegation
+type RedelegationEntry struct {
+	CreationHeight int64     `json:"creation_height" yaml:"creation_height"` // height at which the redelegation took place
+	CompletionTime time.Time `json:"completion_time" yaml:"completion_time"` // time at which the redelegation will complete
+	Balance        sdk.Int   `json:"balance" yaml:"balance"`                 // ustos moved to the destination node
+}
+
+// RedelegationPair identifies a redelegation in the redelegation queue
+type RedelegationPair struct {
+	SrcNetworkAddr stratos.SdsAddress `json:"src_network_addr" yaml:"src_network_addr"`
+	DstNetworkAddr stratos.SdsAddress `json:"dst_network_addr" yaml:"dst_network_addr"`
+}
+
+// NewRedelegation - create a new Redelegation object
+func NewRedelegation(ownerAddr sdk.AccAddress, srcNetworkAddr, dstNetworkAddr stratos.SdsAddress, isIndexingNode bool,
+	creationHeight int64, completionTime time.Time, balance sdk.Int) Redelegation {
+
+	return Redelegation{
+		OwnerAddress:   ownerAddr,
+		SrcNetworkAddr: srcNetworkAddr,
+		DstNetworkAddr: dstNetworkAddr,
+		IsIndexingNode: isIndexingNode,
+		Entries:        []RedelegationEntry{NewRedelegationEntry(creationHeight, completionTime, balance)},
+	}
+}
+
+// NewRedelegationEntry - create a new RedelegationEntry object
+func NewRedelegationEntry(creationHeight int64, completionTime time.Time, balance sdk.Int) RedelegationEntry {
+	return RedelegationEntry{
+		CreationHeight: creationHeight,
+		CompletionTime: completionTime,
+		Balance:        balance,
+	}
+}
+
+// IsMature - is the current entry mature
+func (e RedelegationEntry) IsMature(currentTime time.Time) bool {
+	return !e.CompletionTime.After(currentTime)
+}
+
+// AddEntry - append entry to the redelegation
+func (red *Redelegation) AddEntry(creationHeight int64, completionTime time.Time, balance sdk.Int) {
+	red.Entries = append(red.Entries, NewRedelegationEntry(creationHeight, completionTime, balance))
+}
+
+// RemoveEntry - remove entry at index i from the redelegation
+func (red *Redelegation) RemoveEntry(i int64) {
+	red.Entries = append(red.Entries[:i], red.Entries[i+1:]...)
+}
+
+// Validate performs a stateless validation of the redelegation
+func (red Redelegation) Validate() error {
+	if red.OwnerAddress.Empty() {
+		return ErrEmptyOwnerAddr
+	}
+	if red.SrcNetworkAddr.Empty() || red.DstNetworkAddr.Empty() || red.SrcNetworkAddr.Equals(red.DstNetworkAddr) {
+		return ErrInvalidNetworkAddr
+	}
+	for _, entry := range red.Entries {
+		if entry.Balance.IsNil() || !entry.Balance.IsPositive() {
+			return ErrValueNegative
+		}
+	}
+	return nil
+}
+
+// String returns a human readable string representation of a Redelegation.
+func (red Redelegation) String() string {
+	out := fmt.Sprintf(`Redelegation:
+	OwnerAddress:   %s,
+	SrcNetworkAddr: %s,
+	DstNetworkAddr: %s,
+	IsIndexingNode: %t,
+	Entries:`, red.OwnerAddress, red.SrcNetworkAddr, red.DstNetworkAddr, red.IsIndexingNode)
+	for i, entry := range red.Entries {
+		out += fmt.Sprintf(`
+    Redelegation %d:
+      Creation Height: %v
+      Completion Time: %v
+      Balance:         %s`, i, entry.CreationHeight, entry.CompletionTime, entry.Balance)
+	}
+	return out
+}
+
+// Redelegations is a collection of Redelegation
+type Redelegations []Redelegation
+
+func (reds Redelegations) String() (out string) {
+	for _, red := range reds {
+		out += red.String() + "\n"
+	}
+	return strings.TrimSpace(out)
+}
@@ -87,15 +87,17 @@ func (v ResourceNodes) Validate() error {
 }
 
 type ResourceNode struct {
-	NetworkAddr  stratos.SdsAddress `json:"network_address" yaml:"network_address"` // network id of the resource node, sds://...
-	PubKey       crypto.PubKey      `json:"pubkey" yaml:"pubkey"`                   // the public key of the resource node; bech encoded in JSON
-	Suspend      bool               `json:"suspend" yaml:"suspend"`                 // has the resource node been suspended from bonded status?
-	Status       sdk.BondStatus     `json:"status" yaml:"status"`                   // resource node bond status (bonded/unbonding/unbonded)
-	Tokens       sdk.Int            `json:"tokens" yaml:"tokens"`                   // delegated tokens
-	OwnerAddress sdk.AccAddress     `json:"owner_address" yaml:"owner_address"`     // owner address of the resource node
-	Description  Description        `json:"description" yaml:"description"`         // description terms for the resource node
-	NodeType     NodeType           `json:"node_type" yaml:"node_type"`
-	CreationTime time.Time          `json:"creation_time" yaml:"creation_time"`
+	NetworkAddr   stratos.SdsAddress `json:"network_address" yaml:"network_address"` // network id of the resource node, sds://...
+	PubKey        crypto.PubKey      `json:"pubkey" yaml:"pubkey"`                   // the public key of the resource node; bech encoded in JSON
+	Suspend       bool               `json:"suspend" yaml:"suspend"`                 // has the resource node been suspended from bonded status?
+	Status        sdk.BondStatus     `json:"status" yaml:"status"`                   // resource node bond status (bonded/unbonding/unbonded)
+	Tokens        sdk.Int            `json:"tokens" yaml:"tokens"`                   // delegated tokens
+	OwnerAddress  sdk.AccAddress     `json:"owner_address" yaml:"owner_address"`     // owner address of the resource node
+	Description   Description        `json:"description" yaml:"description"`         // description terms for the resource node
+	NodeType      NodeType           `json:"node_type" yaml:"node_type"`
+	CreationTime  time.Time          `json:"creation_time" yaml:"creation_time"`
+	Commission    Commission         `json:"commission" yaml:"commission"`       // commission kept by the owner on the rewards of the node
+	Beneficiaries []sdk.AccAddress   `json:"beneficiaries" yaml:"beneficiaries"` // addresses sharing the rewards of the node left after the commission
 }
 
 // NewResourceNode - initialize a new resource node
@@ -111,6 +113,7 @@ func NewResourceNode(networkAddr stratos.SdsAddress, pubKey crypto.PubKey, owner
 		Description:  description,
 		NodeType:     nodeType,
 		CreationTime: creationTime,
+		Commission:   NewCommission(DefaultCommissionRates(), creationTime),
 	}
 }
 
@@ -129,7 +132,10 @@ func (v ResourceNode) String() string {
 		Owner Address: 		%s
   		Description:		%s
   		CreationTime:		%s
-	}`, v.NetworkAddr, pubKey, v.Suspend, v.Status, v.Tokens, v.OwnerAddress, v.Description, v.CreationTime)
+  		Commission:			%s
+  		Beneficiaries:		%v
+	}`, v.NetworkAddr, pubKey, v.Suspend, v.Status, v.Tokens, v.OwnerAddress, v.Description, v.CreationTime, v.Commission,
+		v.Beneficiaries)
 }
 
 // AddToken adds tokens to a resource node
@@ -169,7 +175,12 @@ func (v ResourceNode) Validate() error {
 	if v.Description.Moniker == "" {
 		return ErrEmptyMoniker
 	}
-	return nil
+	if !v.Commission.IsEmpty() {
+		if err := v.Commission.Validate(); err != nil {
+			return err
+		}
+	}
+	return ValidateBeneficiaries(v.Beneficiaries)
 }
 
 // IsBonded checks if the node status equals Bonded
@@ -198,6 +209,10 @@ func (v ResourceNode) GetTokens() sdk.Int           { return v.Tokens }
 func (v ResourceNode) GetOwnerAddr() sdk.AccAddress { return v.OwnerAddress }
 func (v ResourceNode) GetNodeType() string          { return v.NodeType.String() }
 func (v ResourceNode) GetCreationTime() time.Time   { return v.CreationTime }
+func (v ResourceNode) GetCommission() Commission    { return v.Commission }
+func (v ResourceNode) GetBeneficiaries() []sdk.AccAddress {
+	return v.Beneficiaries
+}
 
 // MustMarshalResourceNode returns the resourceNode bytes. Panics if fails
 func MustMarshalResourceNode(cdc *codec.Codec, resourceNode ResourceNode) []byte {
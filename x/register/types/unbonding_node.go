@@ -17,7 +17,7 @@ import (
 // for a single unbonding node in an time-ordered list
 type UnbondingNode struct {
 	NetworkAddr    stratos.SdsAddress   `json:"network_addr" yaml:"network_addr"`
-	IsIndexingNode bool                 `json:"is_indexing_node yaml:"is_indexing_node`
+	IsIndexingNode bool                 `json:"is_indexing_node" yaml:"is_indexing_node"`
 	Entries        []UnbondingNodeEntry `json:"entries" yaml:"entries"` // unbonding node entries
 }
 
@@ -27,6 +27,13 @@ type UnbondingNodeEntry struct {
 	CompletionTime time.Time `json:"completion_time" yaml:"completion_time"` // time at which the unbonding delegation will complete
 	InitialBalance sdk.Int   `json:"initial_balance" yaml:"initial_balance"` // ustos initially scheduled to receive at completion
 	Balance        sdk.Int   `json:"balance" yaml:"balance"`                 // ustos to receive at completion
+
+	Delegator sdk.AccAddress `json:"delegator,omitempty" yaml:"delegator,omitempty"` // delegator receiving the ustos at completion, empty for the node owner
+}
+
+// IsDelegation - does the entry pay a delegator rather than the node owner
+func (e UnbondingNodeEntry) IsDelegation() bool {
+	return !e.Delegator.Empty()
 }
 
 // IsMature - is the current entry mature
@@ -36,9 +43,9 @@ func (e UnbondingNodeEntry) IsMature(currentTime time.Time) bool {
 
 // NewUnbondingNode - create a new unbonding Node object
 func NewUnbondingNode(networkAddr stratos.SdsAddress, isIndexingNode bool, creationHeight int64, minTime time.Time,
-	balance sdk.Int) UnbondingNode {
+	balance sdk.Int, delegator sdk.AccAddress) UnbondingNode {
 
-	entry := NewUnbondingNodeEntry(creationHeight, minTime, balance)
+	entry := NewUnbondingNodeEntry(creationHeight, minTime, balance, delegator)
 	return UnbondingNode{
 		NetworkAddr:    networkAddr,
 		IsIndexingNode: isIndexingNode,
@@ -48,21 +55,22 @@ func NewUnbondingNode(networkAddr stratos.SdsAddress, isIndexingNode bool, creat
 
 // NewUnbondingNodeEntry - create a new unbonding Node object
 func NewUnbondingNodeEntry(creationHeight int64, completionTime time.Time,
-	balance sdk.Int) UnbondingNodeEntry {
+	balance sdk.Int, delegator sdk.AccAddress) UnbondingNodeEntry {
 
 	return UnbondingNodeEntry{
 		CreationHeight: creationHeight,
 		CompletionTime: completionTime,
 		InitialBalance: balance,
 		Balance:        balance,
+		Delegator:      delegator,
 	}
 }
 
 // AddEntry - append entry to the unbonding Node
 func (un *UnbondingNode) AddEntry(creationHeight int64,
-	minTime time.Time, balance sdk.Int) {
+	minTime time.Time, balance sdk.Int, delegator sdk.AccAddress) {
 
-	entry := NewUnbondingNodeEntry(creationHeight, minTime, balance)
+	entry := NewUnbondingNodeEntry(creationHeight, minTime, balance, delegator)
 	un.Entries = append(un.Entries, entry)
 }
 
@@ -0,0 +1,41 @@
+package types
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// UozSnapshot is the uoz price & supply at the beginning of a block
+type UozSnapshot struct {
+	Height              int64     `json:"height" yaml:"height"`
+	Time                time.Time `json:"time" yaml:"time"`
+	UozPrice            sdk.Dec   `json:"uoz_price" yaml:"uoz_price"`
+	RemainingUoz        sdk.Int   `json:"remaining_uoz" yaml:"remaining_uoz"`
+	TotalUoz            sdk.Int   `json:"total_uoz" yaml:"total_uoz"`
+	TotalUnissuedPrepay sdk.Int   `json:"total_unissued_prepay" yaml:"total_unissued_prepay"`
+}
+
+func NewUozSnapshot(height int64, time time.Time, uozPrice sdk.Dec, remainingUoz, totalUoz, totalUnissuedPrepay sdk.Int) UozSnapshot {
+	return UozSnapshot{
+		Height:              height,
+		Time:                time,
+		UozPrice:            uozPrice,
+		RemainingUoz:        remainingUoz,
+		TotalUoz:            totalUoz,
+		TotalUnissuedPrepay: totalUnissuedPrepay,
+	}
+}
+
+// String implements fmt.Stringer
+func (s UozSnapshot) String() string {
+	return fmt.Sprintf(`UozSnapshot:
+  Height:                %d
+  Time:                  %s
+  UozPrice:              %s
+  RemainingUoz:          %s
+  TotalUoz:              %s
+  TotalUnissuedPrepay:   %s`,
+		s.Height, s.Time, s.UozPrice, s.RemainingUoz, s.TotalUoz, s.TotalUnissuedPrepay)
+}
@@ -4,16 +4,16 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/stratosnet/stratos-chain/x/sds/keeper"
 	abci "github.com/tendermint/tendermint/abci/types"
-	// abci "github.com/tendermint/tendermint/abci/types"
 )
 
-// BeginBlocker check for infraction evidence or downtime of validators
-// on every begin block
+// BeginBlocker issues a round of proof-of-storage challenges every ChallengeInterval blocks
 func BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock, k keeper.Keeper) {
-	// 	TODO: fill out if your application requires beginblock, if not you can delete this function
+	k.IssueChallenges(ctx)
 }
 
-// EndBlocker called every block, process inflation, update validator set.
+// EndBlocker fails the challenges whose deadline is reached without a valid proof,
+// then pays the storage deals at the end of each epoch
 func EndBlocker(ctx sdk.Context, k keeper.Keeper) {
-	// 	TODO: fill out if your application requires endblock, if not you can delete this function
+	k.ExpireChallenges(ctx)
+	k.PayDeals(ctx)
 }
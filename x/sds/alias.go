@@ -13,9 +13,11 @@ const (
 )
 
 var (
-	NewKeeper       = keeper.NewKeeper
-	RegisterCodec   = types.RegisterCodec
-	NewGenesisState = types.NewGenesisState
+	NewKeeper          = keeper.NewKeeper
+	RegisterInvariants = keeper.RegisterInvariants
+	AllInvariants      = keeper.AllInvariants
+	RegisterCodec      = types.RegisterCodec
+	NewGenesisState    = types.NewGenesisState
 )
 
 type (
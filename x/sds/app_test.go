@@ -20,10 +20,12 @@ import (
 	pottypes "github.com/stratosnet/stratos-chain/x/pot/types"
 	"github.com/stratosnet/stratos-chain/x/register"
 	regtypes "github.com/stratosnet/stratos-chain/x/register/types"
+	sdskeeper "github.com/stratosnet/stratos-chain/x/sds/keeper"
 	"github.com/stratosnet/stratos-chain/x/sds/types"
 	"github.com/stretchr/testify/require"
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/merkle"
 )
 
 var (
@@ -149,10 +151,14 @@ func TestPurchasedUoz(t *testing.T) {
 		tmpResourceNode.OwnerAddress = accs[i%5].GetAddress()
 		ozoneLimitChange, _ := registerKeeper.AddResourceNodeStake(ctx, tmpResourceNode, sdk.NewCoin("ustos", val))
 		log.Printf("Add resourceNode #%v(stake=%v), ozone limit increases by %v, remaining ozone limit is %v", i, resourceNodeStake, ozoneLimitChange, registerKeeper.GetRemainingOzoneLimit(ctx))
-		// doPrepay
+		// doPrepay, the purchased uoz is credited to the ozone balance of the wallet
+		lastOzoneBalance := registerKeeper.GetOzoneBalance(ctx, accs[i%5].GetAddress())
 		purchased, _ := k.Prepay(ctx, accs[i%5].GetAddress(), sdk.NewCoins(sdk.NewCoin("ustos", sdk.NewInt(10000000000))))
+		require.Equal(t, lastOzoneBalance.Add(purchased), registerKeeper.GetOzoneBalance(ctx, accs[i%5].GetAddress()))
 		log.Printf("%v Uoz purchased by 10 stos, remaining ozone limit drops to %v", purchased, registerKeeper.GetRemainingOzoneLimit(ctx))
 	}
+	msg, broken := AllInvariants(k)(ctx)
+	require.False(t, broken, msg)
 }
 
 func TestOzoneLimitChange(t *testing.T) {
@@ -191,12 +197,373 @@ func TestOzoneLimitChange(t *testing.T) {
 		tmpResourceNode.OwnerAddress = accs[i%5].GetAddress()
 		ozoneLimitChange, _ := registerKeeper.AddResourceNodeStake(ctx, tmpResourceNode, sdk.NewCoin("ustos", val))
 		log.Printf("Add resourceNode #%v(stake=%v), ozone limit increases by %v, remaining ozone limit is %v", i, resourceNodeStake, ozoneLimitChange, registerKeeper.GetRemainingOzoneLimit(ctx))
-		// doPrepay
+		// doPrepay, the purchased uoz is credited to the ozone balance of the wallet
+		lastOzoneBalance := registerKeeper.GetOzoneBalance(ctx, accs[i%5].GetAddress())
 		purchased, _ := k.Prepay(ctx, accs[i%5].GetAddress(), sdk.NewCoins(sdk.NewCoin("ustos", sdk.NewInt(10000000000))))
+		require.Equal(t, lastOzoneBalance.Add(purchased), registerKeeper.GetOzoneBalance(ctx, accs[i%5].GetAddress()))
 		log.Printf("%v Uoz purchased by 10 stos", purchased)
 	}
 }
 
+func TestFileUploadMetadata(t *testing.T) {
+	mApp, k, _, _, _ := getMockApp(t)
+	accs := setupAccounts(mApp)
+	mock.SetGenesis(mApp, accs)
+
+	header := abci.Header{Height: mApp.LastBlockHeight() + 1}
+	ctx := mApp.BaseApp.NewContext(true, header)
+	handler := NewHandler(k)
+	reporter := stratos.SdsAddress(addrIdx1)
+	fileResourceNodes := []stratos.SdsAddress{stratos.SdsAddress(addrRes1), stratos.SdsAddress(addrRes2)}
+
+	/********************* invalid metadata is rejected *********************/
+	fileUploadMsg := types.NewMsgUpload(testFileHashHex, idxOwner1, reporter, sdsAccAddr2, 0, 2, fileResourceNodes, "text/plain", 0, nil)
+	require.Equal(t, types.ErrInvalidFileSize, fileUploadMsg.ValidateBasic())
+	fileUploadMsg = types.NewMsgUpload(testFileHashHex, idxOwner1, reporter, sdsAccAddr2, 1024, 2,
+		[]stratos.SdsAddress{fileResourceNodes[0], fileResourceNodes[0]}, "text/plain", 0, nil)
+	require.Equal(t, types.ErrDuplicateResourceNode, fileUploadMsg.ValidateBasic())
+
+	fileUploadMsg = types.NewMsgUpload(testFileHashHex, idxOwner1, reporter, sdsAccAddr2, 1024, 2,
+		[]stratos.SdsAddress{stratos.SdsAddress(addrIdx2)}, "text/plain", 0, nil)
+	_, err := handler(ctx, fileUploadMsg)
+	require.Error(t, err)
+	fileUploadMsg = types.NewMsgUpload(testFileHashHex, idxOwner1, reporter, sdsAccAddr2, 1024, 2, fileResourceNodes, "text/plain", ctx.BlockHeight(), nil)
+	_, err = handler(ctx, fileUploadMsg)
+	require.Error(t, err)
+	fileUploadMsg = types.NewMsgUpload(testFileHashHex, sdsAccAddr1, reporter, sdsAccAddr2, 1024, 2, fileResourceNodes, "text/plain", 0, nil)
+	_, err = handler(ctx, fileUploadMsg)
+	require.Equal(t, types.ErrInvalidReporter, err)
+
+	/********************* the metadata is stored & exposed by the querier *********************/
+	fileUploadMsg = types.NewMsgUpload(testFileHashHex, idxOwner1, reporter, sdsAccAddr2, 1024, 2, fileResourceNodes, "text/plain", ctx.BlockHeight()+100, nil)
+	require.NoError(t, fileUploadMsg.ValidateBasic())
+	_, err = handler(ctx, fileUploadMsg)
+	require.NoError(t, err)
+
+	querier := sdskeeper.NewQuerier(k)
+	bz, err := querier(ctx, []string{types.QueryUploadedFile}, abci.RequestQuery{Data: []byte(testFileHashHex)})
+	require.NoError(t, err)
+	var fileInfo types.FileInfo
+	mApp.Cdc.MustUnmarshalJSON(bz, &fileInfo)
+	require.Equal(t, uint64(1024), fileInfo.FileSize)
+	require.Equal(t, uint32(2), fileInfo.ReplicaCount)
+	require.Equal(t, fileResourceNodes, fileInfo.ResourceNodes)
+	require.Equal(t, "text/plain", fileInfo.ContentType)
+	require.Equal(t, ctx.BlockHeight()+100, fileInfo.ExpiryHeight)
+	require.False(t, fileInfo.IsExpired(ctx.BlockHeight()))
+
+	exported := ExportGenesis(ctx, k)
+	require.NoError(t, types.ValidateGenesis(exported))
+	require.Len(t, exported.FileUpload, 1)
+	require.Equal(t, fileInfo, exported.FileUpload[0].FileInfo)
+}
+
+func TestFileDeleteAndTransferOwnership(t *testing.T) {
+	mApp, k, _, _, _ := getMockApp(t)
+	accs := setupAccounts(mApp)
+	mock.SetGenesis(mApp, accs)
+
+	header := abci.Header{Height: mApp.LastBlockHeight() + 1}
+	ctx := mApp.BaseApp.NewContext(true, header)
+	handler := NewHandler(k)
+	reporter := stratos.SdsAddress(addrIdx1)
+	fileResourceNodes := []stratos.SdsAddress{stratos.SdsAddress(addrRes1)}
+	fileUploadMsg := types.NewMsgUpload(testFileHashHex, idxOwner1, reporter, sdsAccAddr2, 1024, 1, fileResourceNodes, "", 0, nil)
+	_, err := handler(ctx, fileUploadMsg)
+	require.NoError(t, err)
+
+	/********************* only the uploader can upload the file again *********************/
+	_, err = handler(ctx, types.NewMsgUpload(testFileHashHex, idxOwner1, reporter, sdsAccAddr3, 2048, 1, fileResourceNodes, "", 0, nil))
+	require.Equal(t, types.ErrFileExists, err)
+	_, err = handler(ctx, fileUploadMsg)
+	require.NoError(t, err)
+
+	/********************* only the uploader can transfer the ownership *********************/
+	transferMsg := types.NewMsgFileTransferOwnership(testFileHashHex, sdsAccAddr3, sdsAccAddr1)
+	_, err = handler(ctx, transferMsg)
+	require.Equal(t, types.ErrNotFileOwner, err)
+
+	transferMsg = types.NewMsgFileTransferOwnership(testFileHashHex, sdsAccAddr2, sdsAccAddr3)
+	require.Equal(t, []sdk.AccAddress{sdsAccAddr2}, transferMsg.GetSigners())
+	_, err = handler(ctx, transferMsg)
+	require.NoError(t, err)
+	fileInfo, err := k.GetFileInfoByFileHash(ctx, []byte(testFileHashHex))
+	require.NoError(t, err)
+	require.Equal(t, sdsAccAddr3, fileInfo.Uploader)
+
+	/********************* an sp node deletes the file with the signature of the new owner *********************/
+	deleteMsg := types.NewMsgFileDelete(testFileHashHex, idxOwner1, nil, sdsAccAddr3)
+	require.Error(t, deleteMsg.ValidateBasic())
+	deleteMsg = types.NewMsgFileDelete(testFileHashHex, idxOwner2, reporter, sdsAccAddr3)
+	_, err = handler(ctx, deleteMsg)
+	require.Equal(t, types.ErrInvalidReporter, err)
+	deleteMsg = types.NewMsgFileDelete(testFileHashHex, idxOwner1, reporter, sdsAccAddr2)
+	_, err = handler(ctx, deleteMsg)
+	require.Equal(t, types.ErrNotFileOwner, err)
+
+	deleteMsg = types.NewMsgFileDelete(testFileHashHex, idxOwner1, reporter, sdsAccAddr3)
+	require.NoError(t, deleteMsg.ValidateBasic())
+	require.Equal(t, []sdk.AccAddress{idxOwner1, sdsAccAddr3}, deleteMsg.GetSigners())
+	res, err := handler(ctx, deleteMsg)
+	require.NoError(t, err)
+	require.Equal(t, types.EventTypeFileDelete, res.Events[0].Type)
+	_, err = k.GetFileInfoByFileHash(ctx, []byte(testFileHashHex))
+	require.Error(t, err)
+
+	_, err = handler(ctx, deleteMsg)
+	require.Error(t, err)
+}
+
+func TestFilesByUploaderAndReporter(t *testing.T) {
+	mApp, k, _, _, _ := getMockApp(t)
+	accs := setupAccounts(mApp)
+	mock.SetGenesis(mApp, accs)
+
+	header := abci.Header{Height: mApp.LastBlockHeight() + 1}
+	ctx := mApp.BaseApp.NewContext(true, header)
+	handler := NewHandler(k)
+	querier := sdskeeper.NewQuerier(k)
+	reporter := stratos.SdsAddress(addrIdx1)
+	fileResourceNodes := []stratos.SdsAddress{stratos.SdsAddress(addrRes1)}
+	fileHashes := []string{"01", "02", "03"}
+	for _, fileHash := range fileHashes {
+		_, err := handler(ctx, types.NewMsgUpload(fileHash, idxOwner1, reporter, sdsAccAddr2, 1024, 1, fileResourceNodes, "", 0, nil))
+		require.NoError(t, err)
+	}
+
+	queryFiles := func(route string, params interface{}) []types.FileUpload {
+		bz, err := querier(ctx, []string{route}, abci.RequestQuery{Data: mApp.Cdc.MustMarshalJSON(params)})
+		require.NoError(t, err)
+		var files []types.FileUpload
+		mApp.Cdc.MustUnmarshalJSON(bz, &files)
+		return files
+	}
+
+	/********************* the files are listed page by page *********************/
+	files := queryFiles(types.QueryFilesByUploader, types.NewQueryFilesByUploaderParams(1, 2, sdsAccAddr2))
+	require.Len(t, files, 2)
+	require.Equal(t, "01", files[0].FileHash)
+	files = queryFiles(types.QueryFilesByUploader, types.NewQueryFilesByUploaderParams(2, 2, sdsAccAddr2))
+	require.Len(t, files, 1)
+	require.Equal(t, "03", files[0].FileHash)
+	files = queryFiles(types.QueryFilesByReporter, types.NewQueryFilesByReporterParams(1, 0, reporter))
+	require.Len(t, files, 3)
+
+	/********************* the indexes follow the ownership transfers & deletions *********************/
+	_, err := handler(ctx, types.NewMsgFileTransferOwnership("01", sdsAccAddr2, sdsAccAddr3))
+	require.NoError(t, err)
+	_, err = handler(ctx, types.NewMsgFileDelete("02", sdsAccAddr2, nil, sdsAccAddr2))
+	require.NoError(t, err)
+
+	files = queryFiles(types.QueryFilesByUploader, types.NewQueryFilesByUploaderParams(1, 0, sdsAccAddr2))
+	require.Len(t, files, 1)
+	require.Equal(t, "03", files[0].FileHash)
+	files = queryFiles(types.QueryFilesByUploader, types.NewQueryFilesByUploaderParams(1, 0, sdsAccAddr3))
+	require.Len(t, files, 1)
+	require.Equal(t, "01", files[0].FileHash)
+	files = queryFiles(types.QueryFilesByReporter, types.NewQueryFilesByReporterParams(1, 0, reporter))
+	require.Len(t, files, 2)
+}
+
+func TestProofOfStorageChallenge(t *testing.T) {
+	mApp, k, _, registerKeeper, _ := getMockApp(t)
+	accs := setupAccounts(mApp)
+	mock.SetGenesis(mApp, accs)
+
+	header := abci.Header{Height: mApp.LastBlockHeight() + 1}
+	ctx := mApp.BaseApp.NewContext(true, header)
+	handler := NewHandler(k)
+	params := k.GetParams(ctx)
+	params.ChallengeInterval = 1
+	params.ChallengeDeadline = 10
+	k.SetParams(ctx, params)
+
+	resourceNode := stratos.SdsAddress(addrRes1)
+	chunks := [][]byte{[]byte("chunk0"), []byte("chunk1"), []byte("chunk2"), []byte("chunk3")}
+	merkleRoot, proofs := merkle.SimpleProofsFromByteSlices(chunks)
+	fileUploadMsg := types.NewMsgUpload(testFileHashHex, idxOwner1, stratos.SdsAddress(addrIdx1), sdsAccAddr2, 1024, 1,
+		[]stratos.SdsAddress{resourceNode}, "", 0, merkleRoot[:4])
+	require.Equal(t, types.ErrInvalidMerkleRoot, fileUploadMsg.ValidateBasic())
+	fileUploadMsg.MerkleRoot = merkleRoot
+	_, err := handler(ctx, fileUploadMsg)
+	require.NoError(t, err)
+
+	/********************* only the resource nodes acknowledging the file are challenged *********************/
+	node, _ := registerKeeper.GetResourceNode(ctx, resourceNode)
+	node.Suspend = false
+	registerKeeper.SetResourceNode(ctx, node)
+	BeginBlocker(ctx, abci.RequestBeginBlock{}, k)
+	_, found := k.GetChallenge(ctx, resourceNode, []byte(testFileHashHex))
+	require.False(t, found, "nodes not acknowledging the file are not challenged")
+
+	_, err = handler(ctx, types.NewMsgFileAcknowledge(resOwner2, resourceNode, testFileHashHex))
+	require.Error(t, err)
+	_, err = handler(ctx, types.NewMsgFileAcknowledge(resOwner2, stratos.SdsAddress(addrRes2), testFileHashHex))
+	require.Equal(t, types.ErrNotFileHolder, err)
+	ackMsg := types.NewMsgFileAcknowledge(resOwner1, resourceNode, testFileHashHex)
+	require.NoError(t, ackMsg.ValidateBasic())
+	_, err = handler(ctx, ackMsg)
+	require.NoError(t, err)
+	_, err = handler(ctx, ackMsg)
+	require.Equal(t, types.ErrFileAcknowledged, err)
+
+	/********************* the stored file is challenged deterministically *********************/
+	node.Suspend = true
+	registerKeeper.SetResourceNode(ctx, node)
+	BeginBlocker(ctx, abci.RequestBeginBlock{}, k)
+	_, found = k.GetChallenge(ctx, resourceNode, []byte(testFileHashHex))
+	require.False(t, found, "suspended nodes are not challenged")
+	node.Suspend = false
+	registerKeeper.SetResourceNode(ctx, node)
+
+	BeginBlocker(ctx, abci.RequestBeginBlock{}, k)
+	challenge, found := k.GetChallenge(ctx, resourceNode, []byte(testFileHashHex))
+	require.True(t, found)
+	require.Equal(t, ctx.BlockHeight()+10, challenge.Deadline)
+	bz, err := sdskeeper.NewQuerier(k)(ctx, []string{types.QueryChallenges}, abci.RequestQuery{Data: resourceNode})
+	require.NoError(t, err)
+	var challenges []types.Challenge
+	mApp.Cdc.MustUnmarshalJSON(bz, &challenges)
+	require.Equal(t, []types.Challenge{challenge}, challenges)
+
+	/********************* only the owner of the node answers with the proof of the challenged chunk *********************/
+	idx := challenge.ChunkIndex(len(chunks))
+	proofMsg := types.NewMsgChallengeProof(resOwner2, resourceNode, testFileHashHex, chunks[idx], *proofs[idx])
+	require.NoError(t, proofMsg.ValidateBasic())
+	_, err = handler(ctx, proofMsg)
+	require.Equal(t, types.ErrInvalidProver, err)
+	proofMsg.Sender = resOwner1
+	res, err := handler(ctx, proofMsg)
+	require.NoError(t, err)
+	require.Equal(t, "true", string(res.Events[0].Attributes[2].Value))
+	_, found = k.GetChallenge(ctx, resourceNode, []byte(testFileHashHex))
+	require.False(t, found)
+	_, err = handler(ctx, proofMsg)
+	require.True(t, types.ErrNoChallengeFound.Is(err))
+
+	/********************* a wrong proof fails the challenge and suspends the node *********************/
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+	BeginBlocker(ctx, abci.RequestBeginBlock{}, k)
+	challenge, found = k.GetChallenge(ctx, resourceNode, []byte(testFileHashHex))
+	require.True(t, found)
+	wrongIdx := (challenge.ChunkIndex(len(chunks)) + 1) % len(chunks)
+	res, err = handler(ctx, types.NewMsgChallengeProof(resOwner1, resourceNode, testFileHashHex, chunks[wrongIdx], *proofs[wrongIdx]))
+	require.NoError(t, err)
+	require.Equal(t, types.EventTypeChallengeFailed, res.Events[0].Type)
+	node, _ = registerKeeper.GetResourceNode(ctx, resourceNode)
+	require.True(t, node.Suspend)
+
+	/********************* missed deadlines fail the challenge *********************/
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+	node.Suspend = false
+	registerKeeper.SetResourceNode(ctx, node)
+	BeginBlocker(ctx, abci.RequestBeginBlock{}, k)
+	challenge, found = k.GetChallenge(ctx, resourceNode, []byte(testFileHashHex))
+	require.True(t, found)
+	EndBlocker(ctx.WithBlockHeight(challenge.Deadline-1), k)
+	_, found = k.GetChallenge(ctx, resourceNode, []byte(testFileHashHex))
+	require.True(t, found)
+	exported := ExportGenesis(ctx, k)
+	require.NoError(t, types.ValidateGenesis(exported))
+	require.Equal(t, []types.Challenge{challenge}, exported.Challenges)
+
+	EndBlocker(ctx.WithBlockHeight(challenge.Deadline), k)
+	_, found = k.GetChallenge(ctx, resourceNode, []byte(testFileHashHex))
+	require.False(t, found)
+	node, _ = registerKeeper.GetResourceNode(ctx, resourceNode)
+	require.True(t, node.Suspend)
+}
+
+func TestStorageDeal(t *testing.T) {
+	mApp, k, bankKeeper, registerKeeper, _ := getMockApp(t)
+	accs := setupAccounts(mApp)
+	mock.SetGenesis(mApp, accs)
+
+	header := abci.Header{Height: mApp.LastBlockHeight() + 1}
+	ctx := mApp.BaseApp.NewContext(true, header)
+	handler := NewHandler(k)
+	params := k.GetParams(ctx)
+	params.DealEpochLength = 10
+	k.SetParams(ctx, params)
+
+	resourceNode := stratos.SdsAddress(addrRes1)
+	node, _ := registerKeeper.GetResourceNode(ctx, resourceNode)
+	node.Suspend = false
+	registerKeeper.SetResourceNode(ctx, node)
+	fileResourceNodes := []stratos.SdsAddress{resourceNode, stratos.SdsAddress(addrRes2)}
+	for _, fileHash := range []string{"01", "02"} {
+		_, err := handler(ctx, types.NewMsgUpload(fileHash, idxOwner1, stratos.SdsAddress(addrIdx1), sdsAccAddr2, 1024, 2, fileResourceNodes, "", 0, nil))
+		require.NoError(t, err)
+	}
+
+	/********************* only the uploader locks an escrow for the size of the file *********************/
+	escrow := sdk.NewCoin(DefaultDenom, sdk.NewInt(1000))
+	require.Equal(t, types.ErrInvalidDealDuration, types.NewMsgCreateDeal(sdsAccAddr2, "01", 1024, 0, escrow).ValidateBasic())
+	_, err := handler(ctx, types.NewMsgCreateDeal(sdsAccAddr1, "01", 1024, 3, escrow))
+	require.Equal(t, types.ErrNotFileOwner, err)
+	_, err = handler(ctx, types.NewMsgCreateDeal(sdsAccAddr2, "01", 2048, 3, escrow))
+	require.True(t, types.ErrDealFileSizeMismatch.Is(err))
+
+	ownerBalance := bankKeeper.GetCoins(ctx, sdsAccAddr2).AmountOf(DefaultDenom)
+	_, err = handler(ctx, types.NewMsgCreateDeal(sdsAccAddr2, "01", 1024, 3, escrow))
+	require.NoError(t, err)
+	_, err = handler(ctx, types.NewMsgCreateDeal(sdsAccAddr2, "01", 1024, 3, escrow))
+	require.Equal(t, types.ErrDealExists, err)
+	require.Equal(t, ownerBalance.Sub(escrow.Amount), bankKeeper.GetCoins(ctx, sdsAccAddr2).AmountOf(DefaultDenom))
+	deal, found := k.GetDeal(ctx, []byte("01"))
+	require.True(t, found)
+	require.Equal(t, int64(30), deal.EndHeight)
+	fileInfo, err := k.GetFileInfoByFileHash(ctx, []byte("01"))
+	require.NoError(t, err)
+	require.Equal(t, deal.EndHeight, fileInfo.ExpiryHeight)
+
+	/********************* the escrow is streamed per epoch to the active resource nodes which acknowledged the file *********************/
+	nodeOwnerBalance := bankKeeper.GetCoins(ctx, resOwner1).AmountOf(DefaultDenom)
+	EndBlocker(ctx.WithBlockHeight(9), k)
+	require.Equal(t, nodeOwnerBalance, bankKeeper.GetCoins(ctx, resOwner1).AmountOf(DefaultDenom))
+	EndBlocker(ctx.WithBlockHeight(10), k)
+	require.Equal(t, nodeOwnerBalance, bankKeeper.GetCoins(ctx, resOwner1).AmountOf(DefaultDenom))
+	deal, _ = k.GetDeal(ctx, []byte("01"))
+	require.Equal(t, escrow, deal.RemainingEscrow)
+
+	_, err = handler(ctx, types.NewMsgFileAcknowledge(resOwner1, resourceNode, "01"))
+	require.NoError(t, err)
+	EndBlocker(ctx.WithBlockHeight(20), k)
+	require.Equal(t, nodeOwnerBalance.AddRaw(250), bankKeeper.GetCoins(ctx, resOwner1).AmountOf(DefaultDenom))
+	deal, _ = k.GetDeal(ctx, []byte("01"))
+	require.Equal(t, int64(2), deal.PaidEpochs)
+	require.Equal(t, sdk.NewInt(750), deal.RemainingEscrow.Amount)
+	require.NoError(t, types.ValidateGenesis(ExportGenesis(ctx, k)))
+	_, broken := sdskeeper.AllInvariants(k)(ctx)
+	require.False(t, broken)
+
+	/********************* the expired deal refunds the share of the other resource nodes and cleans up the file *********************/
+	ownerBalance = bankKeeper.GetCoins(ctx, sdsAccAddr2).AmountOf(DefaultDenom)
+	EndBlocker(ctx.WithBlockHeight(30), k)
+	require.Equal(t, nodeOwnerBalance.AddRaw(625), bankKeeper.GetCoins(ctx, resOwner1).AmountOf(DefaultDenom))
+	require.Equal(t, ownerBalance.AddRaw(375), bankKeeper.GetCoins(ctx, sdsAccAddr2).AmountOf(DefaultDenom))
+	_, found = k.GetDeal(ctx, []byte("01"))
+	require.False(t, found)
+	_, err = k.GetFileInfoByFileHash(ctx, []byte("01"))
+	require.Error(t, err)
+
+	/********************* deleting the file refunds the escrow left to the owner of the file *********************/
+	ownerBalance = bankKeeper.GetCoins(ctx, sdsAccAddr2).AmountOf(DefaultDenom)
+	newOwnerBalance := bankKeeper.GetCoins(ctx, sdsAccAddr3).AmountOf(DefaultDenom)
+	_, err = handler(ctx, types.NewMsgCreateDeal(sdsAccAddr2, "02", 1024, 3, escrow))
+	require.NoError(t, err)
+	_, err = handler(ctx, types.NewMsgFileTransferOwnership("02", sdsAccAddr2, sdsAccAddr3))
+	require.NoError(t, err)
+	deal, _ = k.GetDeal(ctx, []byte("02"))
+	require.Equal(t, sdsAccAddr3, deal.Owner)
+	_, err = handler(ctx, types.NewMsgFileDelete("02", sdsAccAddr3, nil, sdsAccAddr3))
+	require.NoError(t, err)
+	_, found = k.GetDeal(ctx, []byte("02"))
+	require.False(t, found)
+	require.Equal(t, ownerBalance.Sub(escrow.Amount), bankKeeper.GetCoins(ctx, sdsAccAddr2).AmountOf(DefaultDenom))
+	require.Equal(t, newOwnerBalance.Add(escrow.Amount), bankKeeper.GetCoins(ctx, sdsAccAddr3).AmountOf(DefaultDenom))
+}
+
 func TestSdsMsgs(t *testing.T) {
 
 	/********************* initialize mock app *********************/
@@ -208,7 +575,8 @@ func TestSdsMsgs(t *testing.T) {
 	///********************* create fileUpload msg *********************/
 	log.Print("====== Testing MsgFileUpload ======")
 	//fileHash, _ := hex.DecodeString(testFileHashHex)
-	fileUploadMsg := types.NewMsgUpload(testFileHashHex, sdsAccAddr1, stratos.SdsAddress(spP2pAddr), sdsAccAddr2)
+	fileResourceNodes := []stratos.SdsAddress{stratos.SdsAddress(addrRes1), stratos.SdsAddress(addrRes2)}
+	fileUploadMsg := types.NewMsgUpload(testFileHashHex, sdsAccAddr1, stratos.SdsAddress(spP2pAddr), sdsAccAddr2, 1024, 2, fileResourceNodes, "text/plain", 0, nil)
 	headerUpload := abci.Header{Height: mApp.LastBlockHeight() + 1}
 	mock.SignCheckDeliver(t, mApp.Cdc, mApp.BaseApp, headerUpload, []sdk.Msg{fileUploadMsg}, []uint64{18}, []uint64{0}, true, true, sdsAccPrivKey1)
 	coin := sdk.NewCoin(DefaultDenom, spNodeInitialStakeIdx1)
@@ -216,7 +584,7 @@ func TestSdsMsgs(t *testing.T) {
 	///********************* create prepay msg *********************/
 	log.Print("====== Testing MsgPrepay ======")
 	coinToPrepay := sdk.NewCoin(DefaultDenom, prepayAmt)
-	prepayMsg := types.NewMsgPrepay(sdsAccAddr3, sdk.NewCoins(coinToPrepay))
+	prepayMsg := types.NewMsgPrepay(sdsAccAddr3, sdk.NewCoins(coinToPrepay), sdk.ZeroInt())
 	headerPrepay := abci.Header{Height: mApp.LastBlockHeight() + 1}
 	mock.SignCheckDeliver(t, mApp.Cdc, mApp.BaseApp, headerPrepay, []sdk.Msg{prepayMsg}, []uint64{20}, []uint64{0}, true, true, sdsAccPrivKey3)
 	newBalanceInt := sdsAccBal3.Sub(prepayAmt)
@@ -335,6 +703,109 @@ func getMockApp(t *testing.T) (*mock.App, Keeper, bank.Keeper, register.Keeper,
 	return mApp, keeper, bankKeeper, registerKeeper, potKeeper
 }
 
+func TestPrepayMinUoz(t *testing.T) {
+	mApp, k, _, registerKeeper, _ := getMockAppPrepay(t)
+	accs := setupAccounts(mApp)
+	mock.SetGenesis(mApp, accs)
+
+	header := abci.Header{Height: mApp.LastBlockHeight() + 1}
+	ctx := mApp.BaseApp.NewContext(true, header)
+	handler := NewHandler(k)
+
+	initialStakeTotal := sdk.NewInt(43000000000000)
+	registerKeeper.SetInitialGenesisStakeTotal(ctx, initialStakeTotal)
+	registerKeeper.SetRemainingOzoneLimit(ctx, initialStakeTotal.ToDec().Quo(registerKeeper.GetInitialUOzonePrice(ctx)).TruncateInt())
+
+	buyer := accs[0].GetAddress()
+	coins := sdk.NewCoins(sdk.NewCoin("ustos", sdk.NewInt(10000000000)))
+	amtToPrepay, _ := coins.AmountOf("ustos").MarshalJSON()
+	bz, err := sdskeeper.NewQuerier(k)(ctx, []string{sdskeeper.QuerySimulatePrepay}, abci.RequestQuery{Data: amtToPrepay})
+	require.NoError(t, err)
+	var simulated sdk.Int
+	require.NoError(t, simulated.UnmarshalJSON(bz))
+
+	require.Equal(t, types.ErrInvalidMinUoz, types.NewMsgPrepay(buyer, coins, sdk.NewInt(-1)).ValidateBasic())
+
+	/********************* the prepay fails when another prepay of the block moved the price *********************/
+	_, err = k.Prepay(ctx, accs[1].GetAddress(), coins)
+	require.NoError(t, err)
+	cacheCtx, _ := ctx.CacheContext()
+	_, err = handler(cacheCtx, types.NewMsgPrepay(buyer, coins, simulated))
+	require.True(t, types.ErrUozBelowMinimum.Is(err))
+
+	/********************* the prepay succeeds when the minimum is met *********************/
+	_, err = handler(ctx, types.NewMsgPrepay(buyer, coins, simulated.QuoRaw(2)))
+	require.NoError(t, err)
+	require.True(t, registerKeeper.GetOzoneBalance(ctx, buyer).GTE(simulated.QuoRaw(2)))
+}
+
+func TestSellOzone(t *testing.T) {
+	mApp, k, bankKeeper, registerKeeper, _ := getMockAppPrepay(t)
+	accs := setupAccounts(mApp)
+	mock.SetGenesis(mApp, accs)
+
+	header := abci.Header{Height: mApp.LastBlockHeight() + 1}
+	ctx := mApp.BaseApp.NewContext(true, header)
+	handler := NewHandler(k)
+
+	initialStakeTotal := sdk.NewInt(43000000000000)
+	registerKeeper.SetInitialGenesisStakeTotal(ctx, initialStakeTotal)
+	initOzoneLimit := initialStakeTotal.ToDec().Quo(registerKeeper.GetInitialUOzonePrice(ctx)).TruncateInt()
+	registerKeeper.SetRemainingOzoneLimit(ctx, initOzoneLimit)
+
+	seller := accs[0].GetAddress()
+	prepay := sdk.NewInt(10000000000)
+	purchased, err := k.Prepay(ctx, seller, sdk.NewCoins(sdk.NewCoin("ustos", prepay)))
+	require.NoError(t, err)
+
+	/********************* uoz beyond the ozone balance cannot be sold *********************/
+	_, err = handler(ctx, types.NewMsgSellOzone(seller, purchased.AddRaw(1)))
+	require.True(t, types.ErrInsufficientOzone.Is(err))
+	require.Equal(t, types.ErrInvalidSellAmount, types.NewMsgSellOzone(seller, sdk.ZeroInt()).ValidateBasic())
+
+	/********************* selling back all the uoz restores the bonding curve *********************/
+	lastCoins := bankKeeper.GetCoins(ctx, seller)
+	amtToSell, _ := purchased.MarshalJSON()
+	bz, err := sdskeeper.NewQuerier(k)(ctx, []string{sdskeeper.QuerySimulateSell}, abci.RequestQuery{Data: amtToSell})
+	require.NoError(t, err)
+	var simulatedRefund sdk.Int
+	require.NoError(t, simulatedRefund.UnmarshalJSON(bz))
+	refund, fee, err := k.SellOzone(ctx, seller, purchased)
+	require.NoError(t, err)
+	require.Equal(t, simulatedRefund, refund)
+	require.True(t, fee.IsZero())
+	require.True(t, refund.LTE(prepay))
+	require.True(t, prepay.Sub(refund).LTE(sdk.OneInt()))
+	require.Equal(t, lastCoins.Add(sdk.NewCoin("ustos", refund)), bankKeeper.GetCoins(ctx, seller))
+	require.Equal(t, prepay.Sub(refund), registerKeeper.GetTotalUnissuedPrepay(ctx).Amount)
+	require.Equal(t, initOzoneLimit, registerKeeper.GetRemainingOzoneLimit(ctx))
+	require.True(t, registerKeeper.GetOzoneBalance(ctx, seller).IsZero())
+
+	/********************* the exit fee is kept in the unissued prepay *********************/
+	params := k.GetParams(ctx)
+	params.ExitFeeRate = sdk.NewDecWithPrec(1, 1)
+	k.SetParams(ctx, params)
+	purchased, err = k.Prepay(ctx, seller, sdk.NewCoins(sdk.NewCoin("ustos", prepay)))
+	require.NoError(t, err)
+	lastUnissuedPrepay := registerKeeper.GetTotalUnissuedPrepay(ctx).Amount
+	refund, fee, err = k.SellOzone(ctx, seller, purchased)
+	require.NoError(t, err)
+	require.Equal(t, refund.Add(fee).ToDec().Mul(params.ExitFeeRate).TruncateInt(), fee)
+	require.Equal(t, lastUnissuedPrepay.Sub(refund), registerKeeper.GetTotalUnissuedPrepay(ctx).Amount)
+
+	msg, broken := AllInvariants(k)(ctx)
+	require.False(t, broken, msg)
+
+	/********************* the unissued prepay can not exceed the genesis preset and the prepays *********************/
+	bound := registerKeeper.GetGenesisUnissuedPrepay(ctx).Add(prepay.MulRaw(2))
+	registerKeeper.SetTotalUnissuedPrepay(ctx, sdk.NewCoin("ustos", bound))
+	msg, broken = AllInvariants(k)(ctx)
+	require.False(t, broken, msg)
+	registerKeeper.SetTotalUnissuedPrepay(ctx, sdk.NewCoin("ustos", bound.AddRaw(1)))
+	_, broken = AllInvariants(k)(ctx)
+	require.True(t, broken)
+}
+
 func getMockAppPrepay(t *testing.T) (*mock.App, Keeper, bank.Keeper, register.Keeper, pot.Keeper) {
 	mApp := mock.NewApp()
 
@@ -422,8 +893,9 @@ func getInitChainer(mapp *mock.App, keeper Keeper, accountKeeper auth.AccountKee
 			resourceNodes,
 			indexingNodes,
 			initialUOzonePrice,
-			sdk.ZeroInt(),
+			totalUnissuedPrepay.Amount,
 			make([]register.Slashing, 0),
+			make([]register.IndexingNodeRegistrationVotePool, 0),
 		)
 
 		register.InitGenesis(ctx, registerKeeper, registerGenesis)
@@ -447,7 +919,6 @@ func getInitChainer(mapp *mock.App, keeper Keeper, accountKeeper auth.AccountKee
 
 		//preset
 		registerKeeper.SetRemainingOzoneLimit(ctx, remainingOzoneLimit)
-		registerKeeper.SetTotalUnissuedPrepay(ctx, totalUnissuedPrepay)
 
 		//pot genesis data load
 		pot.InitGenesis(ctx, potKeeper, pot.NewGenesisState(
@@ -462,7 +933,7 @@ func getInitChainer(mapp *mock.App, keeper Keeper, accountKeeper auth.AccountKee
 		// init bank genesis
 		keeper.BankKeeper.SetSendEnabled(ctx, true)
 
-		InitGenesis(ctx, keeper, NewGenesisState(types.DefaultParams(), nil))
+		InitGenesis(ctx, keeper, NewGenesisState(types.DefaultParams(), nil, nil, nil))
 
 		return abci.ResponseInitChain{
 			Validators: validators,
@@ -492,6 +963,7 @@ func getInitChainerTestPurchase(mapp *mock.App, keeper Keeper, accountKeeper aut
 			initialUOzonePriceTestPurchase,
 			sdk.ZeroInt(),
 			make([]register.Slashing, 0),
+			make([]register.IndexingNodeRegistrationVotePool, 0),
 		)
 
 		register.InitGenesis(ctx, registerKeeper, registerGenesis)
@@ -530,7 +1002,7 @@ func getInitChainerTestPurchase(mapp *mock.App, keeper Keeper, accountKeeper aut
 		// init bank genesis
 		keeper.BankKeeper.SetSendEnabled(ctx, true)
 
-		InitGenesis(ctx, keeper, NewGenesisState(types.DefaultParams(), nil))
+		InitGenesis(ctx, keeper, NewGenesisState(types.DefaultParams(), nil, nil, nil))
 
 		return abci.ResponseInitChain{
 			Validators: validators,
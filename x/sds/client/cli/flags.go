@@ -1,7 +1,18 @@
 package cli
 
 const (
-	FlagFileHash = "file-hash"
-	FlagReporter = "reporter"
-	FlagUploader = "uploader"
+	FlagFileHash      = "file-hash"
+	FlagReporter      = "reporter"
+	FlagUploader      = "uploader"
+	FlagFileSize      = "file-size"
+	FlagReplicaCount  = "replica-count"
+	FlagResourceNodes = "resource-nodes"
+	FlagContentType   = "content-type"
+	FlagExpiryHeight  = "expiry-height"
+	FlagMerkleRoot    = "merkle-root"
+	FlagMinUoz        = "min-uoz"
+	FlagFromHeight    = "from-height"
+	FlagToHeight      = "to-height"
+	FlagLeaf          = "leaf"
+	FlagProof         = "proof"
 )
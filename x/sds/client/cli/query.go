@@ -10,11 +10,13 @@ import (
 	// "strings"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stratosnet/stratos-chain/x/register"
 	"github.com/stratosnet/stratos-chain/x/sds/types"
 )
 
@@ -33,6 +35,12 @@ func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
 		flags.GetCommands(
 			GetCmdQueryUploadedFile(queryRoute, cdc),
 			GetCmdQueryPrepayBalance(queryRoute, cdc),
+			GetCmdQueryOzoneBalance(queryRoute, cdc),
+			GetCmdQueryUozPriceHistory(queryRoute, cdc),
+			GetCmdQueryFilesByUploader(queryRoute, cdc),
+			GetCmdQueryFilesByReporter(queryRoute, cdc),
+			GetCmdQueryChallenges(queryRoute, cdc),
+			GetCmdQueryDeal(queryRoute, cdc),
 		)...,
 	)
 
@@ -62,7 +70,10 @@ $ %s query sds upload c03661732294feb49caf6dc16c7cbb2534986d73
 			if err != nil {
 				return err
 			}
-			fi := types.MustUnmarshalFileInfo(cdc, resp)
+			var fi types.FileInfo
+			if err := cdc.UnmarshalJSON(resp, &fi); err != nil {
+				return err
+			}
 			return cliCtx.PrintOutput(fi.String())
 		},
 	}
@@ -100,3 +111,202 @@ $ %s query sds prepay st1yx3kkx9jnqeck59j744nc5qgtv4lt4dc45jcwz
 		},
 	}
 }
+
+// GetCmdQueryOzoneBalance implements the query ozone balance command.
+func GetCmdQueryOzoneBalance(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "ozone-balance [acct_addr]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Query the remaining uoz of an account",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query the remaining uoz of an account, purchased by prepay and not consumed yet.
+
+Example:
+$ %s query sds ozone-balance st1yx3kkx9jnqeck59j744nc5qgtv4lt4dc45jcwz
+`,
+				version.ClientName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			resp, _, err := common.QueryOzoneBalance(cliCtx, queryRoute, args[0])
+			if err != nil {
+				return err
+			}
+			var ozoneBalance sdk.Int
+			if err := ozoneBalance.UnmarshalJSON(resp); err != nil {
+				return err
+			}
+			return cliCtx.PrintOutput(ozoneBalance.String())
+		},
+	}
+}
+
+// GetCmdQueryChallenges implements the query pending challenges command.
+func GetCmdQueryChallenges(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "challenges [network_addr]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Query the pending proof-of-storage challenges of a resource node",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query the pending proof-of-storage challenges of a resource node.
+
+Example:
+$ %s query sds challenges stsds1yx3kkx9jnqeck59j744nc5qgtv4lt4dc45jcwz
+`,
+				version.ClientName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			resp, _, err := common.QueryChallenges(cliCtx, queryRoute, args[0])
+			if err != nil {
+				return err
+			}
+			var challenges []types.Challenge
+			if err := cdc.UnmarshalJSON(resp, &challenges); err != nil {
+				return err
+			}
+			return cliCtx.PrintOutput(challenges)
+		},
+	}
+}
+
+// GetCmdQueryDeal implements the query storage deal command.
+func GetCmdQueryDeal(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "deal [file_hash]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Query the storage deal of a file by hash",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query the storage deal of a file by hash, including the escrow left to pay the resource nodes.
+
+Example:
+$ %s query sds deal c03661732294feb49caf6dc16c7cbb2534986d73
+`,
+				version.ClientName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			resp, _, err := common.QueryDeal(cliCtx, queryRoute, args[0])
+			if err != nil {
+				return err
+			}
+			var deal types.Deal
+			if err := cdc.UnmarshalJSON(resp, &deal); err != nil {
+				return err
+			}
+			return cliCtx.PrintOutput(deal)
+		},
+	}
+}
+
+// GetCmdQueryUozPriceHistory implements the query uoz price history command.
+func GetCmdQueryUozPriceHistory(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "uoz-price-history",
+		Args:  cobra.NoArgs,
+		Short: "Query the uoz price & supply recorded in a range of heights",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query the uoz price, the remaining/total uoz supply and the total unissued prepay recorded
+at the beginning of each block in a range of heights. Only the most recent blocks are kept.
+
+Example:
+$ %s query sds uoz-price-history --from-height=1000 --to-height=2000 --page=1 --limit=100
+`,
+				version.ClientName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			resp, _, err := common.QueryUozPriceHistory(cliCtx, queryRoute, viper.GetInt64(FlagFromHeight), viper.GetInt64(FlagToHeight),
+				viper.GetInt(flags.FlagPage), viper.GetInt(flags.FlagLimit))
+			if err != nil {
+				return err
+			}
+			var snapshots []register.UozSnapshot
+			if err := cdc.UnmarshalJSON(resp, &snapshots); err != nil {
+				return err
+			}
+			return cliCtx.PrintOutput(snapshots)
+		},
+	}
+	cmd.Flags().Int64(FlagFromHeight, 0, "first height of the range")
+	cmd.Flags().Int64(FlagToHeight, 0, "last height of the range, 0 for the latest height")
+	cmd.Flags().Int(flags.FlagPage, 1, "pagination page of snapshots to query for")
+	cmd.Flags().Int(flags.FlagLimit, 100, "pagination limit of snapshots to query for")
+	return cmd
+}
+
+// GetCmdQueryFilesByUploader implements the query files by uploader command.
+func GetCmdQueryFilesByUploader(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "files-by-uploader [uploader_addr]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Query the files of an uploader",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query the files of an uploader.
+
+Example:
+$ %s query sds files-by-uploader st1yx3kkx9jnqeck59j744nc5qgtv4lt4dc45jcwz --page=1 --limit=10
+`,
+				version.ClientName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			resp, _, err := common.QueryFilesByUploader(cliCtx, queryRoute, args[0], viper.GetInt(flags.FlagPage), viper.GetInt(flags.FlagLimit))
+			if err != nil {
+				return err
+			}
+			var files []types.FileUpload
+			if err := cdc.UnmarshalJSON(resp, &files); err != nil {
+				return err
+			}
+			return cliCtx.PrintOutput(files)
+		},
+	}
+	cmd.Flags().Int(flags.FlagPage, 1, "pagination page of files to query for")
+	cmd.Flags().Int(flags.FlagLimit, 100, "pagination limit of files to query for")
+	return cmd
+}
+
+// GetCmdQueryFilesByReporter implements the query files by reporter command.
+func GetCmdQueryFilesByReporter(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "files-by-reporter [reporter_network_addr]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Query the files reported by an sp node",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query the files reported by an sp node.
+
+Example:
+$ %s query sds files-by-reporter stsds1yx3kkx9jnqeck59j744nc5qgtv4lt4dc45jcwz --page=1 --limit=10
+`,
+				version.ClientName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			resp, _, err := common.QueryFilesByReporter(cliCtx, queryRoute, args[0], viper.GetInt(flags.FlagPage), viper.GetInt(flags.FlagLimit))
+			if err != nil {
+				return err
+			}
+			var files []types.FileUpload
+			if err := cdc.UnmarshalJSON(resp, &files); err != nil {
+				return err
+			}
+			return cliCtx.PrintOutput(files)
+		},
+	}
+	cmd.Flags().Int(flags.FlagPage, 1, "pagination page of files to query for")
+	cmd.Flags().Int(flags.FlagLimit, 100, "pagination limit of files to query for")
+	return cmd
+}
@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"encoding/hex"
 	"fmt"
+	"strconv"
 
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/x/auth"
@@ -11,6 +12,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	stratos "github.com/stratosnet/stratos-chain/types"
+	"github.com/tendermint/tendermint/crypto/merkle"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
@@ -32,6 +34,12 @@ func GetTxCmd(cdc *codec.Codec) *cobra.Command {
 	sdsTxCmd.AddCommand(
 		FileUploadTxCmd(cdc),
 		PrepayTxCmd(cdc),
+		SellOzoneTxCmd(cdc),
+		FileDeleteTxCmd(cdc),
+		FileTransferOwnershipTxCmd(cdc),
+		FileAcknowledgeTxCmd(cdc),
+		ChallengeProofTxCmd(cdc),
+		CreateDealTxCmd(cdc),
 	)
 	return sdsTxCmd
 }
@@ -63,8 +71,23 @@ func FileUploadTxCmd(cdc *codec.Codec) *cobra.Command {
 				return err
 			}
 
+			var resourceNodes []stratos.SdsAddress
+			for _, resourceNodeStr := range viper.GetStringSlice(FlagResourceNodes) {
+				resourceNode, err := stratos.SdsAddressFromBech32(resourceNodeStr)
+				if err != nil {
+					return err
+				}
+				resourceNodes = append(resourceNodes, resourceNode)
+			}
+
+			merkleRoot, err := hex.DecodeString(viper.GetString(FlagMerkleRoot))
+			if err != nil {
+				return err
+			}
+
 			// build and sign the transaction, then broadcast to Tendermint
-			msg := types.NewMsgUpload(fileHash, cliCtx.GetFromAddress(), reporter, uploader)
+			msg := types.NewMsgUpload(fileHash, cliCtx.GetFromAddress(), reporter, uploader, viper.GetUint64(FlagFileSize),
+				viper.GetUint32(FlagReplicaCount), resourceNodes, viper.GetString(FlagContentType), viper.GetInt64(FlagExpiryHeight), merkleRoot)
 			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
 	}
@@ -73,11 +96,19 @@ func FileUploadTxCmd(cdc *codec.Codec) *cobra.Command {
 	cmd.Flags().String(FlagFileHash, "", "Hash of uploaded file")
 	cmd.Flags().String(FlagReporter, "", "Reporter of file")
 	cmd.Flags().String(FlagUploader, "", "Uploader of file")
+	cmd.Flags().Uint64(FlagFileSize, 0, "Size of file in bytes")
+	cmd.Flags().Uint32(FlagReplicaCount, 1, "Number of replicas of file")
+	cmd.Flags().StringSlice(FlagResourceNodes, nil, "Comma separated network addresses of the resource nodes holding the file shards")
+	cmd.Flags().String(FlagContentType, "", "Content type of file")
+	cmd.Flags().Int64(FlagExpiryHeight, 0, "Height the file expires at, 0 if it never expires")
+	cmd.Flags().String(FlagMerkleRoot, "", "Hex encoded merkle root of the file chunks, files without it are not challenged")
 
 	cmd.MarkFlagRequired(flags.FlagFrom)
 	cmd.MarkFlagRequired(FlagFileHash)
 	cmd.MarkFlagRequired(FlagReporter)
 	cmd.MarkFlagRequired(FlagUploader)
+	cmd.MarkFlagRequired(FlagFileSize)
+	cmd.MarkFlagRequired(FlagResourceNodes)
 
 	return cmd
 }
@@ -97,14 +128,241 @@ func PrepayTxCmd(cdc *codec.Codec) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			minUoz, ok := sdk.NewIntFromString(viper.GetString(FlagMinUoz))
+			if !ok {
+				return fmt.Errorf("invalid minimum uoz: %s", viper.GetString(FlagMinUoz))
+			}
+
+			// build and sign the transaction, then broadcast to Tendermint
+			msg := types.NewMsgPrepay(cliCtx.GetFromAddress(), coins, minUoz)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	cmd.Flags().String(FlagMinUoz, "0", "Minimum uoz to purchase, the tx fails if fewer uoz are purchased. 0 accepts any amount")
+
+	cmd = flags.PostCommands(cmd)[0]
+
+	return cmd
+}
+
+// SellOzoneTxCmd will create a tx selling back uoz and sign it with the given key.
+func SellOzoneTxCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sell-ozone [from_address] [amount]",
+		Short: "Create and sign a tx selling back unused uoz for ustos",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInputAndFrom(inBuf, args[0]).WithCodec(cdc)
+
+			amount, ok := sdk.NewIntFromString(args[1])
+			if !ok {
+				return fmt.Errorf("invalid amount of uoz: %s", args[1])
+			}
+
+			// build and sign the transaction, then broadcast to Tendermint
+			msg := types.NewMsgSellOzone(cliCtx.GetFromAddress(), amount)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd = flags.PostCommands(cmd)[0]
+
+	return cmd
+}
+
+// FileDeleteTxCmd will create a file delete tx and sign it with the given key.
+// When the deletion is reported by an sp node, the tx must be signed by the uploader as well.
+func FileDeleteTxCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete [flags]",
+		Short: "Create and sign a file delete tx",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			fileHash := viper.GetString(FlagFileHash)
+			_, err := hex.DecodeString(fileHash)
+			if err != nil {
+				return err
+			}
+
+			var reporter stratos.SdsAddress
+			if reporterStr := viper.GetString(FlagReporter); reporterStr != "" {
+				reporter, err = stratos.SdsAddressFromBech32(reporterStr)
+				if err != nil {
+					return err
+				}
+			}
+
+			uploader := cliCtx.GetFromAddress()
+			if uploaderStr := viper.GetString(FlagUploader); uploaderStr != "" {
+				uploader, err = sdk.AccAddressFromBech32(uploaderStr)
+				if err != nil {
+					return err
+				}
+			}
+
+			// build and sign the transaction, then broadcast to Tendermint
+			msg := types.NewMsgFileDelete(fileHash, cliCtx.GetFromAddress(), reporter, uploader)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	cmd = flags.PostCommands(cmd)[0]
+	cmd.Flags().String(FlagFileHash, "", "Hash of uploaded file")
+	cmd.Flags().String(FlagReporter, "", "Sp node reporting the deletion, empty if deleted by the uploader")
+	cmd.Flags().String(FlagUploader, "", "Uploader of file, the sender by default")
+
+	cmd.MarkFlagRequired(flags.FlagFrom)
+	cmd.MarkFlagRequired(FlagFileHash)
+
+	return cmd
+}
+
+// FileTransferOwnershipTxCmd will create a file ownership transfer tx and sign it with the key of the uploader.
+func FileTransferOwnershipTxCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "transfer-ownership [file_hash] [new_owner]",
+		Short: "Create and sign a file ownership transfer tx",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			_, err := hex.DecodeString(args[0])
+			if err != nil {
+				return err
+			}
+
+			newOwner, err := sdk.AccAddressFromBech32(args[1])
+			if err != nil {
+				return err
+			}
 
 			// build and sign the transaction, then broadcast to Tendermint
-			msg := types.NewMsgPrepay(cliCtx.GetFromAddress(), coins)
+			msg := types.NewMsgFileTransferOwnership(args[0], cliCtx.GetFromAddress(), newOwner)
 			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
 	}
+	cmd = flags.PostCommands(cmd)[0]
+	cmd.MarkFlagRequired(flags.FlagFrom)
+
+	return cmd
+}
+
+// FileAcknowledgeTxCmd will create a tx acknowledging that a resource node holds a file and sign it with the key of
+// the owner of the resource node.
+func FileAcknowledgeTxCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "acknowledge [network_addr] [file_hash]",
+		Short: "Create and sign a tx acknowledging that a resource node holds a file",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			resourceNode, err := stratos.SdsAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			// build and sign the transaction, then broadcast to Tendermint
+			msg := types.NewMsgFileAcknowledge(cliCtx.GetFromAddress(), resourceNode, args[1])
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	cmd = flags.PostCommands(cmd)[0]
+	cmd.MarkFlagRequired(flags.FlagFrom)
+
+	return cmd
+}
+
+// ChallengeProofTxCmd will create a tx answering a proof-of-storage challenge and sign it with the key of
+// the owner of the challenged resource node.
+func ChallengeProofTxCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "challenge-proof [network_addr] [file_hash]",
+		Short: "Create and sign a tx answering a proof-of-storage challenge",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			resourceNode, err := stratos.SdsAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			leaf, err := hex.DecodeString(viper.GetString(FlagLeaf))
+			if err != nil {
+				return err
+			}
+
+			var proof merkle.SimpleProof
+			if err := cdc.UnmarshalJSON([]byte(viper.GetString(FlagProof)), &proof); err != nil {
+				return err
+			}
+
+			// build and sign the transaction, then broadcast to Tendermint
+			msg := types.NewMsgChallengeProof(cliCtx.GetFromAddress(), resourceNode, args[1], leaf, proof)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	cmd = flags.PostCommands(cmd)[0]
+	cmd.Flags().String(FlagLeaf, "", "Hex encoded content of the challenged chunk")
+	cmd.Flags().String(FlagProof, "", "JSON encoded merkle proof of the challenged chunk")
+
+	cmd.MarkFlagRequired(flags.FlagFrom)
+	cmd.MarkFlagRequired(FlagLeaf)
+	cmd.MarkFlagRequired(FlagProof)
+
+	return cmd
+}
+
+// CreateDealTxCmd will create a storage deal tx and sign it with the key of the uploader.
+func CreateDealTxCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create-deal [file_hash] [file_size] [duration] [escrow]",
+		Short: "Create and sign a tx locking an escrow to pay for the storage of a file during a number of epochs",
+		Args:  cobra.ExactArgs(4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			_, err := hex.DecodeString(args[0])
+			if err != nil {
+				return err
+			}
+
+			fileSize, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid file size: %s", args[1])
+			}
+
+			duration, err := strconv.ParseInt(args[2], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid duration: %s", args[2])
+			}
+
+			escrow, err := sdk.ParseCoin(args[3])
+			if err != nil {
+				return err
+			}
 
+			// build and sign the transaction, then broadcast to Tendermint
+			msg := types.NewMsgCreateDeal(cliCtx.GetFromAddress(), args[0], fileSize, duration, escrow)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
 	cmd = flags.PostCommands(cmd)[0]
+	cmd.MarkFlagRequired(flags.FlagFrom)
 
 	return cmd
 }
@@ -4,6 +4,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	stratos "github.com/stratosnet/stratos-chain/types"
 	sds "github.com/stratosnet/stratos-chain/x/sds/types"
 
 	"github.com/cosmos/cosmos-sdk/client/context"
@@ -29,6 +30,36 @@ func QueryPrepayBalance(cliCtx context.CLIContext, queryRoute, sender string) ([
 	return cliCtx.QueryWithData(route, accAddr)
 }
 
+// QueryOzoneBalance queries the remaining uoz of an account
+func QueryOzoneBalance(cliCtx context.CLIContext, queryRoute, address string) ([]byte, int64, error) {
+	accAddr, err := sdk.AccAddressFromBech32(address)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid address, please specify an address in Bech32 format %w", err)
+	}
+	route := fmt.Sprintf("custom/%s/%s", queryRoute, sds.QueryOzoneBalance)
+	return cliCtx.QueryWithData(route, accAddr)
+}
+
+// QueryChallenges queries the pending challenges of a resource node
+func QueryChallenges(cliCtx context.CLIContext, queryRoute, networkAddr string) ([]byte, int64, error) {
+	resourceNode, err := stratos.SdsAddressFromBech32(networkAddr)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid network address, please specify an address in Bech32 format %w", err)
+	}
+	route := fmt.Sprintf("custom/%s/%s", queryRoute, sds.QueryChallenges)
+	return cliCtx.QueryWithData(route, resourceNode)
+}
+
+// QueryDeal queries the storage deal of a file
+func QueryDeal(cliCtx context.CLIContext, queryRoute, fileHashHex string) ([]byte, int64, error) {
+	_, err := hex.DecodeString(fileHashHex)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid file hash, please specify a hash in hex format %w", err)
+	}
+	route := fmt.Sprintf("custom/%s/%s", queryRoute, sds.QueryDeal)
+	return cliCtx.QueryWithData(route, []byte(fileHashHex))
+}
+
 // QuerySimulatePrepay queries the ongoing price for prepay
 func QuerySimulatePrepay(cliCtx context.CLIContext, queryRoute string, amtToPrepay sdk.Int) ([]byte, int64, error) {
 	amtByteArray, err := amtToPrepay.MarshalJSON()
@@ -39,6 +70,16 @@ func QuerySimulatePrepay(cliCtx context.CLIContext, queryRoute string, amtToPrep
 	return cliCtx.QueryWithData(route, amtByteArray)
 }
 
+// QuerySimulateSellOzone queries the ustos refunded when selling back uoz
+func QuerySimulateSellOzone(cliCtx context.CLIContext, queryRoute string, amtToSell sdk.Int) ([]byte, int64, error) {
+	amtByteArray, err := amtToSell.MarshalJSON()
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid amount, please specify a valid amount of uoz to simulate the sale %w", err)
+	}
+	route := fmt.Sprintf("custom/%s/%s", queryRoute, sds.QuerySimulateSell)
+	return cliCtx.QueryWithData(route, amtByteArray)
+}
+
 // QueryCurrUozPrice queries the current price for uoz
 func QueryCurrUozPrice(cliCtx context.CLIContext, queryRoute string) ([]byte, int64, error) {
 	route := fmt.Sprintf("custom/%s/%s", queryRoute, sds.QueryCurrUozPrice)
@@ -50,3 +91,41 @@ func QueryUozSupply(cliCtx context.CLIContext, queryRoute string) ([]byte, int64
 	route := fmt.Sprintf("custom/%s/%s", queryRoute, sds.QueryUozSupply)
 	return cliCtx.QueryWithData(route, nil)
 }
+
+// QueryUozPriceHistory queries a page of the uoz price & supply snapshots recorded in a range of heights
+func QueryUozPriceHistory(cliCtx context.CLIContext, queryRoute string, fromHeight, toHeight int64, page, limit int) ([]byte, int64, error) {
+	bz, err := cliCtx.Codec.MarshalJSON(sds.NewQueryUozPriceHistoryParams(page, limit, fromHeight, toHeight))
+	if err != nil {
+		return nil, 0, err
+	}
+	route := fmt.Sprintf("custom/%s/%s", queryRoute, sds.QueryUozPriceHistory)
+	return cliCtx.QueryWithData(route, bz)
+}
+
+// QueryFilesByUploader queries a page of the files of an uploader
+func QueryFilesByUploader(cliCtx context.CLIContext, queryRoute, uploader string, page, limit int) ([]byte, int64, error) {
+	accAddr, err := sdk.AccAddressFromBech32(uploader)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid uploader, please specify an uploader in Bech32 format %w", err)
+	}
+	bz, err := cliCtx.Codec.MarshalJSON(sds.NewQueryFilesByUploaderParams(page, limit, accAddr))
+	if err != nil {
+		return nil, 0, err
+	}
+	route := fmt.Sprintf("custom/%s/%s", queryRoute, sds.QueryFilesByUploader)
+	return cliCtx.QueryWithData(route, bz)
+}
+
+// QueryFilesByReporter queries a page of the files reported by an sp node
+func QueryFilesByReporter(cliCtx context.CLIContext, queryRoute, reporter string, page, limit int) ([]byte, int64, error) {
+	sdsAddr, err := stratos.SdsAddressFromBech32(reporter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid reporter, please specify a reporter in Bech32 format %w", err)
+	}
+	bz, err := cliCtx.Codec.MarshalJSON(sds.NewQueryFilesByReporterParams(page, limit, sdsAddr))
+	if err != nil {
+		return nil, 0, err
+	}
+	route := fmt.Sprintf("custom/%s/%s", queryRoute, sds.QueryFilesByReporter)
+	return cliCtx.QueryWithData(route, bz)
+}
@@ -3,6 +3,7 @@ package rest
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/stratosnet/stratos-chain/x/sds/client/common"
@@ -19,14 +20,42 @@ func registerSdsQueryRoutes(cliCtx context.CLIContext, r *mux.Router, queryRoute
 		"/sds/simulatePrepay/{amtToPrepay}",
 		SimulatePrepayHandlerFn(cliCtx, queryRoute),
 	).Methods("GET")
+	r.HandleFunc(
+		"/sds/simulateSellOzone/{amtToSell}",
+		SimulateSellOzoneHandlerFn(cliCtx, queryRoute),
+	).Methods("GET")
 	r.HandleFunc(
 		"/sds/uozPrice",
 		UozPriceHandlerFn(cliCtx, queryRoute),
 	).Methods("GET")
+	r.HandleFunc(
+		"/sds/uozPrice/history",
+		UozPriceHistoryHandlerFn(cliCtx, queryRoute),
+	).Methods("GET")
 	r.HandleFunc(
 		"/sds/uozSupply",
 		UozSupplyHandlerFn(cliCtx, queryRoute),
 	).Methods("GET")
+	r.HandleFunc(
+		"/sds/ozoneBalance/{address}",
+		OzoneBalanceHandlerFn(cliCtx, queryRoute),
+	).Methods("GET")
+	r.HandleFunc(
+		"/sds/files/uploader/{uploader}",
+		FilesByUploaderHandlerFn(cliCtx, queryRoute),
+	).Methods("GET")
+	r.HandleFunc(
+		"/sds/files/reporter/{reporter}",
+		FilesByReporterHandlerFn(cliCtx, queryRoute),
+	).Methods("GET")
+	r.HandleFunc(
+		"/sds/challenges/{networkAddr}",
+		ChallengesHandlerFn(cliCtx, queryRoute),
+	).Methods("GET")
+	r.HandleFunc(
+		"/sds/deal/{fileHash}",
+		DealHandlerFn(cliCtx, queryRoute),
+	).Methods("GET")
 }
 
 // HTTP request handler to query the simulated purchased amt of prepay
@@ -57,6 +86,35 @@ func SimulatePrepayHandlerFn(cliCtx context.CLIContext, queryRoute string) http.
 	}
 }
 
+// HTTP request handler to query the simulated refund of selling back uoz
+func SimulateSellOzoneHandlerFn(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+		amtToSellStr := mux.Vars(r)["amtToSell"]
+		amtToSell, ok := sdk.NewIntFromString(amtToSellStr)
+		if !ok {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "invalid amount of uoz: "+amtToSellStr)
+			return
+		}
+		resp, height, err := common.QuerySimulateSellOzone(cliCtx, queryRoute, amtToSell)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		var refund sdk.Int
+		err = refund.UnmarshalJSON(resp)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, refund)
+	}
+}
+
 // HTTP request handler to query ongoing uoz price
 func UozPriceHandlerFn(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -81,6 +139,62 @@ func UozPriceHandlerFn(cliCtx context.CLIContext, queryRoute string) http.Handle
 	}
 }
 
+// HTTP request handler to query the remaining uoz of an account
+func OzoneBalanceHandlerFn(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+		resp, height, err := common.QueryOzoneBalance(cliCtx, queryRoute, mux.Vars(r)["address"])
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		var ozoneBalance sdk.Int
+		err = ozoneBalance.UnmarshalJSON(resp)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, ozoneBalance)
+	}
+}
+
+// HTTP request handler to query a page of the uoz price & supply history,
+// the range of heights is given by the from_height & to_height query params
+func UozPriceHistoryHandlerFn(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_, page, limit, err := rest.ParseHTTPArgsWithLimit(r, 0)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		var fromHeight, toHeight int64
+		for param, height := range map[string]*int64{"from_height": &fromHeight, "to_height": &toHeight} {
+			if heightStr := r.FormValue(param); heightStr != "" {
+				*height, err = strconv.ParseInt(heightStr, 10, 64)
+				if err != nil {
+					rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+					return
+				}
+			}
+		}
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+		resp, height, err := common.QueryUozPriceHistory(cliCtx, queryRoute, fromHeight, toHeight, page, limit)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, resp)
+	}
+}
+
 // HTTP request handler to query uoz supply details
 func UozSupplyHandlerFn(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -118,3 +232,81 @@ func checkAmtToPrepayVar(w http.ResponseWriter, r *http.Request) (sdk.Int, bool)
 	}
 	return amtToPrepay, true
 }
+
+// HTTP request handler to query a page of the files of an uploader
+func FilesByUploaderHandlerFn(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_, page, limit, err := rest.ParseHTTPArgsWithLimit(r, 0)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+		resp, height, err := common.QueryFilesByUploader(cliCtx, queryRoute, mux.Vars(r)["uploader"], page, limit)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, resp)
+	}
+}
+
+// HTTP request handler to query a page of the files reported by an sp node
+func FilesByReporterHandlerFn(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_, page, limit, err := rest.ParseHTTPArgsWithLimit(r, 0)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+		resp, height, err := common.QueryFilesByReporter(cliCtx, queryRoute, mux.Vars(r)["reporter"], page, limit)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, resp)
+	}
+}
+
+// HTTP request handler to query the pending challenges of a resource node
+func ChallengesHandlerFn(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+		resp, height, err := common.QueryChallenges(cliCtx, queryRoute, mux.Vars(r)["networkAddr"])
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, resp)
+	}
+}
+
+// HTTP request handler to query the storage deal of a file
+func DealHandlerFn(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+		resp, height, err := common.QueryDeal(cliCtx, queryRoute, mux.Vars(r)["fileHash"])
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, resp)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/gorilla/mux"
 	stratos "github.com/stratosnet/stratos-chain/types"
 	"github.com/stratosnet/stratos-chain/x/sds/types"
+	"github.com/tendermint/tendermint/crypto/merkle"
 
 	"github.com/cosmos/cosmos-sdk/client/context"
 )
@@ -18,6 +19,12 @@ import (
 func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router, queryRoute string) {
 	r.HandleFunc("/sds/file/upload", FileUploadRequestHandlerFn(cliCtx)).Methods("POST")
 	r.HandleFunc("/sds/prepay", PrepayRequestHandlerFn(cliCtx)).Methods("POST")
+	r.HandleFunc("/sds/sellOzone", SellOzoneRequestHandlerFn(cliCtx)).Methods("POST")
+	r.HandleFunc("/sds/file/delete", FileDeleteRequestHandlerFn(cliCtx)).Methods("POST")
+	r.HandleFunc("/sds/file/transferOwnership", FileTransferOwnershipRequestHandlerFn(cliCtx)).Methods("POST")
+	r.HandleFunc("/sds/file/acknowledge", FileAcknowledgeRequestHandlerFn(cliCtx)).Methods("POST")
+	r.HandleFunc("/sds/challenge/proof", ChallengeProofRequestHandlerFn(cliCtx)).Methods("POST")
+	r.HandleFunc("/sds/deal/create", CreateDealRequestHandlerFn(cliCtx)).Methods("POST")
 	registerSdsQueryRoutes(cliCtx, r, queryRoute)
 }
 
@@ -27,12 +34,66 @@ type FileUploadReq struct {
 	Reporter string       `json:"reporter" yaml:"reporter"`
 	FileHash string       `json:"file_hash" yaml:"file_hash"`
 	Uploader string       `json:"uploader" yaml:"uploader"`
+
+	FileSize      uint64   `json:"file_size" yaml:"file_size"`
+	ReplicaCount  uint32   `json:"replica_count" yaml:"replica_count"`
+	ResourceNodes []string `json:"resource_nodes" yaml:"resource_nodes"`
+	ContentType   string   `json:"content_type" yaml:"content_type"`
+	ExpiryHeight  int64    `json:"expiry_height" yaml:"expiry_height"`
+	MerkleRoot    string   `json:"merkle_root" yaml:"merkle_root"` // optional, hex encoded merkle root of the file chunks
 }
 
 // PrepayReq defines the properties of a prepay request's body.
 type PrepayReq struct {
 	BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
 	Amount  sdk.Coins    `json:"amount" yaml:"amount"`
+	MinUoz  sdk.Int      `json:"min_uoz" yaml:"min_uoz"` // optional, 0 accepts any amount of purchased uoz
+}
+
+// SellOzoneReq defines the properties of a request's body selling back uoz.
+type SellOzoneReq struct {
+	BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
+	Amount  sdk.Int      `json:"amount" yaml:"amount"`
+}
+
+// FileDeleteReq defines the properties of a file delete request's body.
+type FileDeleteReq struct {
+	BaseReq  rest.BaseReq `json:"base_req" yaml:"base_req"`
+	Reporter string       `json:"reporter" yaml:"reporter"`
+	FileHash string       `json:"file_hash" yaml:"file_hash"`
+	Uploader string       `json:"uploader" yaml:"uploader"`
+}
+
+// FileTransferOwnershipReq defines the properties of a file ownership transfer request's body.
+type FileTransferOwnershipReq struct {
+	BaseReq  rest.BaseReq `json:"base_req" yaml:"base_req"`
+	FileHash string       `json:"file_hash" yaml:"file_hash"`
+	NewOwner string       `json:"new_owner" yaml:"new_owner"`
+}
+
+// FileAcknowledgeReq defines the properties of a request's body acknowledging that a resource node holds a file.
+type FileAcknowledgeReq struct {
+	BaseReq      rest.BaseReq `json:"base_req" yaml:"base_req"`
+	ResourceNode string       `json:"resource_node" yaml:"resource_node"`
+	FileHash     string       `json:"file_hash" yaml:"file_hash"`
+}
+
+// ChallengeProofReq defines the properties of a request's body answering a proof-of-storage challenge.
+type ChallengeProofReq struct {
+	BaseReq      rest.BaseReq       `json:"base_req" yaml:"base_req"`
+	ResourceNode string             `json:"resource_node" yaml:"resource_node"`
+	FileHash     string             `json:"file_hash" yaml:"file_hash"`
+	Leaf         string             `json:"leaf" yaml:"leaf"` // hex encoded content of the challenged chunk
+	Proof        merkle.SimpleProof `json:"proof" yaml:"proof"`
+}
+
+// CreateDealReq defines the properties of a storage deal creation request's body.
+type CreateDealReq struct {
+	BaseReq  rest.BaseReq `json:"base_req" yaml:"base_req"`
+	FileHash string       `json:"file_hash" yaml:"file_hash"`
+	FileSize uint64       `json:"file_size" yaml:"file_size"`
+	Duration int64        `json:"duration" yaml:"duration"` // number of epochs the storage is paid for
+	Escrow   sdk.Coin     `json:"escrow" yaml:"escrow"`
 }
 
 // FileUploadRequestHandlerFn - http request handler for file uploading.
@@ -73,7 +134,24 @@ func FileUploadRequestHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
-		msg := types.NewMsgUpload(fileHash, fromAddr, reporter, uploader)
+		var resourceNodes []stratos.SdsAddress
+		for _, resourceNodeStr := range req.ResourceNodes {
+			resourceNode, err := stratos.SdsAddressFromBech32(resourceNodeStr)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			resourceNodes = append(resourceNodes, resourceNode)
+		}
+
+		merkleRoot, err := hex.DecodeString(req.MerkleRoot)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgUpload(fileHash, fromAddr, reporter, uploader, req.FileSize, req.ReplicaCount, resourceNodes,
+			req.ContentType, req.ExpiryHeight, merkleRoot)
 		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
 	}
 }
@@ -97,7 +175,232 @@ func PrepayRequestHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
-		msg := types.NewMsgPrepay(fromAddr, req.Amount)
+		minUoz := req.MinUoz
+		if minUoz.IsNil() {
+			minUoz = sdk.ZeroInt()
+		}
+		msg := types.NewMsgPrepay(fromAddr, req.Amount, minUoz)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+// SellOzoneRequestHandlerFn - http request handler for selling back uoz.
+func SellOzoneRequestHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req SellOzoneReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		fromAddr, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgSellOzone(fromAddr, req.Amount)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+// FileDeleteRequestHandlerFn - http request handler for file deletion.
+func FileDeleteRequestHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req FileDeleteReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		fromAddr, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		var reporter stratos.SdsAddress
+		if req.Reporter != "" {
+			reporter, err = stratos.SdsAddressFromBech32(req.Reporter)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		_, err = hex.DecodeString(req.FileHash)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		uploader, err := sdk.AccAddressFromBech32(req.Uploader)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgFileDelete(req.FileHash, fromAddr, reporter, uploader)
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+// FileTransferOwnershipRequestHandlerFn - http request handler for file ownership transfer.
+func FileTransferOwnershipRequestHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req FileTransferOwnershipReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		fromAddr, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		_, err = hex.DecodeString(req.FileHash)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		newOwner, err := sdk.AccAddressFromBech32(req.NewOwner)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgFileTransferOwnership(req.FileHash, fromAddr, newOwner)
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+// FileAcknowledgeRequestHandlerFn - http request handler for acknowledging that a resource node holds a file.
+func FileAcknowledgeRequestHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req FileAcknowledgeReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		fromAddr, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		resourceNode, err := stratos.SdsAddressFromBech32(req.ResourceNode)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgFileAcknowledge(fromAddr, resourceNode, req.FileHash)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+// ChallengeProofRequestHandlerFn - http request handler for answering a proof-of-storage challenge.
+func ChallengeProofRequestHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req ChallengeProofReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		fromAddr, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		resourceNode, err := stratos.SdsAddressFromBech32(req.ResourceNode)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		leaf, err := hex.DecodeString(req.Leaf)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgChallengeProof(fromAddr, resourceNode, req.FileHash, leaf, req.Proof)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+// CreateDealRequestHandlerFn - http request handler for creating a storage deal.
+func CreateDealRequestHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req CreateDealReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		ownerAddr, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		_, err = hex.DecodeString(req.FileHash)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgCreateDeal(ownerAddr, req.FileHash, req.FileSize, req.Duration, req.Escrow)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
 		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
 	}
 }
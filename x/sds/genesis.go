@@ -13,6 +13,14 @@ func InitGenesis(ctx sdk.Context, keeper Keeper, data types.GenesisState) {
 	for _, file := range data.FileUpload {
 		keeper.SetFileHash(ctx, []byte(file.FileHash), file.FileInfo)
 	}
+
+	for _, challenge := range data.Challenges {
+		keeper.SetChallenge(ctx, challenge)
+	}
+
+	for _, deal := range data.Deals {
+		keeper.SetDeal(ctx, deal)
+	}
 }
 
 // ExportGenesis writes the current store values
@@ -27,5 +35,17 @@ func ExportGenesis(ctx sdk.Context, keeper Keeper) (data types.GenesisState) {
 		return false
 	})
 
-	return types.NewGenesisState(params, fileUpload)
+	var challenges []types.Challenge
+	keeper.IterateChallenges(ctx, func(challenge types.Challenge) (stop bool) {
+		challenges = append(challenges, challenge)
+		return false
+	})
+
+	var deals []types.Deal
+	keeper.IterateDeals(ctx, func(deal types.Deal) (stop bool) {
+		deals = append(deals, deal)
+		return false
+	})
+
+	return types.NewGenesisState(params, fileUpload, challenges, deals)
 }
@@ -2,6 +2,8 @@ package sds
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
@@ -18,6 +20,18 @@ func NewHandler(k keeper.Keeper) sdk.Handler {
 			return handleMsgFileUpload(ctx, k, msg)
 		case types.MsgPrepay:
 			return handleMsgPrepay(ctx, k, msg)
+		case types.MsgSellOzone:
+			return handleMsgSellOzone(ctx, k, msg)
+		case types.MsgFileDelete:
+			return handleMsgFileDelete(ctx, k, msg)
+		case types.MsgFileTransferOwnership:
+			return handleMsgFileTransferOwnership(ctx, k, msg)
+		case types.MsgFileAcknowledge:
+			return handleMsgFileAcknowledge(ctx, k, msg)
+		case types.MsgChallengeProof:
+			return handleMsgChallengeProof(ctx, k, msg)
+		case types.MsgCreateDeal:
+			return handleMsgCreateDeal(ctx, k, msg)
 
 		default:
 			errMsg := fmt.Sprintf("unrecognized %s message type: %T", types.ModuleName, msg)
@@ -28,16 +42,34 @@ func NewHandler(k keeper.Keeper) sdk.Handler {
 
 // Handle MsgFileUpload.
 func handleMsgFileUpload(ctx sdk.Context, k keeper.Keeper, msg types.MsgFileUpload) (*sdk.Result, error) {
-	// check if reporter addr belongs to an registered sp node
-	if _, found := k.RegisterKeeper.GetIndexingNode(ctx, msg.Reporter); found == false {
+	// check if reporter addr belongs to an registered sp node of the sender
+	reporter, found := k.RegisterKeeper.GetIndexingNode(ctx, msg.Reporter)
+	if found == false {
 		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "Reporter %s isn't an SP node", msg.Reporter.String())
 	}
+	if !reporter.OwnerAddress.Equals(msg.From) {
+		return nil, types.ErrInvalidReporter
+	}
+	if msg.ExpiryHeight > 0 && msg.ExpiryHeight <= ctx.BlockHeight() {
+		return nil, sdkerrors.Wrapf(types.ErrInvalidExpiryHeight, "file expires at height %d before current height %d", msg.ExpiryHeight, ctx.BlockHeight())
+	}
+	for _, resourceNode := range msg.ResourceNodes {
+		if _, found := k.RegisterKeeper.GetResourceNode(ctx, resourceNode); !found {
+			return nil, sdkerrors.Wrapf(types.ErrNoResourceNodeFound, "resource node %s", resourceNode.String())
+		}
+	}
+	// only the uploader of an existing file can upload it again
+	if oldFileInfo, err := k.GetFileInfoByFileHash(ctx, []byte(msg.FileHash)); err == nil && !oldFileInfo.Uploader.Equals(msg.Uploader) {
+		return nil, types.ErrFileExists
+	}
 	height := sdk.NewInt(ctx.BlockHeight())
 	heightByteArr, _ := height.MarshalJSON()
 	var heightReEncoded sdk.Int
 	heightReEncoded.UnmarshalJSON(heightByteArr)
 
-	fileInfo := types.NewFileInfo(heightReEncoded, msg.Reporter, msg.Uploader)
+	// the resource nodes acknowledge holding the file again after it is uploaded again
+	fileInfo := types.NewFileInfo(heightReEncoded, msg.Reporter, msg.Uploader, msg.FileSize, msg.ReplicaCount,
+		msg.ResourceNodes, msg.ContentType, msg.ExpiryHeight, msg.MerkleRoot)
 	fileHashByte := []byte(msg.FileHash)
 	k.SetFileHash(ctx, fileHashByte, fileInfo)
 
@@ -48,6 +80,9 @@ func handleMsgFileUpload(ctx sdk.Context, k keeper.Keeper, msg types.MsgFileUplo
 			sdk.NewAttribute(types.AttributeKeyReporter, msg.Reporter.String()),
 			sdk.NewAttribute(types.AttributeKeyUploader, msg.Uploader.String()),
 			sdk.NewAttribute(types.AttributeKeyFileHash, msg.FileHash),
+			sdk.NewAttribute(types.AttributeKeyFileSize, strconv.FormatUint(msg.FileSize, 10)),
+			sdk.NewAttribute(types.AttributeKeyReplicaCount, strconv.FormatUint(uint64(msg.ReplicaCount), 10)),
+			sdk.NewAttribute(types.AttributeKeyExpiryHeight, strconv.FormatInt(msg.ExpiryHeight, 10)),
 		),
 		sdk.NewEvent(
 			sdk.EventTypeMessage,
@@ -68,6 +103,10 @@ func handleMsgPrepay(ctx sdk.Context, k keeper.Keeper, msg types.MsgPrepay) (*sd
 	if err != nil {
 		return nil, err
 	}
+	// the state changes of the prepay are discarded along with the failed tx
+	if !msg.MinUoz.IsNil() && purchased.LT(msg.MinUoz) {
+		return nil, sdkerrors.Wrapf(types.ErrUozBelowMinimum, "purchased %s uoz, minimum %s uoz", purchased.String(), msg.MinUoz.String())
+	}
 
 	ctx.EventManager().EmitEvents(sdk.Events{
 		sdk.NewEvent(
@@ -85,3 +124,197 @@ func handleMsgPrepay(ctx sdk.Context, k keeper.Keeper, msg types.MsgPrepay) (*sd
 
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
 }
+
+// Handle MsgSellOzone.
+func handleMsgSellOzone(ctx sdk.Context, k keeper.Keeper, msg types.MsgSellOzone) (*sdk.Result, error) {
+	refund, fee, err := k.SellOzone(ctx, msg.Sender, msg.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeSellOzone,
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender.String()),
+			sdk.NewAttribute(types.AttributeKeySoldUoz, msg.Amount.String()),
+			sdk.NewAttribute(types.AttributeKeyRefund, refund.String()),
+			sdk.NewAttribute(types.AttributeKeyExitFee, fee.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+// Handle MsgFileDelete.
+func handleMsgFileDelete(ctx sdk.Context, k keeper.Keeper, msg types.MsgFileDelete) (*sdk.Result, error) {
+	fileHashByte := []byte(msg.FileHash)
+	fileInfo, err := k.GetFileInfoByFileHash(ctx, fileHashByte)
+	if err != nil {
+		return nil, sdkerrors.Wrap(types.ErrNoFileFound, msg.FileHash)
+	}
+	if !fileInfo.Uploader.Equals(msg.Uploader) {
+		return nil, types.ErrNotFileOwner
+	}
+	// the deletion reported by an sp node is paid by the owner of the sp node
+	if !msg.Reporter.Empty() {
+		indexingNode, found := k.RegisterKeeper.GetIndexingNode(ctx, msg.Reporter)
+		if !found || !indexingNode.OwnerAddress.Equals(msg.From) {
+			return nil, types.ErrInvalidReporter
+		}
+	}
+	// the escrow of the storage deal not paid yet is refunded along with the deletion
+	if _, found := k.GetDeal(ctx, fileHashByte); found {
+		if _, err := k.CancelDeal(ctx, fileHashByte); err != nil {
+			return nil, err
+		}
+	}
+	k.DeleteFileHash(ctx, fileHashByte)
+
+	resourceNodes := make([]string, 0, len(fileInfo.ResourceNodes))
+	for _, resourceNode := range fileInfo.ResourceNodes {
+		resourceNodes = append(resourceNodes, resourceNode.String())
+	}
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeFileDelete,
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.From.String()),
+			sdk.NewAttribute(types.AttributeKeyReporter, msg.Reporter.String()),
+			sdk.NewAttribute(types.AttributeKeyUploader, msg.Uploader.String()),
+			sdk.NewAttribute(types.AttributeKeyFileHash, msg.FileHash),
+			sdk.NewAttribute(types.AttributeKeyResourceNodes, strings.Join(resourceNodes, ",")),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.From.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+// Handle MsgFileTransferOwnership.
+func handleMsgFileTransferOwnership(ctx sdk.Context, k keeper.Keeper, msg types.MsgFileTransferOwnership) (*sdk.Result, error) {
+	fileHashByte := []byte(msg.FileHash)
+	fileInfo, err := k.GetFileInfoByFileHash(ctx, fileHashByte)
+	if err != nil {
+		return nil, sdkerrors.Wrap(types.ErrNoFileFound, msg.FileHash)
+	}
+	if !fileInfo.Uploader.Equals(msg.Uploader) {
+		return nil, types.ErrNotFileOwner
+	}
+	fileInfo.Uploader = msg.NewOwner
+	k.SetFileHash(ctx, fileHashByte, fileInfo)
+	// the storage deal of the file follows the ownership, its remaining escrow is refunded to the new owner
+	if deal, found := k.GetDeal(ctx, fileHashByte); found {
+		deal.Owner = msg.NewOwner
+		k.SetDeal(ctx, deal)
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeFileTransferOwnership,
+			sdk.NewAttribute(types.AttributeKeyUploader, msg.Uploader.String()),
+			sdk.NewAttribute(types.AttributeKeyNewOwner, msg.NewOwner.String()),
+			sdk.NewAttribute(types.AttributeKeyFileHash, msg.FileHash),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Uploader.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+// Handle MsgFileAcknowledge.
+func handleMsgFileAcknowledge(ctx sdk.Context, k keeper.Keeper, msg types.MsgFileAcknowledge) (*sdk.Result, error) {
+	resourceNode, found := k.RegisterKeeper.GetResourceNode(ctx, msg.ResourceNode)
+	if !found {
+		return nil, sdkerrors.Wrapf(types.ErrNoResourceNodeFound, "resource node %s", msg.ResourceNode.String())
+	}
+	if !resourceNode.OwnerAddress.Equals(msg.Sender) {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "%s isn't the owner of resource node %s", msg.Sender.String(), msg.ResourceNode.String())
+	}
+	if err := k.AcknowledgeFile(ctx, msg.ResourceNode, []byte(msg.FileHash)); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeFileAcknowledge,
+			sdk.NewAttribute(types.AttributeKeyResourceNode, msg.ResourceNode.String()),
+			sdk.NewAttribute(types.AttributeKeyFileHash, msg.FileHash),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+// Handle MsgChallengeProof.
+func handleMsgChallengeProof(ctx sdk.Context, k keeper.Keeper, msg types.MsgChallengeProof) (*sdk.Result, error) {
+	challenge, found := k.GetChallenge(ctx, msg.ResourceNode, []byte(msg.FileHash))
+	if !found {
+		return nil, sdkerrors.Wrapf(types.ErrNoChallengeFound, "resource node %s, file %s", msg.ResourceNode.String(), msg.FileHash)
+	}
+	resourceNode, found := k.RegisterKeeper.GetResourceNode(ctx, msg.ResourceNode)
+	if !found || !resourceNode.OwnerAddress.Equals(msg.Sender) {
+		return nil, types.ErrInvalidProver
+	}
+	passed, err := k.AnswerChallenge(ctx, challenge, msg.Leaf, msg.Proof)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeChallengeProof,
+			sdk.NewAttribute(types.AttributeKeyResourceNode, msg.ResourceNode.String()),
+			sdk.NewAttribute(types.AttributeKeyFileHash, msg.FileHash),
+			sdk.NewAttribute(types.AttributeKeyPassed, strconv.FormatBool(passed)),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+// Handle MsgCreateDeal.
+func handleMsgCreateDeal(ctx sdk.Context, k keeper.Keeper, msg types.MsgCreateDeal) (*sdk.Result, error) {
+	deal, err := k.CreateDeal(ctx, msg.Owner, []byte(msg.FileHash), msg.FileSize, msg.Duration, msg.Escrow)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeCreateDeal,
+			sdk.NewAttribute(types.AttributeKeyFileHash, msg.FileHash),
+			sdk.NewAttribute(types.AttributeKeyDuration, strconv.FormatInt(msg.Duration, 10)),
+			sdk.NewAttribute(types.AttributeKeyEscrow, msg.Escrow.String()),
+			sdk.NewAttribute(types.AttributeKeyEndHeight, strconv.FormatInt(deal.EndHeight, 10)),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Owner.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
@@ -0,0 +1,180 @@
+package keeper
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	stratos "github.com/stratosnet/stratos-chain/types"
+	"github.com/stratosnet/stratos-chain/x/sds/types"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+)
+
+// GetChallenge returns the pending challenge of the resource node on the file
+func (k Keeper) GetChallenge(ctx sdk.Context, resourceNode stratos.SdsAddress, fileHash []byte) (challenge types.Challenge, found bool) {
+	store := ctx.KVStore(k.key)
+	bz := store.Get(types.GetChallengeKey(resourceNode, fileHash))
+	if bz == nil {
+		return challenge, false
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &challenge)
+	return challenge, true
+}
+
+// SetChallenge sets a pending challenge
+func (k Keeper) SetChallenge(ctx sdk.Context, challenge types.Challenge) {
+	store := ctx.KVStore(k.key)
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(challenge)
+	store.Set(types.GetChallengeKey(challenge.ResourceNode, []byte(challenge.FileHash)), bz)
+}
+
+// DeleteChallenge removes a pending challenge
+func (k Keeper) DeleteChallenge(ctx sdk.Context, resourceNode stratos.SdsAddress, fileHash []byte) {
+	store := ctx.KVStore(k.key)
+	store.Delete(types.GetChallengeKey(resourceNode, fileHash))
+}
+
+// IterateChallenges Iterate over all pending challenges.
+func (k Keeper) IterateChallenges(ctx sdk.Context, handler func(types.Challenge) (stop bool)) {
+	k.iterateChallenges(ctx, types.ChallengeKeyPrefix, handler)
+}
+
+// IterateChallengesByNode Iterate over the pending challenges of a resource node.
+func (k Keeper) IterateChallengesByNode(ctx sdk.Context, resourceNode stratos.SdsAddress, handler func(types.Challenge) (stop bool)) {
+	k.iterateChallenges(ctx, types.GetChallengesByNodeKey(resourceNode), handler)
+}
+
+func (k Keeper) iterateChallenges(ctx sdk.Context, prefix []byte, handler func(types.Challenge) (stop bool)) {
+	store := ctx.KVStore(k.key)
+	iter := sdk.KVStorePrefixIterator(store, prefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var challenge types.Challenge
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &challenge)
+		if handler(challenge) {
+			break
+		}
+	}
+}
+
+// IssueChallenges is called in BeginBlocker, every ChallengeInterval blocks it challenges resource nodes on
+// the files they hold. The files and resource nodes are picked from a seed derived from the last block id,
+// so that every validator issues the same challenges.
+func (k Keeper) IssueChallenges(ctx sdk.Context) {
+	interval := k.ChallengeInterval(ctx)
+	if interval <= 0 || ctx.BlockHeight()%interval != 0 {
+		return
+	}
+
+	// only the live files committing to the merkle root of their chunks can be challenged,
+	// on the resource nodes which acknowledged holding them
+	var fileHashes []string
+	var fileInfos []types.FileInfo
+	k.IterateFileUpload(ctx, func(fileHash string, fileInfo types.FileInfo) (stop bool) {
+		if len(fileInfo.MerkleRoot) > 0 && len(fileInfo.AcknowledgedNodes) > 0 && !fileInfo.IsExpired(ctx.BlockHeight()) {
+			fileHashes = append(fileHashes, fileHash)
+			fileInfos = append(fileInfos, fileInfo)
+		}
+		return false
+	})
+	if len(fileHashes) == 0 {
+		return
+	}
+
+	lastBlockHash := ctx.BlockHeader().LastBlockId.Hash
+	height := sdk.Uint64ToBigEndian(uint64(ctx.BlockHeight()))
+	deadline := ctx.BlockHeight() + k.ChallengeDeadline(ctx)
+	for i := uint32(0); i < k.ChallengesPerRound(ctx); i++ {
+		seed := tmhash.Sum(bytes.Join([][]byte{lastBlockHash, height, sdk.Uint64ToBigEndian(uint64(i))}, nil))
+		fileIdx := binary.BigEndian.Uint64(seed[:8]) % uint64(len(fileHashes))
+		fileInfo := fileInfos[fileIdx]
+		resourceNode := fileInfo.AcknowledgedNodes[binary.BigEndian.Uint64(seed[8:16])%uint64(len(fileInfo.AcknowledgedNodes))]
+
+		if _, found := k.GetChallenge(ctx, resourceNode, []byte(fileHashes[fileIdx])); found {
+			continue
+		}
+		node, found := k.RegisterKeeper.GetResourceNode(ctx, resourceNode)
+		if !found || node.IsSuspended() {
+			continue
+		}
+
+		challenge := types.NewChallenge(fileHashes[fileIdx], resourceNode, seed, ctx.BlockHeight(), deadline)
+		k.SetChallenge(ctx, challenge)
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				types.EventTypeChallenge,
+				sdk.NewAttribute(types.AttributeKeyFileHash, challenge.FileHash),
+				sdk.NewAttribute(types.AttributeKeyResourceNode, challenge.ResourceNode.String()),
+				sdk.NewAttribute(types.AttributeKeyChunkSeed, fmt.Sprintf("%X", challenge.Seed)),
+				sdk.NewAttribute(types.AttributeKeyDeadline, strconv.FormatInt(challenge.Deadline, 10)),
+			),
+		)
+	}
+}
+
+// AnswerChallenge settles a pending challenge with the proof of the challenged chunk. A wrong proof fails the
+// challenge the same way as a missed deadline does.
+func (k Keeper) AnswerChallenge(ctx sdk.Context, challenge types.Challenge, leaf []byte, proof merkle.SimpleProof) (passed bool, err error) {
+	if challenge.IsExpired(ctx.BlockHeight()) {
+		return false, sdkerrors.Wrapf(types.ErrInvalidChallenge, "proof deadline %d is passed", challenge.Deadline)
+	}
+	fileInfo, err := k.GetFileInfoByFileHash(ctx, []byte(challenge.FileHash))
+	if err != nil {
+		return false, types.ErrNoFileFound
+	}
+
+	passed = proof.Index == challenge.ChunkIndex(proof.Total) && proof.Verify(fileInfo.MerkleRoot, leaf) == nil
+	if !passed {
+		return false, k.failChallenge(ctx, challenge)
+	}
+	k.DeleteChallenge(ctx, challenge.ResourceNode, []byte(challenge.FileHash))
+	return true, nil
+}
+
+// ExpireChallenges is called in EndBlocker, it fails the challenges whose deadline is reached without a proof
+func (k Keeper) ExpireChallenges(ctx sdk.Context) {
+	var expired []types.Challenge
+	k.IterateChallenges(ctx, func(challenge types.Challenge) (stop bool) {
+		if challenge.IsExpired(ctx.BlockHeight() + 1) {
+			expired = append(expired, challenge)
+		}
+		return false
+	})
+
+	for _, challenge := range expired {
+		if err := k.failChallenge(ctx, challenge); err != nil {
+			k.Logger(ctx).Error("failed to slash the resource node missing a challenge",
+				"resourceNode", challenge.ResourceNode.String(), "fileHash", challenge.FileHash, "err", err.Error())
+		}
+	}
+}
+
+// failChallenge removes the challenge then slashes and suspends the challenged resource node
+// through the pot slashing path
+func (k Keeper) failChallenge(ctx sdk.Context, challenge types.Challenge) error {
+	k.DeleteChallenge(ctx, challenge.ResourceNode, []byte(challenge.FileHash))
+
+	node, found := k.RegisterKeeper.GetResourceNode(ctx, challenge.ResourceNode)
+	if !found {
+		return types.ErrNoResourceNodeFound
+	}
+	slashing, _, _, err := k.PotKeeper.SlashingResourceNode(ctx, challenge.ResourceNode, node.OwnerAddress,
+		k.ChallengeSlashing(ctx), true)
+	if err != nil {
+		return err
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeChallengeFailed,
+			sdk.NewAttribute(types.AttributeKeyFileHash, challenge.FileHash),
+			sdk.NewAttribute(types.AttributeKeyResourceNode, challenge.ResourceNode.String()),
+			sdk.NewAttribute(types.AttributeKeySlashing, slashing.String()),
+		),
+	)
+	return nil
+}
@@ -0,0 +1,196 @@
+package keeper
+
+import (
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stratosnet/stratos-chain/x/sds/types"
+)
+
+// GetDeal returns the storage deal of the file
+func (k Keeper) GetDeal(ctx sdk.Context, fileHash []byte) (deal types.Deal, found bool) {
+	store := ctx.KVStore(k.key)
+	bz := store.Get(types.GetDealKey(fileHash))
+	if bz == nil {
+		return deal, false
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &deal)
+	return deal, true
+}
+
+// SetDeal sets the storage deal of a file
+func (k Keeper) SetDeal(ctx sdk.Context, deal types.Deal) {
+	store := ctx.KVStore(k.key)
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(deal)
+	store.Set(types.GetDealKey([]byte(deal.FileHash)), bz)
+}
+
+// DeleteDeal removes the storage deal of a file
+func (k Keeper) DeleteDeal(ctx sdk.Context, fileHash []byte) {
+	store := ctx.KVStore(k.key)
+	store.Delete(types.GetDealKey(fileHash))
+}
+
+// IterateDeals Iterate over all storage deals.
+func (k Keeper) IterateDeals(ctx sdk.Context, handler func(types.Deal) (stop bool)) {
+	store := ctx.KVStore(k.key)
+	iter := sdk.KVStorePrefixIterator(store, types.DealKeyPrefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var deal types.Deal
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &deal)
+		if handler(deal) {
+			break
+		}
+	}
+}
+
+// CreateDeal locks the escrow of the owner to pay for the storage of an uploaded file during the given number of
+// epochs. The end height of the deal is derived from the current epoch length, the file expires with the deal.
+func (k Keeper) CreateDeal(ctx sdk.Context, owner sdk.AccAddress, fileHash []byte, fileSize uint64, duration int64,
+	escrow sdk.Coin) (types.Deal, error) {
+
+	fileInfo, err := k.GetFileInfoByFileHash(ctx, fileHash)
+	if err != nil {
+		return types.Deal{}, sdkerrors.Wrap(types.ErrNoFileFound, string(fileHash))
+	}
+	if !fileInfo.Uploader.Equals(owner) {
+		return types.Deal{}, types.ErrNotFileOwner
+	}
+	if fileInfo.FileSize != fileSize {
+		return types.Deal{}, sdkerrors.Wrapf(types.ErrDealFileSizeMismatch, "uploaded %d bytes, deal of %d bytes", fileInfo.FileSize, fileSize)
+	}
+	if _, found := k.GetDeal(ctx, fileHash); found {
+		return types.Deal{}, types.ErrDealExists
+	}
+	if escrow.Denom != k.BondDenom(ctx) {
+		return types.Deal{}, sdkerrors.Wrapf(types.ErrInvalidCoins, "escrow must be paid in %s", k.BondDenom(ctx))
+	}
+
+	_, err = k.BankKeeper.SubtractCoins(ctx, owner, sdk.NewCoins(escrow))
+	if err != nil {
+		return types.Deal{}, err
+	}
+
+	epochLength := k.DealEpochLength(ctx)
+	firstPayment := (ctx.BlockHeight()/epochLength + 1) * epochLength
+	deal := types.NewDeal(string(fileHash), owner, fileSize, duration, escrow, ctx.BlockHeight(),
+		firstPayment+(duration-1)*epochLength)
+	k.SetDeal(ctx, deal)
+
+	fileInfo.ExpiryHeight = deal.EndHeight
+	k.SetFileHash(ctx, fileHash, fileInfo)
+	return deal, nil
+}
+
+// CancelDeal removes the storage deal of a file and refunds the remaining escrow to the owner of the deal
+func (k Keeper) CancelDeal(ctx sdk.Context, fileHash []byte) (refund sdk.Coin, err error) {
+	deal, found := k.GetDeal(ctx, fileHash)
+	if !found {
+		return refund, types.ErrNoDealFound
+	}
+	k.DeleteDeal(ctx, fileHash)
+	if deal.RemainingEscrow.IsPositive() {
+		_, err = k.BankKeeper.AddCoins(ctx, deal.Owner, sdk.NewCoins(deal.RemainingEscrow))
+		if err != nil {
+			return refund, err
+		}
+	}
+	return deal.RemainingEscrow, nil
+}
+
+// PayDeals is called in EndBlocker, at the end of each epoch every storage deal streams its payment of the epoch to
+// the owners of the active resource nodes which acknowledged holding the file. The deals paying their last epoch
+// expire along with their files, refunding the escrow left.
+func (k Keeper) PayDeals(ctx sdk.Context) {
+	if ctx.BlockHeight()%k.DealEpochLength(ctx) != 0 {
+		return
+	}
+
+	var deals []types.Deal
+	k.IterateDeals(ctx, func(deal types.Deal) (stop bool) {
+		deals = append(deals, deal)
+		return false
+	})
+
+	// each deal is paid with a cached context so that a failed payment leaves no partial state
+	for _, deal := range deals {
+		cacheCtx, write := ctx.CacheContext()
+		cacheCtx = cacheCtx.WithEventManager(sdk.NewEventManager())
+		if err := k.payDeal(cacheCtx, deal); err != nil {
+			k.Logger(ctx).Error("failed to pay the storage deal", "fileHash", deal.FileHash, "err", err.Error())
+			continue
+		}
+		write()
+		ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+	}
+}
+
+func (k Keeper) payDeal(ctx sdk.Context, deal types.Deal) error {
+	fileHash := []byte(deal.FileHash)
+	fileInfo, err := k.GetFileInfoByFileHash(ctx, fileHash)
+	if err != nil {
+		// the file is gone, nothing is stored for the remaining epochs
+		_, err = k.CancelDeal(ctx, fileHash)
+		return err
+	}
+
+	// only the active resource nodes which acknowledged holding the file are challenged, so only they are paid
+	var recipients []sdk.AccAddress
+	for _, resourceNode := range fileInfo.AcknowledgedNodes {
+		node, found := k.RegisterKeeper.GetResourceNode(ctx, resourceNode)
+		if found && !node.IsSuspended() {
+			recipients = append(recipients, node.OwnerAddress)
+		}
+	}
+
+	// the shares of the other resource nodes holding the file and the rounding remainder stay in escrow
+	payment := deal.NextPayment()
+	paid := sdk.NewCoin(payment.Denom, sdk.ZeroInt())
+	if len(fileInfo.ResourceNodes) > 0 {
+		share := sdk.NewCoin(payment.Denom, payment.Amount.QuoRaw(int64(len(fileInfo.ResourceNodes))))
+		if share.IsPositive() {
+			for _, recipient := range recipients {
+				_, err = k.BankKeeper.AddCoins(ctx, recipient, sdk.NewCoins(share))
+				if err != nil {
+					return err
+				}
+				paid = paid.Add(share)
+			}
+		}
+	}
+	deal.RemainingEscrow = deal.RemainingEscrow.Sub(paid)
+	deal.PaidEpochs++
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeDealPayment,
+			sdk.NewAttribute(types.AttributeKeyFileHash, deal.FileHash),
+			sdk.NewAttribute(types.AttributeKeyPayment, paid.String()),
+		),
+	)
+
+	if deal.PaidEpochs < deal.Duration {
+		k.SetDeal(ctx, deal)
+		return nil
+	}
+
+	// the deal expires, the escrow left is refunded and the file is cleaned up
+	k.SetDeal(ctx, deal)
+	refund, err := k.CancelDeal(ctx, fileHash)
+	if err != nil {
+		return err
+	}
+	k.DeleteFileHash(ctx, fileHash)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeDealExpired,
+			sdk.NewAttribute(types.AttributeKeyFileHash, deal.FileHash),
+			sdk.NewAttribute(types.AttributeKeyRefund, refund.String()),
+			sdk.NewAttribute(types.AttributeKeyEndHeight, strconv.FormatInt(ctx.BlockHeight(), 10)),
+		),
+	)
+	return nil
+}
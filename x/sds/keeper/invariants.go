@@ -0,0 +1,85 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/stratosnet/stratos-chain/x/sds/types"
+)
+
+// RegisterInvariants registers all sds invariants
+func RegisterInvariants(ir sdk.InvariantRegistry, k Keeper) {
+	ir.RegisterRoute(types.ModuleName, "unissued-prepay",
+		UnissuedPrepayInvariant(k))
+	ir.RegisterRoute(types.ModuleName, "deal-escrow",
+		DealEscrowInvariant(k))
+}
+
+// AllInvariants runs all invariants of the sds module.
+func AllInvariants(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		res, stop := UnissuedPrepayInvariant(k)(ctx)
+		if stop {
+			return res, stop
+		}
+		return DealEscrowInvariant(k)(ctx)
+	}
+}
+
+// UnissuedPrepayInvariant checks that no prepay is negative and that the total unissued prepay is a non-negative
+// amount of the bond denom, bounded by the sum of the prepays and the total unissued prepay preset by the genesis.
+// Each prepay adds the same amount to both, while the traffic rewards drawn by pot and the refunds of the uoz sold
+// back only take from the total unissued prepay, the prepay of a wallet being the ustos it ever prepaid.
+func UnissuedPrepayInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var msg string
+		count := 0
+		totalPrepay := sdk.ZeroInt()
+		k.IteratePrepay(ctx, func(sender sdk.AccAddress, prepay sdk.Int) (stop bool) {
+			if prepay.IsNegative() {
+				count++
+				msg += fmt.Sprintf("\t%v has a negative prepay: %v\n", sender, prepay)
+			}
+			totalPrepay = totalPrepay.Add(prepay)
+			return false
+		})
+
+		totalUnissuedPrepay := k.RegisterKeeper.GetTotalUnissuedPrepay(ctx)
+		genesisUnissuedPrepay := k.RegisterKeeper.GetGenesisUnissuedPrepay(ctx)
+		broken := count != 0 || totalUnissuedPrepay.IsNegative() || totalUnissuedPrepay.Denom != k.BondDenom(ctx) ||
+			totalUnissuedPrepay.Amount.GT(genesisUnissuedPrepay.Add(totalPrepay))
+
+		return sdk.FormatInvariant(types.ModuleName, "unissued-prepay", fmt.Sprintf(
+			"\ttotal unissued prepay: %v\n"+
+				"\tgenesis unissued prepay: %v\n"+
+				"\tsum of prepays: %v\n"+
+				"%d negative prepays found\n%s",
+			totalUnissuedPrepay, genesisUnissuedPrepay, totalPrepay, count, msg)), broken
+	}
+}
+
+// DealEscrowInvariant checks that the escrow left in each storage deal is a non-negative amount of the escrow
+// locked when the deal was created
+func DealEscrowInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var msg string
+		count := 0
+		totalEscrow := sdk.ZeroInt()
+		k.IterateDeals(ctx, func(deal types.Deal) (stop bool) {
+			if deal.RemainingEscrow.IsNegative() || deal.RemainingEscrow.Denom != deal.Escrow.Denom ||
+				deal.Escrow.IsLT(deal.RemainingEscrow) {
+				count++
+				msg += fmt.Sprintf("\tdeal of %v has an invalid remaining escrow: %v of %v\n", deal.FileHash,
+					deal.RemainingEscrow, deal.Escrow)
+			}
+			totalEscrow = totalEscrow.Add(deal.RemainingEscrow.Amount)
+			return false
+		})
+
+		return sdk.FormatInvariant(types.ModuleName, "deal-escrow", fmt.Sprintf(
+			"\ttotal remaining escrow: %v\n"+
+				"%d invalid deals found\n%s",
+			totalEscrow, count, msg)), count != 0
+	}
+}
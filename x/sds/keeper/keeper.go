@@ -9,6 +9,7 @@ import (
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/cosmos/cosmos-sdk/x/bank"
 	"github.com/cosmos/cosmos-sdk/x/params"
+	stratos "github.com/stratosnet/stratos-chain/types"
 	"github.com/stratosnet/stratos-chain/x/pot"
 	"github.com/stratosnet/stratos-chain/x/register"
 	"github.com/stratosnet/stratos-chain/x/sds/types"
@@ -66,12 +67,88 @@ func (k Keeper) GetFileInfoBytesByFileHash(ctx sdk.Context, key []byte) ([]byte,
 	return bz, nil
 }
 
-// SetFileHash Sets sender-fileHash KV pair
+// GetFileInfoByFileHash Returns the info of the file
+func (k Keeper) GetFileInfoByFileHash(ctx sdk.Context, key []byte) (types.FileInfo, error) {
+	bz, err := k.GetFileInfoBytesByFileHash(ctx, key)
+	if err != nil {
+		return types.FileInfo{}, err
+	}
+	return types.MustUnmarshalFileInfo(k.cdc, bz), nil
+}
+
+// SetFileHash Sets sender-fileHash KV pair, along with the uploader & reporter indexes of the file
 func (k Keeper) SetFileHash(ctx sdk.Context, fileHash []byte, fileInfo types.FileInfo) {
 	store := ctx.KVStore(k.key)
 	storeKey := types.FileStoreKey(fileHash)
+	if oldFileInfo, err := k.GetFileInfoByFileHash(ctx, fileHash); err == nil {
+		k.deleteFileIndexes(ctx, fileHash, oldFileInfo)
+	}
 	bz := types.MustMarshalFileInfo(k.cdc, fileInfo)
 	store.Set(storeKey, bz)
+	store.Set(types.GetFileByUploaderKey(fileInfo.Uploader, fileHash), fileHash)
+	store.Set(types.GetFileByReporterKey(fileInfo.Reporter, fileHash), fileHash)
+}
+
+// DeleteFileHash Deletes the info of the file, along with its uploader & reporter indexes and its pending challenges
+func (k Keeper) DeleteFileHash(ctx sdk.Context, fileHash []byte) {
+	store := ctx.KVStore(k.key)
+	if fileInfo, err := k.GetFileInfoByFileHash(ctx, fileHash); err == nil {
+		k.deleteFileIndexes(ctx, fileHash, fileInfo)
+		for _, resourceNode := range fileInfo.ResourceNodes {
+			k.DeleteChallenge(ctx, resourceNode, fileHash)
+		}
+	}
+	store.Delete(types.FileStoreKey(fileHash))
+}
+
+// AcknowledgeFile records that a resource node assigned to hold the file acknowledged holding it,
+// the file is only challenged on the resource nodes which acknowledged it
+func (k Keeper) AcknowledgeFile(ctx sdk.Context, resourceNode stratos.SdsAddress, fileHash []byte) error {
+	fileInfo, err := k.GetFileInfoByFileHash(ctx, fileHash)
+	if err != nil {
+		return types.ErrNoFileFound
+	}
+	if !fileInfo.IsFileHolder(resourceNode) {
+		return types.ErrNotFileHolder
+	}
+	if fileInfo.HasAcknowledged(resourceNode) {
+		return types.ErrFileAcknowledged
+	}
+	fileInfo.AcknowledgedNodes = append(fileInfo.AcknowledgedNodes, resourceNode)
+	k.SetFileHash(ctx, fileHash, fileInfo)
+	return nil
+}
+
+func (k Keeper) deleteFileIndexes(ctx sdk.Context, fileHash []byte, fileInfo types.FileInfo) {
+	store := ctx.KVStore(k.key)
+	store.Delete(types.GetFileByUploaderKey(fileInfo.Uploader, fileHash))
+	store.Delete(types.GetFileByReporterKey(fileInfo.Reporter, fileHash))
+}
+
+// IterateFilesByUploader Iterate over the files of an uploader.
+func (k Keeper) IterateFilesByUploader(ctx sdk.Context, uploader sdk.AccAddress, handler func(string, types.FileInfo) (stop bool)) {
+	k.iterateFileIndex(ctx, types.GetFilesByUploaderKey(uploader), handler)
+}
+
+// IterateFilesByReporter Iterate over the files reported by an sp node.
+func (k Keeper) IterateFilesByReporter(ctx sdk.Context, reporter stratos.SdsAddress, handler func(string, types.FileInfo) (stop bool)) {
+	k.iterateFileIndex(ctx, types.GetFilesByReporterKey(reporter), handler)
+}
+
+func (k Keeper) iterateFileIndex(ctx sdk.Context, prefix []byte, handler func(string, types.FileInfo) (stop bool)) {
+	store := ctx.KVStore(k.key)
+	iter := sdk.KVStorePrefixIterator(store, prefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		fileHash := iter.Value()
+		fileInfo, err := k.GetFileInfoByFileHash(ctx, fileHash)
+		if err != nil {
+			continue
+		}
+		if handler(string(fileHash), fileInfo) {
+			break
+		}
+	}
 }
 
 // [S] is the initial genesis deposit by all Resource Nodes and Meta Nodes at t=0
@@ -115,6 +192,63 @@ func (k Keeper) simulatePurchaseUoz(ctx sdk.Context, amount sdk.Int) sdk.Int {
 	return purchased
 }
 
+// sellUoz is the inverse of purchaseUoz: buying back the refund right after the sale would purchase the sold uoz again.
+// The exit fee stays in the unissued prepay.
+func (k Keeper) sellUoz(ctx sdk.Context, amount sdk.Int) (refund, fee sdk.Int) {
+	refund, fee = k.simulateSellUoz(ctx, amount)
+
+	// update total unissued prepay
+	Pt := k.RegisterKeeper.GetTotalUnissuedPrepay(ctx).Amount
+	k.RegisterKeeper.SetTotalUnissuedPrepay(ctx, sdk.NewCoin(k.BondDenom(ctx), Pt.Sub(refund)))
+
+	// update remaining uoz limit
+	Lt := k.RegisterKeeper.GetRemainingOzoneLimit(ctx)
+	k.RegisterKeeper.SetRemainingOzoneLimit(ctx, Lt.Add(amount))
+
+	return refund, fee
+}
+
+func (k Keeper) simulateSellUoz(ctx sdk.Context, amount sdk.Int) (refund, fee sdk.Int) {
+	S := k.RegisterKeeper.GetInitialGenesisStakeTotal(ctx)
+	Pt := k.RegisterKeeper.GetTotalUnissuedPrepay(ctx).Amount
+	Lt := k.RegisterKeeper.GetRemainingOzoneLimit(ctx)
+
+	sold := amount.ToDec().
+		Mul((S.
+			Add(Pt)).ToDec()).
+		Quo((Lt.
+			Add(amount)).ToDec()).
+		TruncateInt()
+	sold = sdk.MinInt(sold, Pt)
+
+	fee = sold.ToDec().Mul(k.ExitFeeRate(ctx)).TruncateInt()
+	return sold.Sub(fee), fee
+}
+
+// SellOzone converts the unused uoz of the sender back to ustos, the exit fee is deducted from the refund.
+// The prepay of the sender records the ustos it ever prepaid and is left unchanged, the refund is only taken
+// from the total unissued prepay.
+func (k Keeper) SellOzone(ctx sdk.Context, sender sdk.AccAddress, amount sdk.Int) (refund, fee sdk.Int, err error) {
+	balance := k.RegisterKeeper.GetOzoneBalance(ctx, sender)
+	if balance.LT(amount) {
+		return sdk.ZeroInt(), sdk.ZeroInt(), sdkerrors.Wrapf(types.ErrInsufficientOzone, "ozone balance %s uoz, selling %s uoz",
+			balance.String(), amount.String())
+	}
+
+	refund, fee = k.simulateSellUoz(ctx, amount)
+	if !refund.IsPositive() {
+		return sdk.ZeroInt(), sdk.ZeroInt(), types.ErrZeroRefund
+	}
+
+	k.RegisterKeeper.DebitOzoneBalance(ctx, sender, amount)
+	refund, fee = k.sellUoz(ctx, amount)
+	_, err = k.BankKeeper.AddCoins(ctx, sender, sdk.NewCoins(sdk.NewCoin(k.BondDenom(ctx), refund)))
+	if err != nil {
+		return sdk.ZeroInt(), sdk.ZeroInt(), err
+	}
+	return refund, fee, nil
+}
+
 // Prepay transfers coins from bank to sds (volumn) pool
 func (k Keeper) Prepay(ctx sdk.Context, sender sdk.AccAddress, coins sdk.Coins) (sdk.Int, error) {
 	// src - hasCoins?
@@ -134,6 +268,8 @@ func (k Keeper) Prepay(ctx sdk.Context, sender sdk.AccAddress, coins sdk.Coins)
 
 	prepay := coins.AmountOf(k.BondDenom(ctx))
 	purchased := k.purchaseUoz(ctx, prepay)
+	// the purchased uoz is consumed by the traffic reported in the volume reports of pot
+	k.RegisterKeeper.AddOzoneBalance(ctx, sender, purchased)
 
 	return purchased, nil
 }
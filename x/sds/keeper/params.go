@@ -16,8 +16,55 @@ func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
 	k.paramSpace.SetParamSet(ctx, &params)
 }
 
+// MigrateParams sets the params missing from the param space of a chain started before they were added
+// to their default values, the other params are left unchanged
+func (k Keeper) MigrateParams(ctx sdk.Context) {
+	defaultParams := types.DefaultParams()
+	for _, pair := range defaultParams.ParamSetPairs() {
+		if !k.paramSpace.Has(ctx, pair.Key) {
+			k.paramSpace.Set(ctx, pair.Key, pair.Value)
+		}
+	}
+}
+
 // BondDenom - Bondable coin denomination
 func (k Keeper) BondDenom(ctx sdk.Context) (res string) {
 	k.paramSpace.Get(ctx, types.KeyBondDenom, &res)
 	return
 }
+
+// ExitFeeRate - share of the refund kept in the prepay pool when uoz is sold back
+func (k Keeper) ExitFeeRate(ctx sdk.Context) (res sdk.Dec) {
+	k.paramSpace.Get(ctx, types.KeyExitFeeRate, &res)
+	return
+}
+
+// ChallengeInterval - blocks between two rounds of challenges, 0 disables the challenges
+func (k Keeper) ChallengeInterval(ctx sdk.Context) (res int64) {
+	k.paramSpace.Get(ctx, types.KeyChallengeInterval, &res)
+	return
+}
+
+// ChallengeDeadline - blocks a challenged resource node has to answer
+func (k Keeper) ChallengeDeadline(ctx sdk.Context) (res int64) {
+	k.paramSpace.Get(ctx, types.KeyChallengeDeadline, &res)
+	return
+}
+
+// ChallengesPerRound - max number of challenges issued in a round
+func (k Keeper) ChallengesPerRound(ctx sdk.Context) (res uint32) {
+	k.paramSpace.Get(ctx, types.KeyChallengesPerRound, &res)
+	return
+}
+
+// ChallengeSlashing - uoz equivalent slashed from a resource node failing a challenge
+func (k Keeper) ChallengeSlashing(ctx sdk.Context) (res sdk.Int) {
+	k.paramSpace.Get(ctx, types.KeyChallengeSlashing, &res)
+	return
+}
+
+// DealEpochLength - blocks between two payments of the storage deals
+func (k Keeper) DealEpochLength(ctx sdk.Context) (res int64) {
+	k.paramSpace.Get(ctx, types.KeyDealEpochLength, &res)
+	return
+}
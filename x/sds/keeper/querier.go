@@ -7,16 +7,28 @@ import (
 	// this line is used by starport scaffolding # 1
 	abci "github.com/tendermint/tendermint/abci/types"
 
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stratosnet/stratos-chain/x/register"
+	"github.com/stratosnet/stratos-chain/x/sds/types"
 )
 
 const (
-	QueryFileHash       = "uploaded_file"
-	QueryPrepay         = "prepay"
-	QuerySimulatePrepay = "simulate_prepay"
-	QueryCurrUozPrice   = "curr_uoz_price"
-	QueryUozSupply      = "uoz_supply"
+	QueryFileHash        = "uploaded_file"
+	QueryPrepay          = "prepay"
+	QuerySimulatePrepay  = "simulate_prepay"
+	QueryCurrUozPrice    = "curr_uoz_price"
+	QueryUozSupply       = "uoz_supply"
+	QueryFilesByUploader = "files_by_uploader"
+	QueryFilesByReporter = "files_by_reporter"
+	QueryOzoneBalance    = "ozone_balance"
+	QuerySimulateSell    = "simulate_sell_ozone"
+	QueryUozPriceHistory = "uoz_price_history"
+	QueryChallenges      = "challenges"
+	QueryDeal            = "deal"
+	QueryDefaultLimit    = 100
 )
 
 // NewQuerier creates a new querier for sds clients.
@@ -29,24 +41,42 @@ func NewQuerier(k Keeper) sdk.Querier {
 			return queryPrepay(ctx, req, k)
 		case QuerySimulatePrepay:
 			return querySimulatePrepay(ctx, req, k)
+		case QuerySimulateSell:
+			return querySimulateSell(ctx, req, k)
 		case QueryCurrUozPrice:
 			return queryCurrUozPrice(ctx, req, k)
 		case QueryUozSupply:
 			return queryUozSupply(ctx, req, k)
+		case QueryUozPriceHistory:
+			return queryUozPriceHistory(ctx, req, k)
+		case QueryFilesByUploader:
+			return queryFilesByUploader(ctx, req, k)
+		case QueryFilesByReporter:
+			return queryFilesByReporter(ctx, req, k)
+		case QueryOzoneBalance:
+			return queryOzoneBalance(ctx, req, k)
+		case QueryChallenges:
+			return queryChallenges(ctx, req, k)
+		case QueryDeal:
+			return queryDeal(ctx, req, k)
 		default:
 			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "unknown sds query endpoint "+req.String()+hex.EncodeToString(req.Data))
 		}
 	}
 }
 
-// queryFileHash fetch an file's hash for the supplied height.
+// queryFileHash fetch the info of a file, including its metadata, by the file hash.
 func queryFileHash(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
-	fileHash, err := k.GetFileInfoBytesByFileHash(ctx, req.Data)
+	fileInfo, err := k.GetFileInfoByFileHash(ctx, req.Data)
 	if err != nil {
 		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
 	}
 
-	return fileHash, nil
+	bz, err := codec.MarshalJSONIndent(k.cdc, fileInfo)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
 }
 
 // queryPrepay fetch prepaid balance of an account.
@@ -58,6 +88,42 @@ func queryPrepay(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, erro
 	return balance, nil
 }
 
+// queryOzoneBalance fetch the remaining uoz of an account.
+func queryOzoneBalance(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	balance := k.RegisterKeeper.GetOzoneBalance(ctx, req.Data)
+	balanceByte, _ := balance.MarshalJSON()
+	return balanceByte, nil
+}
+
+// queryChallenges fetch the pending challenges of a resource node.
+func queryChallenges(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	challenges := make([]types.Challenge, 0)
+	k.IterateChallengesByNode(ctx, req.Data, func(challenge types.Challenge) (stop bool) {
+		challenges = append(challenges, challenge)
+		return false
+	})
+
+	bz, err := codec.MarshalJSONIndent(k.cdc, challenges)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
+
+// queryDeal fetch the storage deal of a file by the file hash.
+func queryDeal(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	deal, found := k.GetDeal(ctx, req.Data)
+	if !found {
+		return nil, types.ErrNoDealFound
+	}
+
+	bz, err := codec.MarshalJSONIndent(k.cdc, deal)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
+
 // querySimulatePrepay fetch amt of uoz with a simulated prepay of X ustos.
 func querySimulatePrepay(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
 	var amtToPrepay sdk.Int
@@ -70,6 +136,18 @@ func querySimulatePrepay(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]by
 	return uozAmtByte, nil
 }
 
+// querySimulateSell fetch amt of ustos refunded, net of the exit fee, when selling back X uoz.
+func querySimulateSell(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var amtToSell sdk.Int
+	err := amtToSell.UnmarshalJSON(req.Data)
+	if err != nil {
+		return []byte{}, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	refund, _ := k.simulateSellUoz(ctx, amtToSell)
+	refundByte, _ := refund.MarshalJSON()
+	return refundByte, nil
+}
+
 // queryCurrUozPrice fetch current uoz price.
 func queryCurrUozPrice(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
 	uozPrice := k.RegisterKeeper.CurrUozPrice(ctx)
@@ -88,3 +166,84 @@ func queryUozSupply(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, e
 	uozSupplyByte, _ := json.Marshal(uozSupply)
 	return uozSupplyByte, nil
 }
+
+// queryUozPriceHistory fetch the uoz price & supply snapshots recorded in a range of heights.
+func queryUozPriceHistory(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryUozPriceHistoryParams
+	err := k.cdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	fromHeight, toHeight := params.FromHeight, params.ToHeight
+	if fromHeight < 0 {
+		fromHeight = 0
+	}
+	if toHeight <= 0 || toHeight > ctx.BlockHeight() {
+		toHeight = ctx.BlockHeight()
+	}
+	if fromHeight > toHeight {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "from height %d is greater than to height %d", fromHeight, toHeight)
+	}
+
+	snapshots := k.RegisterKeeper.GetUozSnapshots(ctx, fromHeight, toHeight)
+	start, end := client.Paginate(len(snapshots), params.Page, params.Limit, QueryDefaultLimit)
+	if start < 0 || end < 0 {
+		snapshots = []register.UozSnapshot{}
+	} else {
+		snapshots = snapshots[start:end]
+	}
+
+	bz, err := codec.MarshalJSONIndent(k.cdc, snapshots)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
+
+// queryFilesByUploader fetch the files of an uploader.
+func queryFilesByUploader(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryFilesByUploaderParams
+	err := k.cdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	var res []types.FileUpload
+	k.IterateFilesByUploader(ctx, params.Uploader, func(fileHash string, fileInfo types.FileInfo) (stop bool) {
+		res = append(res, types.FileUpload{FileHash: fileHash, FileInfo: fileInfo})
+		return false
+	})
+	return marshalFilesPage(k, res, params.Page, params.Limit)
+}
+
+// queryFilesByReporter fetch the files reported by an sp node.
+func queryFilesByReporter(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryFilesByReporterParams
+	err := k.cdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	var res []types.FileUpload
+	k.IterateFilesByReporter(ctx, params.Reporter, func(fileHash string, fileInfo types.FileInfo) (stop bool) {
+		res = append(res, types.FileUpload{FileHash: fileHash, FileInfo: fileInfo})
+		return false
+	})
+	return marshalFilesPage(k, res, params.Page, params.Limit)
+}
+
+func marshalFilesPage(k Keeper, files []types.FileUpload, page, limit int) ([]byte, error) {
+	start, end := client.Paginate(len(files), page, limit, QueryDefaultLimit)
+	if start < 0 || end < 0 {
+		files = []types.FileUpload{}
+	} else {
+		files = files[start:end]
+	}
+
+	bz, err := codec.MarshalJSONIndent(k.cdc, files)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
@@ -0,0 +1,52 @@
+package types
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	stratos "github.com/stratosnet/stratos-chain/types"
+)
+
+// Challenge asks a resource node to prove it still holds a chunk of a file it stores
+type Challenge struct {
+	FileHash     string             `json:"file_hash" yaml:"file_hash"`
+	ResourceNode stratos.SdsAddress `json:"resource_node" yaml:"resource_node"` // p2pAddr of the challenged resource node
+	Seed         []byte             `json:"seed" yaml:"seed"`                   // determines the challenged chunk of the file
+	Height       int64              `json:"height" yaml:"height"`               // height the challenge was issued at
+	Deadline     int64              `json:"deadline" yaml:"deadline"`           // last height the proof is accepted at
+}
+
+// NewChallenge creates a new Challenge instance
+func NewChallenge(fileHash string, resourceNode stratos.SdsAddress, seed []byte, height, deadline int64) Challenge {
+	return Challenge{
+		FileHash:     fileHash,
+		ResourceNode: resourceNode,
+		Seed:         seed,
+		Height:       height,
+		Deadline:     deadline,
+	}
+}
+
+// ChunkIndex returns the index of the challenged chunk among the given number of chunks of the file
+func (c Challenge) ChunkIndex(total int) int {
+	if total <= 0 || len(c.Seed) < 8 {
+		return 0
+	}
+	return int(binary.BigEndian.Uint64(c.Seed[len(c.Seed)-8:]) % uint64(total))
+}
+
+// IsExpired returns true if the proof is no longer accepted at the given height
+func (c Challenge) IsExpired(height int64) bool {
+	return height > c.Deadline
+}
+
+// String returns a human readable string representation of a challenge.
+func (c Challenge) String() string {
+	return fmt.Sprintf(`Challenge:{
+		FileHash:			%s
+		ResourceNode:		%s
+		Seed:				%X
+		Height:				%d
+		Deadline:			%d
+	}`, c.FileHash, c.ResourceNode.String(), c.Seed, c.Height, c.Deadline)
+}
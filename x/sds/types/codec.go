@@ -9,6 +9,12 @@ func RegisterCodec(cdc *codec.Codec) {
 	// this line is used by starport scaffolding # 1
 	cdc.RegisterConcrete(MsgFileUpload{}, "sds/FileUploadTx", nil)
 	cdc.RegisterConcrete(MsgPrepay{}, "sds/PrepayTx", nil)
+	cdc.RegisterConcrete(MsgFileDelete{}, "sds/FileDeleteTx", nil)
+	cdc.RegisterConcrete(MsgFileTransferOwnership{}, "sds/FileTransferOwnershipTx", nil)
+	cdc.RegisterConcrete(MsgSellOzone{}, "sds/SellOzoneTx", nil)
+	cdc.RegisterConcrete(MsgFileAcknowledge{}, "sds/FileAcknowledgeTx", nil)
+	cdc.RegisterConcrete(MsgChallengeProof{}, "sds/ChallengeProofTx", nil)
+	cdc.RegisterConcrete(MsgCreateDeal{}, "sds/CreateDealTx", nil)
 }
 
 // ModuleCdc defines the module codec
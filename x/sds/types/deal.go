@@ -0,0 +1,83 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Deal is the storage of a file paid for a number of epochs. The escrow locked by the owner is streamed
+// to the resource nodes holding the file at the end of each epoch.
+type Deal struct {
+	FileHash        string         `json:"file_hash" yaml:"file_hash"`
+	Owner           sdk.AccAddress `json:"owner" yaml:"owner"`                       // wallet paying for the storage
+	FileSize        uint64         `json:"file_size" yaml:"file_size"`               // size of the stored file in bytes
+	Duration        int64          `json:"duration" yaml:"duration"`                 // number of epochs the storage is paid for
+	Escrow          sdk.Coin       `json:"escrow" yaml:"escrow"`                     // payment locked when the deal was created
+	RemainingEscrow sdk.Coin       `json:"remaining_escrow" yaml:"remaining_escrow"` // payment not streamed to the resource nodes yet
+	PaidEpochs      int64          `json:"paid_epochs" yaml:"paid_epochs"`
+	StartHeight     int64          `json:"start_height" yaml:"start_height"`
+	EndHeight       int64          `json:"end_height" yaml:"end_height"` // height of the last payment, the deal expires with it
+}
+
+// NewDeal creates a new Deal instance
+func NewDeal(fileHash string, owner sdk.AccAddress, fileSize uint64, duration int64, escrow sdk.Coin,
+	startHeight, endHeight int64) Deal {
+	return Deal{
+		FileHash:        fileHash,
+		Owner:           owner,
+		FileSize:        fileSize,
+		Duration:        duration,
+		Escrow:          escrow,
+		RemainingEscrow: escrow,
+		PaidEpochs:      0,
+		StartHeight:     startHeight,
+		EndHeight:       endHeight,
+	}
+}
+
+// NextPayment returns the payment of the current epoch, the remaining escrow is spread evenly over the remaining epochs
+func (d Deal) NextPayment() sdk.Coin {
+	remainingEpochs := d.Duration - d.PaidEpochs
+	if remainingEpochs <= 1 {
+		return d.RemainingEscrow
+	}
+	return sdk.NewCoin(d.RemainingEscrow.Denom, d.RemainingEscrow.Amount.QuoRaw(remainingEpochs))
+}
+
+// Validate checks the deal stored in the genesis state
+func (d Deal) Validate() error {
+	if len(d.FileHash) == 0 {
+		return ErrEmptyFileHash
+	}
+	if d.Owner.Empty() {
+		return ErrEmptyUploaderAddr
+	}
+	if d.FileSize == 0 {
+		return ErrInvalidFileSize
+	}
+	if d.Duration <= 0 || d.PaidEpochs < 0 || d.PaidEpochs >= d.Duration || d.EndHeight < d.StartHeight {
+		return ErrInvalidDealDuration
+	}
+	if !d.Escrow.IsValid() || !d.RemainingEscrow.IsValid() || d.RemainingEscrow.Denom != d.Escrow.Denom ||
+		d.Escrow.IsLT(d.RemainingEscrow) {
+		return ErrInvalidCoins
+	}
+	return nil
+}
+
+// String returns a human readable string representation of a deal.
+func (d Deal) String() string {
+	return fmt.Sprintf(`Deal:{
+		FileHash:			%s
+		Owner:				%s
+		FileSize:			%d
+		Duration:			%d
+		Escrow:				%s
+		RemainingEscrow:	%s
+		PaidEpochs:			%d
+		StartHeight:		%d
+		EndHeight:			%d
+	}`, d.FileHash, d.Owner.String(), d.FileSize, d.Duration, d.Escrow.String(), d.RemainingEscrow.String(),
+		d.PaidEpochs, d.StartHeight, d.EndHeight)
+}
@@ -5,11 +5,39 @@ import (
 )
 
 var (
-	ErrInvalid           = sdkerrors.Register(ModuleName, 1, "error invalid")
-	ErrInvalidHeight     = sdkerrors.Register(ModuleName, 2, "invalid height")
-	ErrEmptyUploaderAddr = sdkerrors.Register(ModuleName, 3, "missing uploader address")
-	ErrEmptyReporterAddr = sdkerrors.Register(ModuleName, 4, "missing reporter address")
-	ErrEmptyFileHash     = sdkerrors.Register(ModuleName, 5, "missing file hash")
-	ErrEmptySenderAddr   = sdkerrors.Register(ModuleName, 6, "missing sender address")
-	ErrInvalidCoins      = sdkerrors.Register(ModuleName, 7, "invalid coins")
+	ErrInvalid               = sdkerrors.Register(ModuleName, 1, "error invalid")
+	ErrInvalidHeight         = sdkerrors.Register(ModuleName, 2, "invalid height")
+	ErrEmptyUploaderAddr     = sdkerrors.Register(ModuleName, 3, "missing uploader address")
+	ErrEmptyReporterAddr     = sdkerrors.Register(ModuleName, 4, "missing reporter address")
+	ErrEmptyFileHash         = sdkerrors.Register(ModuleName, 5, "missing file hash")
+	ErrEmptySenderAddr       = sdkerrors.Register(ModuleName, 6, "missing sender address")
+	ErrInvalidCoins          = sdkerrors.Register(ModuleName, 7, "invalid coins")
+	ErrInvalidFileSize       = sdkerrors.Register(ModuleName, 8, "file size must be positive")
+	ErrInvalidReplicaCount   = sdkerrors.Register(ModuleName, 9, "replica count must be positive")
+	ErrEmptyResourceNodes    = sdkerrors.Register(ModuleName, 10, "missing resource nodes holding the file")
+	ErrDuplicateResourceNode = sdkerrors.Register(ModuleName, 11, "duplicate resource node holding the file")
+	ErrInvalidContentType    = sdkerrors.Register(ModuleName, 12, "content type is too long")
+	ErrInvalidExpiryHeight   = sdkerrors.Register(ModuleName, 13, "invalid expiry height")
+	ErrNoResourceNodeFound   = sdkerrors.Register(ModuleName, 14, "resource node holding the file is not registered")
+	ErrNoFileFound           = sdkerrors.Register(ModuleName, 15, "file does not exist")
+	ErrNotFileOwner          = sdkerrors.Register(ModuleName, 16, "address is not the owner of the file")
+	ErrSameFileOwner         = sdkerrors.Register(ModuleName, 17, "new owner is the current owner of the file")
+	ErrInvalidReporter       = sdkerrors.Register(ModuleName, 18, "reporter is not an sp node of the sender")
+	ErrInvalidSellAmount     = sdkerrors.Register(ModuleName, 19, "amount of uoz to sell must be positive")
+	ErrInsufficientOzone     = sdkerrors.Register(ModuleName, 20, "insufficient ozone balance")
+	ErrZeroRefund            = sdkerrors.Register(ModuleName, 21, "amount of uoz is too small to be refunded")
+	ErrInvalidMinUoz         = sdkerrors.Register(ModuleName, 22, "minimum uoz to purchase is negative")
+	ErrUozBelowMinimum       = sdkerrors.Register(ModuleName, 23, "purchased uoz is below the minimum")
+	ErrInvalidMerkleRoot     = sdkerrors.Register(ModuleName, 24, "invalid merkle root of the file chunks")
+	ErrNoChallengeFound      = sdkerrors.Register(ModuleName, 25, "no pending challenge of the resource node on the file")
+	ErrInvalidProver         = sdkerrors.Register(ModuleName, 26, "sender is not the owner of the challenged resource node")
+	ErrEmptyProof            = sdkerrors.Register(ModuleName, 27, "missing proof of the challenged chunk")
+	ErrInvalidChallenge      = sdkerrors.Register(ModuleName, 28, "invalid challenge")
+	ErrInvalidDealDuration   = sdkerrors.Register(ModuleName, 29, "invalid duration of the storage deal")
+	ErrDealExists            = sdkerrors.Register(ModuleName, 30, "storage deal of the file already exists")
+	ErrDealFileSizeMismatch  = sdkerrors.Register(ModuleName, 31, "file size of the deal does not match the uploaded file")
+	ErrNoDealFound           = sdkerrors.Register(ModuleName, 32, "storage deal of the file does not exist")
+	ErrFileExists            = sdkerrors.Register(ModuleName, 33, "file is already uploaded by another uploader")
+	ErrNotFileHolder         = sdkerrors.Register(ModuleName, 34, "resource node is not assigned to hold the file")
+	ErrFileAcknowledged      = sdkerrors.Register(ModuleName, 35, "resource node already acknowledged holding the file")
 )
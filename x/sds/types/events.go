@@ -2,16 +2,43 @@ package types
 
 // sds module event types
 const (
-	EventTypeFileUpload = "FileUpload"
-	EventTypePrepay     = "Prepay"
+	EventTypeFileUpload            = "FileUpload"
+	EventTypePrepay                = "Prepay"
+	EventTypeFileDelete            = "FileDelete"
+	EventTypeFileTransferOwnership = "FileTransferOwnership"
+	EventTypeSellOzone             = "SellOzone"
+	EventTypeFileAcknowledge       = "FileAcknowledge"
+	EventTypeChallenge             = "Challenge"
+	EventTypeChallengeProof        = "ChallengeProof"
+	EventTypeChallengeFailed       = "ChallengeFailed"
+	EventTypeCreateDeal            = "CreateDeal"
+	EventTypeDealPayment           = "DealPayment"
+	EventTypeDealExpired           = "DealExpired"
 
-	AttributeKeyReporter = "reporter"
-	AttributeKeyFileHash = "file_hash"
-	AttributeKeyUploader = "uploader"
+	AttributeKeyReporter      = "reporter"
+	AttributeKeyFileHash      = "file_hash"
+	AttributeKeyUploader      = "uploader"
+	AttributeKeyFileSize      = "file_size"
+	AttributeKeyReplicaCount  = "replica_count"
+	AttributeKeyExpiryHeight  = "expiry_height"
+	AttributeKeyResourceNodes = "resource_nodes"
+	AttributeKeyNewOwner      = "new_owner"
+	AttributeKeyResourceNode  = "resource_node"
+	AttributeKeyChunkSeed     = "seed"
+	AttributeKeyDeadline      = "deadline"
+	AttributeKeyPassed        = "passed"
+	AttributeKeySlashing      = "slashing"
+	AttributeKeyDuration      = "duration"
+	AttributeKeyEscrow        = "escrow"
+	AttributeKeyEndHeight     = "end_height"
+	AttributeKeyPayment       = "payment"
 
 	AttributeKeyRecipient    = "recipient"
 	AttributeKeyCoins        = "coins"
 	AttributeKeyPurchasedUoz = "purchased"
+	AttributeKeySoldUoz      = "sold"
+	AttributeKeyRefund       = "refund"
+	AttributeKeyExitFee      = "exit_fee"
 
 	AttributeValueCategory = ModuleName
 )
@@ -11,6 +11,8 @@ import (
 type GenesisState struct {
 	Params     Params       `json:"params" yaml:"params"`
 	FileUpload []FileUpload `json:"file_upload" yaml:"file_upload"`
+	Challenges []Challenge  `json:"challenges" yaml:"challenges"`
+	Deals      []Deal       `json:"deals" yaml:"deals"`
 }
 
 // FileUpload required for fileInfo set update logic
@@ -20,10 +22,12 @@ type FileUpload struct {
 }
 
 // NewGenesisState creates a new GenesisState object
-func NewGenesisState(params Params, fileUpload []FileUpload) GenesisState {
+func NewGenesisState(params Params, fileUpload []FileUpload, challenges []Challenge, deals []Deal) GenesisState {
 	return GenesisState{
 		Params:     params,
 		FileUpload: fileUpload,
+		Challenges: challenges,
+		Deals:      deals,
 	}
 }
 
@@ -65,6 +69,41 @@ func ValidateGenesis(data GenesisState) error {
 			if upload.FileInfo.Uploader.Empty() {
 				return ErrEmptyUploaderAddr
 			}
+			fi := upload.FileInfo
+			if err := ValidateFileMetadata(fi.FileSize, fi.ReplicaCount, fi.ResourceNodes, fi.ContentType, fi.ExpiryHeight); err != nil {
+				return err
+			}
+			if err := ValidateMerkleRoot(fi.MerkleRoot); err != nil {
+				return err
+			}
+			acknowledged := make(map[string]bool)
+			for _, node := range fi.AcknowledgedNodes {
+				if !fi.IsFileHolder(node) {
+					return ErrNotFileHolder
+				}
+				if acknowledged[node.String()] {
+					return ErrFileAcknowledged
+				}
+				acknowledged[node.String()] = true
+			}
+		}
+	}
+
+	for _, challenge := range data.Challenges {
+		if len(challenge.FileHash) == 0 {
+			return ErrEmptyFileHash
+		}
+		if challenge.ResourceNode.Empty() {
+			return ErrEmptyResourceNodes
+		}
+		if challenge.Height < 0 || challenge.Deadline < challenge.Height {
+			return ErrInvalidChallenge
+		}
+	}
+
+	for _, deal := range data.Deals {
+		if err := deal.Validate(); err != nil {
+			return err
 		}
 	}
 	return nil
@@ -19,6 +19,14 @@ var (
 	PrepayBalancePrefix = []byte{0x01}
 	// FileStorage prefix for sds store
 	FileStoreKeyPrefix = []byte{0x02}
+	// FileByUploader index prefix for sds store
+	FileByUploaderKeyPrefix = []byte{0x03}
+	// FileByReporter index prefix for sds store
+	FileByReporterKeyPrefix = []byte{0x04}
+	// Challenge prefix for sds store, challenges are indexed by resource node then file hash
+	ChallengeKeyPrefix = []byte{0x05}
+	// Deal prefix for sds store
+	DealKeyPrefix = []byte{0x06}
 )
 
 // PrepayBalanceKey turn an address to key used to get prepaid balance from the sds store
@@ -30,3 +38,38 @@ func PrepayBalanceKey(acc []byte) []byte {
 func FileStoreKey(sender []byte) []byte {
 	return append(FileStoreKeyPrefix, sender...)
 }
+
+// GetFilesByUploaderKey gets the prefix of the index of the files of an uploader
+func GetFilesByUploaderKey(uploader []byte) []byte {
+	return append(FileByUploaderKeyPrefix, uploader...)
+}
+
+// GetFileByUploaderKey gets the key of the index of a file by its uploader
+func GetFileByUploaderKey(uploader []byte, fileHash []byte) []byte {
+	return append(GetFilesByUploaderKey(uploader), fileHash...)
+}
+
+// GetFilesByReporterKey gets the prefix of the index of the files reported by an sp node
+func GetFilesByReporterKey(reporter []byte) []byte {
+	return append(FileByReporterKeyPrefix, reporter...)
+}
+
+// GetFileByReporterKey gets the key of the index of a file by its reporter
+func GetFileByReporterKey(reporter []byte, fileHash []byte) []byte {
+	return append(GetFilesByReporterKey(reporter), fileHash...)
+}
+
+// GetChallengesByNodeKey gets the prefix of the challenges issued to a resource node
+func GetChallengesByNodeKey(resourceNode []byte) []byte {
+	return append(ChallengeKeyPrefix, resourceNode...)
+}
+
+// GetChallengeKey gets the key of the challenge issued to a resource node on a file
+func GetChallengeKey(resourceNode []byte, fileHash []byte) []byte {
+	return append(GetChallengesByNodeKey(resourceNode), fileHash...)
+}
+
+// GetDealKey gets the key of the storage deal of a file
+func GetDealKey(fileHash []byte) []byte {
+	return append(DealKeyPrefix, fileHash...)
+}
@@ -4,11 +4,18 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	stratos "github.com/stratosnet/stratos-chain/types"
+	"github.com/tendermint/tendermint/crypto/merkle"
 )
 
 const (
-	ConstFileUpload = "FileUploadTx"
-	ConstSdsPrepay  = "SdsPrepayTx"
+	ConstFileUpload            = "FileUploadTx"
+	ConstSdsPrepay             = "SdsPrepayTx"
+	ConstFileDelete            = "FileDeleteTx"
+	ConstFileTransferOwnership = "FileTransferOwnershipTx"
+	ConstSellOzone             = "SellOzoneTx"
+	ConstFileAcknowledge       = "FileAcknowledgeTx"
+	ConstChallengeProof        = "ChallengeProofTx"
+	ConstCreateDeal            = "CreateDealTx"
 )
 
 type MsgFileUpload struct {
@@ -16,18 +23,32 @@ type MsgFileUpload struct {
 	From     sdk.AccAddress     `json:"from" yaml:"from"`           // wallet addr who will pay this tx
 	Reporter stratos.SdsAddress `json:"reporter" yaml:"reporter"`   // p2pAddr of sp node who reports this tx
 	Uploader sdk.AccAddress     `json:"uploader" yaml:"uploader"`   // user who uploads the file
+
+	FileSize      uint64               `json:"file_size" yaml:"file_size"`           // size of the file in bytes
+	ReplicaCount  uint32               `json:"replica_count" yaml:"replica_count"`   // number of replicas of the file
+	ResourceNodes []stratos.SdsAddress `json:"resource_nodes" yaml:"resource_nodes"` // p2pAddr of resource nodes holding the shards
+	ContentType   string               `json:"content_type" yaml:"content_type"`     // MIME type of the file
+	ExpiryHeight  int64                `json:"expiry_height" yaml:"expiry_height"`   // height the file expires at, 0 if it never expires
+	MerkleRoot    []byte               `json:"merkle_root" yaml:"merkle_root"`       // merkle root of the chunks of the file, optional
 }
 
 // verify interface at compile time
 var _ sdk.Msg = &MsgFileUpload{}
 
 // NewMsg<Action> creates a new Msg<Action> instance
-func NewMsgUpload(fileHash string, from sdk.AccAddress, reporter stratos.SdsAddress, uploader sdk.AccAddress) MsgFileUpload {
+func NewMsgUpload(fileHash string, from sdk.AccAddress, reporter stratos.SdsAddress, uploader sdk.AccAddress, fileSize uint64,
+	replicaCount uint32, resourceNodes []stratos.SdsAddress, contentType string, expiryHeight int64, merkleRoot []byte) MsgFileUpload {
 	return MsgFileUpload{
-		FileHash: fileHash,
-		From:     from,
-		Reporter: reporter,
-		Uploader: uploader,
+		FileHash:      fileHash,
+		From:          from,
+		Reporter:      reporter,
+		Uploader:      uploader,
+		FileSize:      fileSize,
+		ReplicaCount:  replicaCount,
+		ResourceNodes: resourceNodes,
+		ContentType:   contentType,
+		ExpiryHeight:  expiryHeight,
+		MerkleRoot:    merkleRoot,
 	}
 }
 
@@ -46,6 +67,9 @@ func (msg MsgFileUpload) GetSignBytes() []byte {
 
 // ValidateBasic validity check for the AnteHandler
 func (msg MsgFileUpload) ValidateBasic() error {
+	if msg.From.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing sender address")
+	}
 	if msg.Reporter.Empty() {
 		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing address of tx reporter")
 	}
@@ -55,22 +79,27 @@ func (msg MsgFileUpload) ValidateBasic() error {
 	if len(msg.FileHash) == 0 {
 		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "missing file hash")
 	}
-	return nil
+	if err := ValidateFileMetadata(msg.FileSize, msg.ReplicaCount, msg.ResourceNodes, msg.ContentType, msg.ExpiryHeight); err != nil {
+		return err
+	}
+	return ValidateMerkleRoot(msg.MerkleRoot)
 }
 
 type MsgPrepay struct {
-	Sender sdk.AccAddress `json:"sender" yaml:"sender"` // sender of tx
-	Coins  sdk.Coins      `json:"coins" yaml:"coins"`   // coins to send
+	Sender sdk.AccAddress `json:"sender" yaml:"sender"`   // sender of tx
+	Coins  sdk.Coins      `json:"coins" yaml:"coins"`     // coins to send
+	MinUoz sdk.Int        `json:"min_uoz" yaml:"min_uoz"` // minimum uoz to purchase, 0 accepts any amount
 }
 
 // verify interface at compile time
 var _ sdk.Msg = &MsgPrepay{}
 
 // NewMsg<Action> creates a new Msg<Action> instance
-func NewMsgPrepay(sender sdk.AccAddress, coins sdk.Coins) MsgPrepay {
+func NewMsgPrepay(sender sdk.AccAddress, coins sdk.Coins, minUoz sdk.Int) MsgPrepay {
 	return MsgPrepay{
 		Sender: sender,
 		Coins:  coins,
+		MinUoz: minUoz,
 	}
 }
 
@@ -95,5 +124,307 @@ func (msg MsgPrepay) ValidateBasic() error {
 	if msg.Coins.Empty() {
 		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "missing coins to send")
 	}
+	if !msg.MinUoz.IsNil() && msg.MinUoz.IsNegative() {
+		return ErrInvalidMinUoz
+	}
+	return nil
+}
+
+type MsgSellOzone struct {
+	Sender sdk.AccAddress `json:"sender" yaml:"sender"` // wallet selling back its uoz
+	Amount sdk.Int        `json:"amount" yaml:"amount"` // amount of uoz to sell back
+}
+
+// verify interface at compile time
+var _ sdk.Msg = &MsgSellOzone{}
+
+// NewMsgSellOzone creates a new MsgSellOzone instance
+func NewMsgSellOzone(sender sdk.AccAddress, amount sdk.Int) MsgSellOzone {
+	return MsgSellOzone{
+		Sender: sender,
+		Amount: amount,
+	}
+}
+
+// nolint
+func (msg MsgSellOzone) Route() string { return RouterKey }
+func (msg MsgSellOzone) Type() string  { return ConstSellOzone }
+func (msg MsgSellOzone) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// GetSignBytes gets the bytes for the message signer to sign on
+func (msg MsgSellOzone) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic validity check for the AnteHandler
+func (msg MsgSellOzone) ValidateBasic() error {
+	if msg.Sender.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing sender address")
+	}
+	if msg.Amount.IsNil() || !msg.Amount.IsPositive() {
+		return ErrInvalidSellAmount
+	}
+	return nil
+}
+
+type MsgFileDelete struct {
+	FileHash string             `json:"file_hash" yaml:"file_hash"` // hash of file
+	From     sdk.AccAddress     `json:"from" yaml:"from"`           // wallet addr who will pay this tx
+	Reporter stratos.SdsAddress `json:"reporter" yaml:"reporter"`   // p2pAddr of sp node who reports this tx, empty if deleted by the uploader
+	Uploader sdk.AccAddress     `json:"uploader" yaml:"uploader"`   // owner of the file
+}
+
+// verify interface at compile time
+var _ sdk.Msg = &MsgFileDelete{}
+
+// NewMsg<Action> creates a new Msg<Action> instance
+func NewMsgFileDelete(fileHash string, from sdk.AccAddress, reporter stratos.SdsAddress, uploader sdk.AccAddress) MsgFileDelete {
+	return MsgFileDelete{
+		FileHash: fileHash,
+		From:     from,
+		Reporter: reporter,
+		Uploader: uploader,
+	}
+}
+
+// nolint
+func (msg MsgFileDelete) Route() string { return RouterKey }
+func (msg MsgFileDelete) Type() string  { return ConstFileDelete }
+
+// GetSigners requires the signature of the uploader, along with the one of the sp node owner when the sp node reports the deletion
+func (msg MsgFileDelete) GetSigners() []sdk.AccAddress {
+	if msg.From.Equals(msg.Uploader) {
+		return []sdk.AccAddress{msg.Uploader}
+	}
+	return []sdk.AccAddress{msg.From, msg.Uploader}
+}
+
+// GetSignBytes gets the bytes for the message signer to sign on
+func (msg MsgFileDelete) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic validity check for the AnteHandler
+func (msg MsgFileDelete) ValidateBasic() error {
+	if msg.From.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing sender address")
+	}
+	if msg.Uploader.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing address of file uploader")
+	}
+	if !msg.From.Equals(msg.Uploader) && msg.Reporter.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing address of tx reporter")
+	}
+	if len(msg.FileHash) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "missing file hash")
+	}
+	return nil
+}
+
+type MsgFileTransferOwnership struct {
+	FileHash string         `json:"file_hash" yaml:"file_hash"` // hash of file
+	Uploader sdk.AccAddress `json:"uploader" yaml:"uploader"`   // current owner of the file
+	NewOwner sdk.AccAddress `json:"new_owner" yaml:"new_owner"` // new owner of the file
+}
+
+// verify interface at compile time
+var _ sdk.Msg = &MsgFileTransferOwnership{}
+
+// NewMsg<Action> creates a new Msg<Action> instance
+func NewMsgFileTransferOwnership(fileHash string, uploader sdk.AccAddress, newOwner sdk.AccAddress) MsgFileTransferOwnership {
+	return MsgFileTransferOwnership{
+		FileHash: fileHash,
+		Uploader: uploader,
+		NewOwner: newOwner,
+	}
+}
+
+// nolint
+func (msg MsgFileTransferOwnership) Route() string { return RouterKey }
+func (msg MsgFileTransferOwnership) Type() string  { return ConstFileTransferOwnership }
+func (msg MsgFileTransferOwnership) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Uploader}
+}
+
+// GetSignBytes gets the bytes for the message signer to sign on
+func (msg MsgFileTransferOwnership) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic validity check for the AnteHandler
+func (msg MsgFileTransferOwnership) ValidateBasic() error {
+	if msg.Uploader.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing address of file uploader")
+	}
+	if msg.NewOwner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing address of new owner")
+	}
+	if msg.Uploader.Equals(msg.NewOwner) {
+		return ErrSameFileOwner
+	}
+	if len(msg.FileHash) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "missing file hash")
+	}
+	return nil
+}
+
+type MsgFileAcknowledge struct {
+	Sender       sdk.AccAddress     `json:"sender" yaml:"sender"`               // owner of the resource node
+	ResourceNode stratos.SdsAddress `json:"resource_node" yaml:"resource_node"` // p2pAddr of the resource node holding the file
+	FileHash     string             `json:"file_hash" yaml:"file_hash"`         // hash of the held file
+}
+
+// verify interface at compile time
+var _ sdk.Msg = &MsgFileAcknowledge{}
+
+// NewMsgFileAcknowledge creates a new MsgFileAcknowledge instance
+func NewMsgFileAcknowledge(sender sdk.AccAddress, resourceNode stratos.SdsAddress, fileHash string) MsgFileAcknowledge {
+	return MsgFileAcknowledge{
+		Sender:       sender,
+		ResourceNode: resourceNode,
+		FileHash:     fileHash,
+	}
+}
+
+// nolint
+func (msg MsgFileAcknowledge) Route() string { return RouterKey }
+func (msg MsgFileAcknowledge) Type() string  { return ConstFileAcknowledge }
+func (msg MsgFileAcknowledge) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// GetSignBytes gets the bytes for the message signer to sign on
+func (msg MsgFileAcknowledge) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic validity check for the AnteHandler
+func (msg MsgFileAcknowledge) ValidateBasic() error {
+	if msg.Sender.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing sender address")
+	}
+	if msg.ResourceNode.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing address of the resource node")
+	}
+	if len(msg.FileHash) == 0 {
+		return ErrEmptyFileHash
+	}
+	return nil
+}
+
+type MsgChallengeProof struct {
+	Sender       sdk.AccAddress     `json:"sender" yaml:"sender"`               // owner of the challenged resource node
+	ResourceNode stratos.SdsAddress `json:"resource_node" yaml:"resource_node"` // p2pAddr of the challenged resource node
+	FileHash     string             `json:"file_hash" yaml:"file_hash"`         // hash of the challenged file
+	Leaf         []byte             `json:"leaf" yaml:"leaf"`                   // content of the challenged chunk
+	Proof        merkle.SimpleProof `json:"proof" yaml:"proof"`                 // merkle proof of the chunk against the merkle root of the file
+}
+
+// verify interface at compile time
+var _ sdk.Msg = &MsgChallengeProof{}
+
+// NewMsgChallengeProof creates a new MsgChallengeProof instance
+func NewMsgChallengeProof(sender sdk.AccAddress, resourceNode stratos.SdsAddress, fileHash string, leaf []byte,
+	proof merkle.SimpleProof) MsgChallengeProof {
+	return MsgChallengeProof{
+		Sender:       sender,
+		ResourceNode: resourceNode,
+		FileHash:     fileHash,
+		Leaf:         leaf,
+		Proof:        proof,
+	}
+}
+
+// nolint
+func (msg MsgChallengeProof) Route() string { return RouterKey }
+func (msg MsgChallengeProof) Type() string  { return ConstChallengeProof }
+func (msg MsgChallengeProof) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// GetSignBytes gets the bytes for the message signer to sign on
+func (msg MsgChallengeProof) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic validity check for the AnteHandler
+func (msg MsgChallengeProof) ValidateBasic() error {
+	if msg.Sender.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing sender address")
+	}
+	if msg.ResourceNode.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing address of the resource node")
+	}
+	if len(msg.FileHash) == 0 {
+		return ErrEmptyFileHash
+	}
+	if len(msg.Leaf) == 0 {
+		return ErrEmptyProof
+	}
+	if err := msg.Proof.ValidateBasic(); err != nil {
+		return sdkerrors.Wrap(ErrEmptyProof, err.Error())
+	}
+	return nil
+}
+
+type MsgCreateDeal struct {
+	Owner    sdk.AccAddress `json:"owner" yaml:"owner"`         // uploader of the file paying for the storage
+	FileHash string         `json:"file_hash" yaml:"file_hash"` // hash of file
+	FileSize uint64         `json:"file_size" yaml:"file_size"` // size of the file in bytes
+	Duration int64          `json:"duration" yaml:"duration"`   // number of epochs the storage is paid for
+	Escrow   sdk.Coin       `json:"escrow" yaml:"escrow"`       // payment locked for the whole duration
+}
+
+// verify interface at compile time
+var _ sdk.Msg = &MsgCreateDeal{}
+
+// NewMsgCreateDeal creates a new MsgCreateDeal instance
+func NewMsgCreateDeal(owner sdk.AccAddress, fileHash string, fileSize uint64, duration int64, escrow sdk.Coin) MsgCreateDeal {
+	return MsgCreateDeal{
+		Owner:    owner,
+		FileHash: fileHash,
+		FileSize: fileSize,
+		Duration: duration,
+		Escrow:   escrow,
+	}
+}
+
+// nolint
+func (msg MsgCreateDeal) Route() string { return RouterKey }
+func (msg MsgCreateDeal) Type() string  { return ConstCreateDeal }
+func (msg MsgCreateDeal) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+// GetSignBytes gets the bytes for the message signer to sign on
+func (msg MsgCreateDeal) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic validity check for the AnteHandler
+func (msg MsgCreateDeal) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing owner address")
+	}
+	if len(msg.FileHash) == 0 {
+		return ErrEmptyFileHash
+	}
+	if msg.FileSize == 0 {
+		return ErrInvalidFileSize
+	}
+	if msg.Duration <= 0 {
+		return ErrInvalidDealDuration
+	}
+	if !msg.Escrow.IsValid() || !msg.Escrow.IsPositive() {
+		return ErrInvalidCoins
+	}
 	return nil
 }
@@ -17,16 +17,44 @@ const (
 	DefaultBondDenom  = "ustos"
 )
 
+var (
+	// DefaultExitFeeRate charges no fee when selling uoz back
+	DefaultExitFeeRate = sdk.ZeroDec()
+	// DefaultChallengeSlashing is the uoz equivalent slashed from a resource node failing a challenge
+	DefaultChallengeSlashing = sdk.ZeroInt()
+)
+
+const (
+	DefaultChallengeInterval  int64  = 100 // blocks between two rounds of challenges
+	DefaultChallengeDeadline  int64  = 50  // blocks a challenged resource node has to answer
+	DefaultChallengesPerRound uint32 = 1
+	DefaultDealEpochLength    int64  = 100 // blocks between two payments of the storage deals
+)
+
 // Parameter store keys
 var (
-	KeyBondDenom = []byte("BondDenom")
+	KeyBondDenom   = []byte("BondDenom")
+	KeyExitFeeRate = []byte("ExitFeeRate")
+
+	KeyChallengeInterval  = []byte("ChallengeInterval")
+	KeyChallengeDeadline  = []byte("ChallengeDeadline")
+	KeyChallengesPerRound = []byte("ChallengesPerRound")
+	KeyChallengeSlashing  = []byte("ChallengeSlashing")
+	KeyDealEpochLength    = []byte("DealEpochLength")
 )
 
 var _ subspace.ParamSet = &Params{}
 
 // Params - used for initializing default parameter for sds at genesis
 type Params struct {
-	BondDenom string `json:"bond_denom" yaml:"bond_denom"` // bondable coin denomination
+	BondDenom   string  `json:"bond_denom" yaml:"bond_denom"`       // bondable coin denomination
+	ExitFeeRate sdk.Dec `json:"exit_fee_rate" yaml:"exit_fee_rate"` // share of the refund kept in the prepay pool when uoz is sold back
+
+	ChallengeInterval  int64   `json:"challenge_interval" yaml:"challenge_interval"`     // blocks between two rounds of challenges, 0 disables the challenges
+	ChallengeDeadline  int64   `json:"challenge_deadline" yaml:"challenge_deadline"`     // blocks a challenged resource node has to answer
+	ChallengesPerRound uint32  `json:"challenges_per_round" yaml:"challenges_per_round"` // max number of challenges issued in a round
+	ChallengeSlashing  sdk.Int `json:"challenge_slashing" yaml:"challenge_slashing"`     // uoz equivalent slashed from a resource node failing a challenge
+	DealEpochLength    int64   `json:"deal_epoch_length" yaml:"deal_epoch_length"`       // blocks between two payments of the storage deals
 }
 
 // ParamKeyTable for sds module
@@ -35,28 +63,49 @@ func ParamKeyTable() params.KeyTable {
 }
 
 // NewParams creates a new Params object
-func NewParams(bondDenom string) Params {
+func NewParams(bondDenom string, exitFeeRate sdk.Dec, challengeInterval, challengeDeadline int64,
+	challengesPerRound uint32, challengeSlashing sdk.Int, dealEpochLength int64) Params {
 	return Params{
-		BondDenom: bondDenom,
+		BondDenom:          bondDenom,
+		ExitFeeRate:        exitFeeRate,
+		ChallengeInterval:  challengeInterval,
+		ChallengeDeadline:  challengeDeadline,
+		ChallengesPerRound: challengesPerRound,
+		ChallengeSlashing:  challengeSlashing,
+		DealEpochLength:    dealEpochLength,
 	}
 }
 
 // DefaultParams defines the parameters for this module
 func DefaultParams() Params {
-	return NewParams(DefaultBondDenom)
+	return NewParams(DefaultBondDenom, DefaultExitFeeRate, DefaultChallengeInterval, DefaultChallengeDeadline,
+		DefaultChallengesPerRound, DefaultChallengeSlashing, DefaultDealEpochLength)
 }
 
 // String implements the stringer interface for Params
 func (p Params) String() string {
 	return fmt.Sprintf(`Params:
-	BondDenom:			%s`,
-		p.BondDenom)
+	BondDenom:			%s
+	ExitFeeRate:			%s
+	ChallengeInterval:		%d
+	ChallengeDeadline:		%d
+	ChallengesPerRound:		%d
+	ChallengeSlashing:		%s
+	DealEpochLength:		%d`,
+		p.BondDenom, p.ExitFeeRate, p.ChallengeInterval, p.ChallengeDeadline, p.ChallengesPerRound, p.ChallengeSlashing,
+		p.DealEpochLength)
 }
 
 // ParamSetPairs - Implements params.ParamSet
 func (p *Params) ParamSetPairs() params.ParamSetPairs {
 	return params.ParamSetPairs{
 		params.NewParamSetPair(KeyBondDenom, &p.BondDenom, validateBondDenom),
+		params.NewParamSetPair(KeyExitFeeRate, &p.ExitFeeRate, validateExitFeeRate),
+		params.NewParamSetPair(KeyChallengeInterval, &p.ChallengeInterval, validateChallengeInterval),
+		params.NewParamSetPair(KeyChallengeDeadline, &p.ChallengeDeadline, validateChallengeDeadline),
+		params.NewParamSetPair(KeyChallengesPerRound, &p.ChallengesPerRound, validateChallengesPerRound),
+		params.NewParamSetPair(KeyChallengeSlashing, &p.ChallengeSlashing, validateChallengeSlashing),
+		params.NewParamSetPair(KeyDealEpochLength, &p.DealEpochLength, validateDealEpochLength),
 	}
 }
 
@@ -76,9 +125,98 @@ func validateBondDenom(i interface{}) error {
 	return nil
 }
 
+func validateExitFeeRate(i interface{}) error {
+	v, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v.IsNil() || v.IsNegative() || v.GTE(sdk.OneDec()) {
+		return fmt.Errorf("exit fee rate must be in [0, 1): %s", v)
+	}
+
+	return nil
+}
+
+func validateChallengeInterval(i interface{}) error {
+	v, ok := i.(int64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v < 0 {
+		return fmt.Errorf("challenge interval must not be negative: %d", v)
+	}
+
+	return nil
+}
+
+func validateChallengeDeadline(i interface{}) error {
+	v, ok := i.(int64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v <= 0 {
+		return fmt.Errorf("challenge deadline must be positive: %d", v)
+	}
+
+	return nil
+}
+
+func validateChallengesPerRound(i interface{}) error {
+	_, ok := i.(uint32)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	return nil
+}
+
+func validateChallengeSlashing(i interface{}) error {
+	v, ok := i.(sdk.Int)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v.IsNil() || v.IsNegative() {
+		return fmt.Errorf("challenge slashing must not be negative: %s", v)
+	}
+
+	return nil
+}
+
+func validateDealEpochLength(i interface{}) error {
+	v, ok := i.(int64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v <= 0 {
+		return fmt.Errorf("deal epoch length must be positive: %d", v)
+	}
+
+	return nil
+}
+
 func (p Params) ValidateBasic() error {
 	if err := validateBondDenom(p.BondDenom); err != nil {
 		return err
 	}
+	if err := validateExitFeeRate(p.ExitFeeRate); err != nil {
+		return err
+	}
+	if err := validateChallengeInterval(p.ChallengeInterval); err != nil {
+		return err
+	}
+	if err := validateChallengeDeadline(p.ChallengeDeadline); err != nil {
+		return err
+	}
+	if err := validateChallengeSlashing(p.ChallengeSlashing); err != nil {
+		return err
+	}
+	if err := validateDealEpochLength(p.DealEpochLength); err != nil {
+		return err
+	}
 	return nil
 }
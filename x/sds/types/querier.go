@@ -1,25 +1,73 @@
 package types
 
-import "github.com/cosmos/cosmos-sdk/types"
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	stratos "github.com/stratosnet/stratos-chain/types"
+)
 
 // querier keys
 const (
-	QueryParams         = "params"
-	QueryUploadedFile   = "uploaded_file"
-	QueryPrepay         = "prepay"
-	QuerySimulatePrepay = "simulate_prepay"
-	QueryCurrUozPrice   = "curr_uoz_price"
-	QueryUozSupply      = "uoz_supply"
+	QueryParams          = "params"
+	QueryUploadedFile    = "uploaded_file"
+	QueryPrepay          = "prepay"
+	QuerySimulatePrepay  = "simulate_prepay"
+	QueryCurrUozPrice    = "curr_uoz_price"
+	QueryUozSupply       = "uoz_supply"
+	QueryFilesByUploader = "files_by_uploader"
+	QueryFilesByReporter = "files_by_reporter"
+	QueryOzoneBalance    = "ozone_balance"
+	QuerySimulateSell    = "simulate_sell_ozone"
+	QueryUozPriceHistory = "uoz_price_history"
+	QueryChallenges      = "challenges"
+	QueryDeal            = "deal"
 )
 
-// params for query 'custom/distr/validator_outstanding_rewards'
-type QueryUploadedFileParams struct {
-	Sender types.AccAddress `json:"sender" yaml:"sender"`
+// params for query 'custom/sds/files_by_uploader'
+type QueryFilesByUploaderParams struct {
+	Page     int            `json:"page" yaml:"page"`
+	Limit    int            `json:"limit" yaml:"limit"`
+	Uploader sdk.AccAddress `json:"uploader" yaml:"uploader"`
+}
+
+// creates a new instance of QueryFilesByUploaderParams
+func NewQueryFilesByUploaderParams(page, limit int, uploader sdk.AccAddress) QueryFilesByUploaderParams {
+	return QueryFilesByUploaderParams{
+		Page:     page,
+		Limit:    limit,
+		Uploader: uploader,
+	}
+}
+
+// params for query 'custom/sds/files_by_reporter'
+type QueryFilesByReporterParams struct {
+	Page     int                `json:"page" yaml:"page"`
+	Limit    int                `json:"limit" yaml:"limit"`
+	Reporter stratos.SdsAddress `json:"reporter" yaml:"reporter"`
+}
+
+// creates a new instance of QueryFilesByReporterParams
+func NewQueryFilesByReporterParams(page, limit int, reporter stratos.SdsAddress) QueryFilesByReporterParams {
+	return QueryFilesByReporterParams{
+		Page:     page,
+		Limit:    limit,
+		Reporter: reporter,
+	}
+}
+
+// params for query 'custom/sds/uoz_price_history'
+type QueryUozPriceHistoryParams struct {
+	Page       int   `json:"page" yaml:"page"`
+	Limit      int   `json:"limit" yaml:"limit"`
+	FromHeight int64 `json:"from_height" yaml:"from_height"`
+	ToHeight   int64 `json:"to_height" yaml:"to_height"` // 0 queries up to the latest snapshot
 }
 
-// creates a new instance of QueryValidatorSlashesParams
-func NewQueryUploadedFileParams(sender types.AccAddress) QueryUploadedFileParams {
-	return QueryUploadedFileParams{
-		Sender: sender,
+// creates a new instance of QueryUozPriceHistoryParams
+func NewQueryUozPriceHistoryParams(page, limit int, fromHeight, toHeight int64) QueryUozPriceHistoryParams {
+	return QueryUozPriceHistoryParams{
+		Page:       page,
+		Limit:      limit,
+		FromHeight: fromHeight,
+		ToHeight:   toHeight,
 	}
 }
@@ -2,25 +2,108 @@ package types
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	stratos "github.com/stratosnet/stratos-chain/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
 )
 
+// MaxContentTypeLength is the max length of the content type of an uploaded file
+const MaxContentTypeLength = 128
+
 type FileInfo struct {
-	Height   sdk.Int
-	Reporter stratos.SdsAddress
-	Uploader sdk.AccAddress
+	Height        sdk.Int
+	Reporter      stratos.SdsAddress
+	Uploader      sdk.AccAddress
+	FileSize      uint64
+	ReplicaCount  uint32
+	ResourceNodes []stratos.SdsAddress // resource nodes holding the shards of the file
+	ContentType   string
+	ExpiryHeight  int64  // 0 if the file never expires
+	MerkleRoot    []byte // merkle root of the chunks of the file, files without it are not challenged
+
+	AcknowledgedNodes []stratos.SdsAddress // resource nodes which acknowledged holding the file, only they are challenged
 }
 
 // constructor
-func NewFileInfo(height sdk.Int, reporter stratos.SdsAddress, uploader sdk.AccAddress) FileInfo {
+func NewFileInfo(height sdk.Int, reporter stratos.SdsAddress, uploader sdk.AccAddress, fileSize uint64, replicaCount uint32,
+	resourceNodes []stratos.SdsAddress, contentType string, expiryHeight int64, merkleRoot []byte) FileInfo {
 	return FileInfo{
-		Height:   height,
-		Reporter: reporter,
-		Uploader: uploader,
+		Height:        height,
+		Reporter:      reporter,
+		Uploader:      uploader,
+		FileSize:      fileSize,
+		ReplicaCount:  replicaCount,
+		ResourceNodes: resourceNodes,
+		ContentType:   contentType,
+		ExpiryHeight:  expiryHeight,
+		MerkleRoot:    merkleRoot,
+	}
+}
+
+// ValidateFileMetadata checks the metadata of an uploaded file
+func ValidateFileMetadata(fileSize uint64, replicaCount uint32, resourceNodes []stratos.SdsAddress, contentType string, expiryHeight int64) error {
+	if fileSize == 0 {
+		return ErrInvalidFileSize
+	}
+	if replicaCount == 0 {
+		return ErrInvalidReplicaCount
 	}
+	if len(resourceNodes) == 0 {
+		return ErrEmptyResourceNodes
+	}
+	seen := make(map[string]bool)
+	for _, resourceNode := range resourceNodes {
+		if resourceNode.Empty() {
+			return ErrEmptyResourceNodes
+		}
+		if seen[resourceNode.String()] {
+			return ErrDuplicateResourceNode
+		}
+		seen[resourceNode.String()] = true
+	}
+	if len(contentType) > MaxContentTypeLength {
+		return ErrInvalidContentType
+	}
+	if expiryHeight < 0 {
+		return ErrInvalidExpiryHeight
+	}
+	return nil
+}
+
+// ValidateMerkleRoot checks the merkle root of the chunks of an uploaded file, which is optional
+func ValidateMerkleRoot(merkleRoot []byte) error {
+	if len(merkleRoot) != 0 && len(merkleRoot) != tmhash.Size {
+		return ErrInvalidMerkleRoot
+	}
+	return nil
+}
+
+// IsFileHolder returns true if the resource node is assigned to hold the file
+func (fi FileInfo) IsFileHolder(resourceNode stratos.SdsAddress) bool {
+	for _, holder := range fi.ResourceNodes {
+		if holder.Equals(resourceNode) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasAcknowledged returns true if the resource node acknowledged holding the file
+func (fi FileInfo) HasAcknowledged(resourceNode stratos.SdsAddress) bool {
+	for _, node := range fi.AcknowledgedNodes {
+		if node.Equals(resourceNode) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsExpired returns true if the file has expired at the given height
+func (fi FileInfo) IsExpired(height int64) bool {
+	return fi.ExpiryHeight > 0 && fi.ExpiryHeight <= height
 }
 
 // MustMarshalFileInfo returns the fileInfo's bytes. Panics if fails
@@ -45,9 +128,25 @@ func UnmarshalFileInfo(cdc *codec.Codec, value []byte) (fi FileInfo, err error)
 
 // String returns a human readable string representation of a resource node.
 func (fi FileInfo) String() string {
+	resourceNodes := make([]string, 0, len(fi.ResourceNodes))
+	for _, resourceNode := range fi.ResourceNodes {
+		resourceNodes = append(resourceNodes, resourceNode.String())
+	}
+	acknowledgedNodes := make([]string, 0, len(fi.AcknowledgedNodes))
+	for _, node := range fi.AcknowledgedNodes {
+		acknowledgedNodes = append(acknowledgedNodes, node.String())
+	}
 	return fmt.Sprintf(`FileInfo:{
 		Height:				%s
   		Reporter:			%s
   		Uploader:			%s
-	}`, fi.Height.String(), fi.Reporter.String(), fi.Uploader.String())
+  		FileSize:			%d
+  		ReplicaCount:		%d
+  		ResourceNodes:		%s
+  		ContentType:		%s
+  		ExpiryHeight:		%d
+  		MerkleRoot:			%X
+  		AcknowledgedNodes:	%s
+	}`, fi.Height.String(), fi.Reporter.String(), fi.Uploader.String(), fi.FileSize, fi.ReplicaCount,
+		strings.Join(resourceNodes, ","), fi.ContentType, fi.ExpiryHeight, fi.MerkleRoot, strings.Join(acknowledgedNodes, ","))
 }